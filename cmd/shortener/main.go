@@ -2,30 +2,49 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"github.com/Totarae/URLShortener/internal/blocklist"
+	"github.com/Totarae/URLShortener/internal/botdetect"
+	"github.com/Totarae/URLShortener/internal/cache"
+	"github.com/Totarae/URLShortener/internal/canonical"
+	"github.com/Totarae/URLShortener/internal/clicks"
 	v2 "github.com/Totarae/URLShortener/internal/grpc/v2"
+	"github.com/Totarae/URLShortener/internal/healthcheck"
+	"github.com/Totarae/URLShortener/internal/linkguard"
+	"github.com/Totarae/URLShortener/internal/metadata"
+	"github.com/Totarae/URLShortener/internal/metrics"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/netguard"
 	pb "github.com/Totarae/URLShortener/internal/pkg/proto_gen"
+	"github.com/Totarae/URLShortener/internal/pubsub"
 	"github.com/Totarae/URLShortener/internal/service"
+	"github.com/Totarae/URLShortener/internal/sso"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/Totarae/URLShortener/internal/analytics"
 	"github.com/Totarae/URLShortener/internal/auth"
 	"github.com/Totarae/URLShortener/internal/config"
 	"github.com/Totarae/URLShortener/internal/database"
 	"github.com/Totarae/URLShortener/internal/handlers"
 	"github.com/Totarae/URLShortener/internal/repositories"
 	"github.com/Totarae/URLShortener/internal/router"
+	"github.com/Totarae/URLShortener/internal/tracing"
 	"github.com/Totarae/URLShortener/internal/util"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"go.uber.org/zap"
 )
 
@@ -57,8 +76,29 @@ func main() {
 	var store *util.URLStore
 	var repo *repositories.URLRepository
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: "url-shortener",
+		Exporter:    cfg.TracingExporter,
+		Endpoint:    cfg.TracingEndpoint,
+		Insecure:    cfg.TracingInsecure,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		logger.Fatal("Ошибка настройки трассировки", zap.Error(err))
+	}
+
+	var appMetrics *metrics.Metrics
+	var dbTracers []pgx.QueryTracer
+	if cfg.MetricsEnabled {
+		appMetrics = metrics.New()
+		dbTracers = append(dbTracers, appMetrics.QueryTracer())
+	}
+	if cfg.TracingExporter != tracing.ExporterNone {
+		dbTracers = append(dbTracers, tracing.QueryTracer{})
+	}
+
 	if cfg.Mode == "database" {
-		db, err = database.NewDB(logger)
+		db, err = database.NewDB(logger, dbTracers...)
 		if err != nil {
 			logger.Error("Ошибка подключения к базе данных", zap.Error(err))
 			return
@@ -74,11 +114,17 @@ func main() {
 			return
 		}
 		repo = repositories.NewURLRepository(db)
+		if appMetrics != nil {
+			appMetrics.Register(metrics.NewPoolCollector(db.Pool))
+		}
 	} else {
 		store = util.NewURLStore(cfg.FileStoragePath)
 	}
 
-	authService := auth.New("rainbow-secret-key") // секрет должен быть из .env или конфигурации
+	authService, err := newAuth(cfg, logger)
+	if err != nil {
+		logger.Fatal("Не удалось настроить ключи подписи cookie", zap.Error(err))
+	}
 
 	var trustedNet *net.IPNet
 	if cfg.TrustedSubnet != "" {
@@ -92,9 +138,114 @@ func main() {
 	// Передача базового URL в обработчики
 	// создаем сервис и хендлер
 	svc := service.NewShortenerService(repo, store, logger, cfg.Mode, cfg.BaseURL)
+	svc.Guard = linkguard.New(
+		append([]string{cfg.BaseURL}, config.SplitList(cfg.OwnDomains)...),
+		config.SplitList(cfg.KnownShorteners),
+		cfg.UnwrapShorteners,
+	)
+	svc.Canonicalizer = canonical.New(canonical.Options{
+		SortQuery:   cfg.CanonicalSortQuery,
+		StripParams: config.SplitList(cfg.CanonicalStripParams),
+	})
+	if cfg.BlocklistPath != "" {
+		list, err := blocklist.Load(cfg.BlocklistPath, logger)
+		if err != nil {
+			logger.Fatal("Не удалось загрузить blocklist", zap.String("path", cfg.BlocklistPath), zap.Error(err))
+		}
+		if cfg.BlocklistRetroactive {
+			list.OnReload(func(added []blocklist.Rule) {
+				n, err := svc.DisableMatching(context.Background(), added)
+				if err != nil {
+					logger.Error("Ошибка отключения ссылок по blocklist", zap.Error(err))
+					return
+				}
+				logger.Info("Ссылки отключены по новым правилам blocklist", zap.Int("count", n))
+			})
+		}
+		svc.Blocklist = list
+		logger.Info("Blocklist загружен", zap.String("path", cfg.BlocklistPath), zap.Int("rules", len(list.Rules())))
+	}
+
+	if cfg.HealthCheckInterval > 0 {
+		checker := healthcheck.New()
+		checker.Client = netguard.NewClient(cfg.HealthCheckTimeout, false)
+		checker.Concurrency = cfg.HealthCheckConcurrency
+		checker.HostInterval = cfg.HealthCheckHostInterval
+		svc.Checker = checker
+	}
+
+	if cfg.MetadataWorkers > 0 {
+		fetcher := metadata.NewFetcher()
+		fetcher.Client = netguard.NewClient(cfg.MetadataTimeout, false)
+		fetcher.MaxBytes = cfg.MetadataMaxBytes
+		svc.Metadata = metadata.NewWorker(fetcher, cfg.MetadataQueueSize, svc.SaveMetadata, logger)
+	}
+
+	svc.TrashRetention = cfg.TrashRetention
+	if cfg.Mode == "file" {
+		svc.APIKeys.Path = cfg.APIKeysFile
+		svc.Accounts.Path = cfg.AccountsFile
+		svc.Orgs.Path = cfg.OrgsFile
+		svc.Bans.Path = cfg.BansFile
+	}
+	if cfg.SessionTTL > 0 {
+		svc.SessionTTL = cfg.SessionTTL
+	}
+	authService.APIKeys = svc
+	authService.Sessions = svc
+	if cfg.ResolveCacheSize > 0 {
+		svc.Cache = cache.New[*model.URLObject](cfg.ResolveCacheSize, cfg.ResolveCacheTTL)
+	}
+
+	svc.Bots = botdetect.New()
+	if cfg.BotRulesPath != "" {
+		bots, err := botdetect.Load(cfg.BotRulesPath, logger)
+		if err != nil {
+			logger.Fatal("Не удалось загрузить правила ботов", zap.String("path", cfg.BotRulesPath), zap.Error(err))
+		}
+		svc.Bots = bots
+		logger.Info("Правила ботов загружены", zap.String("path", cfg.BotRulesPath), zap.Int("rules", len(bots.Rules())))
+	}
+
+	if cfg.ClicksBufferSize > 0 {
+		var sink clicks.Sink
+		if cfg.Mode == "database" {
+			sink = clicks.SinkFunc(repo.SaveClicks)
+		} else {
+			svc.Sketches = &analytics.SketchFile{Dir: cfg.ClicksSketchDir}
+			sink = clicks.MultiSink{&clicks.FileSink{Path: cfg.ClicksFilePath}, svc.Sketches}
+		}
+		tracker := clicks.NewTracker(sink, cfg.ClicksBufferSize, cfg.ClicksBatchSize, cfg.ClicksFlushInterval, logger)
+		tracker.Salt = cfg.ClicksIPSalt
+		if tracker.Salt == "" {
+			tracker.Salt = uuid.NewString()
+			logger.Warn("CLICKS_IP_SALT не задан, хеши IP будут меняться при каждом запуске")
+		}
+		tracker.Start()
+		svc.Clicks = tracker
+		if cfg.Mode != "database" {
+			svc.ClicksPath = cfg.ClicksFilePath
+		}
+	}
+
+	if cfg.StreamBufferSize > 0 {
+		svc.ClickStream = pubsub.New[model.Click](cfg.StreamBufferSize)
+		svc.StreamHeartbeat = cfg.StreamHeartbeat
+	}
+
+	if appMetrics != nil {
+		registerServiceMetrics(appMetrics, svc)
+	}
+
 	handler := handlers.NewHandler(svc, logger, authService, trustedNet)
+	if cfg.OIDCIssuerURL != "" {
+		if handler.SSO, err = newSSO(context.Background(), cfg); err != nil {
+			logger.Fatal("Ошибка настройки входа через OpenID Connect", zap.Error(err))
+		}
+		logger.Info("Вход через OpenID Connect включён", zap.String("issuer", cfg.OIDCIssuerURL))
+	}
 
-	r := router.NewRouter(handler, logger)
+	r := router.NewRouter(handler, logger, appMetrics, cfg.MetricsAddress == "")
 
 	server := &http.Server{
 		Addr:    cfg.ServerAddress,
@@ -112,8 +263,22 @@ func main() {
 		if err != nil {
 			logger.Fatal("Ошибка запуска gRPC сервера", zap.Error(err))
 		}
-		grpcServer = grpc.NewServer()
-		pb.RegisterShortenerServiceServer(grpcServer, v2.NewGRPCServer(svc))
+		opts := []grpc.ServerOption{
+			tracing.ServerOption(),
+			grpc.ChainUnaryInterceptor(v2.UnaryAuthInterceptor(authService)),
+			grpc.ChainStreamInterceptor(v2.StreamAuthInterceptor(authService)),
+		}
+		if appMetrics != nil {
+			opts = append(opts,
+				grpc.ChainUnaryInterceptor(appMetrics.UnaryServerInterceptor()),
+				grpc.ChainStreamInterceptor(appMetrics.StreamServerInterceptor()),
+			)
+		}
+		grpcServer = grpc.NewServer(opts...)
+		grpcHandler := v2.NewGRPCServer(svc)
+		grpcHandler.TrustedSubnet = trustedNet
+		grpcHandler.Auth = authService
+		pb.RegisterShortenerServiceServer(grpcServer, grpcHandler)
 		reflection.Register(grpcServer) // достучатсья из курла
 
 		logger.Info("gRPC сервер запущен", zap.String("address", grpcAddr))
@@ -127,6 +292,51 @@ func main() {
 		syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 	defer stop()
 
+	if svc.Blocklist != nil {
+		go func() {
+			if err := svc.Blocklist.Watch(ctx); err != nil {
+				logger.Error("Ошибка наблюдения за blocklist", zap.Error(err))
+			}
+		}()
+	}
+
+	if cfg.BotRulesPath != "" {
+		go func() {
+			if err := svc.Bots.Watch(ctx); err != nil {
+				logger.Error("Ошибка наблюдения за правилами ботов", zap.Error(err))
+			}
+		}()
+	}
+
+	if svc.Metadata != nil {
+		go svc.Metadata.Run(ctx, cfg.MetadataWorkers)
+	}
+
+	if svc.Checker != nil {
+		go healthcheck.Schedule(ctx, cfg.HealthCheckInterval, func(ctx context.Context) {
+			n, err := svc.CheckDestinations(ctx)
+			if err != nil {
+				logger.Error("Ошибка проверки доступности ссылок", zap.Error(err))
+				return
+			}
+			logger.Info("Проверка доступности ссылок завершена", zap.Int("checked", n))
+		})
+	}
+
+	// Отдельный сервер метрик
+	var metricsServer *http.Server
+	if appMetrics != nil && cfg.MetricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", appMetrics.Handler())
+		metricsServer = &http.Server{Addr: cfg.MetricsAddress, Handler: mux}
+		go func() {
+			logger.Info("Сервер метрик запущен", zap.String("address", cfg.MetricsAddress))
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("Ошибка сервера метрик", zap.Error(err))
+			}
+		}()
+	}
+
 	logger.Info("Сервер запущен на ", zap.String("address", cfg.ServerAddress))
 
 	// Запуск сервера
@@ -163,11 +373,27 @@ func main() {
 		logger.Error("Ошибка при завершении сервера", zap.Error(err))
 	}
 
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Ошибка при завершении сервера метрик", zap.Error(err))
+		}
+	}
+
 	if grpcServer != nil {
 		logger.Info("Завершаем gRPC сервер...")
 		grpcServer.GracefulStop()
 	}
 
+	// Дописываем накопленные события переходов
+	if svc.Clicks != nil {
+		if err := svc.Clicks.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Не удалось записать события переходов", zap.Error(err))
+		}
+		stats := svc.Clicks.Stats()
+		logger.Info("Запись событий переходов завершена",
+			zap.Uint64("written", stats.Written), zap.Uint64("dropped", stats.Dropped), zap.Uint64("failed", stats.Failed))
+	}
+
 	// Сохраняем данные из хранилища
 	if cfg.Mode == "file" && store != nil {
 		if err := store.SaveToFile(); err != nil {
@@ -177,10 +403,104 @@ func main() {
 		}
 	}
 
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Ошибка при завершении трассировки", zap.Error(err))
+	}
+
 	logger.Info("Сервер завершён корректно")
 
 }
 
+// newAuth создаёт сервис аутентификации с ключами и атрибутами cookie из конфигурации.
+// Без ключа генерируется случайный: cookie перестанут приниматься после перезапуска.
+func newAuth(cfg *config.Config, logger *zap.Logger) (*auth.Auth, error) {
+	var keys []auth.Key
+	switch {
+	case cfg.AuthKeyFile != "":
+		var err error
+		if keys, err = auth.LoadKeys(cfg.AuthKeyFile); err != nil {
+			return nil, err
+		}
+	case cfg.AuthSecret != "":
+		keys = []auth.Key{{ID: auth.DefaultKeyID, Secret: []byte(cfg.AuthSecret)}}
+	default:
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		keys = []auth.Key{{ID: "ephemeral", Secret: secret}}
+		logger.Warn("AUTH_SECRET и AUTH_KEY_FILE не заданы, cookie будут недействительны после перезапуска")
+	}
+
+	sameSite, err := auth.ParseSameSite(cfg.AuthCookieSameSite)
+	if err != nil {
+		return nil, err
+	}
+	a, err := auth.NewWithKeys(keys, auth.CookieOptions{
+		Secure:   cfg.AuthCookieSecure || cfg.EnableHTTPS,
+		SameSite: sameSite,
+		Domain:   cfg.AuthCookieDomain,
+		MaxAge:   cfg.AuthCookieMaxAge,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.AuthTokenTTL > 0 {
+		a.TokenTTL = cfg.AuthTokenTTL
+	}
+	a.Admins = config.SplitList(cfg.AdminUsers)
+	return a, nil
+}
+
+// newSSO загружает настройки провайдера OpenID Connect.
+func newSSO(ctx context.Context, cfg *config.Config) (*sso.Provider, error) {
+	redirectURL := cfg.OIDCRedirectURL
+	if redirectURL == "" {
+		redirectURL = strings.TrimSuffix(cfg.BaseURL, "/") + "/api/auth/oidc/callback"
+	}
+	discoveryCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	return sso.New(discoveryCtx, sso.Config{
+		IssuerURL:    cfg.OIDCIssuerURL,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       config.SplitList(cfg.OIDCScopes),
+	})
+}
+
+// registerServiceMetrics публикует состояние кеша и фоновых очередей сервиса.
+func registerServiceMetrics(m *metrics.Metrics, svc *service.ShortenerService) {
+	if svc.Cache != nil {
+		m.RegisterCache("resolve", svc.Cache.Stats, svc.Cache.Len)
+	}
+	m.GaugeFunc("pending_deletions", "Фоновые удаления ссылок, которые ещё не завершены.", func() float64 {
+		return float64(svc.PendingDeletions())
+	})
+	if svc.Clicks != nil {
+		m.GaugeFunc("clicks_buffered", "События переходов, ожидающие записи.", func() float64 {
+			return float64(svc.Clicks.Stats().Buffered)
+		})
+		m.CounterFunc("clicks_dropped_total", "События переходов, отброшенные из-за переполнения буфера.", func() float64 {
+			return float64(svc.Clicks.Stats().Dropped)
+		})
+		m.CounterFunc("clicks_written_total", "Записанные события переходов.", func() float64 {
+			return float64(svc.Clicks.Stats().Written)
+		})
+		m.CounterFunc("clicks_failed_total", "События переходов, потерянные из-за ошибки записи.", func() float64 {
+			return float64(svc.Clicks.Stats().Failed)
+		})
+	}
+	if svc.ClickStream != nil {
+		m.GaugeFunc("stream_subscribers", "Подписчики потока переходов.", func() float64 {
+			return float64(svc.ClickStream.Subscribers())
+		})
+		m.CounterFunc("stream_evicted_total", "Подписчики потока переходов, отключённые за медленное чтение.", func() float64 {
+			return float64(svc.ClickStream.Evicted())
+		})
+	}
+}
+
 // runPgMigrations runs Postgres migrations
 func runPgMigrations(cfg *config.Config) error {
 
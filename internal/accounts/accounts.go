@@ -0,0 +1,202 @@
+// Package accounts хранит учётные записи пользователей и их серверные сессии.
+//
+// Пароли хешируются argon2id. Токен сессии — случайная строка в cookie;
+// в хранилище попадает только его SHA-256, поэтому утечка хранилища
+// не даёт войти по чужой сессии.
+package accounts
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+)
+
+// tokenBytes — длина токена сессии в байтах до hex-кодирования.
+const tokenBytes = 32
+
+// NormalizeEmail приводит адрес к виду, в котором он хранится и ищется.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NewSessionToken создаёт токен сессии и возвращает его вместе с хешем.
+func NewSessionToken() (token, hash string, err error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(buf)
+	return token, HashToken(token), nil
+}
+
+// HashToken возвращает hex SHA-256 токена сессии.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// FileStore хранит учётные записи и сессии в JSON-файле в режимах file и in-memory.
+// Пустой Path хранит их только в памяти.
+type FileStore struct {
+	Path string
+
+	mu     sync.Mutex
+	loaded bool
+	data   fileData
+}
+
+type fileData struct {
+	Accounts map[string]model.Account `json:"accounts"`
+	Sessions map[string]model.Session `json:"sessions"`
+	// Identities сопоставляет "издатель субъект" внешнего провайдера пользователю.
+	Identities map[string]string `json:"identities,omitempty"`
+}
+
+// CreateAccount сохраняет учётную запись. Возвращает false, если адрес уже занят.
+func (f *FileStore) CreateAccount(acc model.Account) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return false, err
+	}
+	for _, existing := range f.data.Accounts {
+		if existing.Email == acc.Email {
+			return false, nil
+		}
+	}
+	f.data.Accounts[acc.ID] = acc
+	return true, f.save()
+}
+
+// GetAccountByEmail возвращает учётную запись по адресу.
+func (f *FileStore) GetAccountByEmail(email string) (model.Account, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return model.Account{}, false, err
+	}
+	for _, acc := range f.data.Accounts {
+		if acc.Email == email {
+			return acc, true, nil
+		}
+	}
+	return model.Account{}, false, nil
+}
+
+// CreateSession сохраняет сессию и удаляет истёкшие.
+func (f *FileStore) CreateSession(s model.Session) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	now := time.Now()
+	for id, existing := range f.data.Sessions {
+		if !existing.ExpiresAt.After(now) {
+			delete(f.data.Sessions, id)
+		}
+	}
+	f.data.Sessions[s.ID] = s
+	return f.save()
+}
+
+// GetSession возвращает сессию по хешу токена, включая истёкшие.
+func (f *FileStore) GetSession(id string) (model.Session, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return model.Session{}, false, err
+	}
+	s, ok := f.data.Sessions[id]
+	return s, ok, nil
+}
+
+// DeleteSession удаляет сессию по хешу токена.
+func (f *FileStore) DeleteSession(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	if _, ok := f.data.Sessions[id]; !ok {
+		return nil
+	}
+	delete(f.data.Sessions, id)
+	return f.save()
+}
+
+// ResolveIdentity возвращает пользователя, сопоставленного субъекту провайдера.
+// Если сопоставления нет, субъект закрепляется за newUserID.
+func (f *FileStore) ResolveIdentity(issuer, subject, newUserID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return "", err
+	}
+	key := issuer + " " + subject
+	if userID, ok := f.data.Identities[key]; ok {
+		return userID, nil
+	}
+	f.data.Identities[key] = newUserID
+	return newUserID, f.save()
+}
+
+// load читает файл при первом обращении; отсутствующий файл означает пустое хранилище.
+func (f *FileStore) load() error {
+	if f.loaded {
+		return nil
+	}
+	f.data = fileData{}
+	if f.Path != "" {
+		raw, err := os.ReadFile(f.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &f.data); err != nil {
+				return err
+			}
+		}
+	}
+	if f.data.Accounts == nil {
+		f.data.Accounts = make(map[string]model.Account)
+	}
+	if f.data.Sessions == nil {
+		f.data.Sessions = make(map[string]model.Session)
+	}
+	if f.data.Identities == nil {
+		f.data.Identities = make(map[string]string)
+	}
+	f.loaded = true
+	return nil
+}
+
+// save атомарно переписывает файл.
+func (f *FileStore) save() error {
+	if f.Path == "" {
+		return nil
+	}
+	raw, err := json.Marshal(f.data)
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
@@ -0,0 +1,93 @@
+package accounts_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/accounts"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastParams = accounts.Params{Memory: 1024, Time: 1, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+func TestPassword(t *testing.T) {
+	hash, err := accounts.HashPassword("correct horse", fastParams)
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+	ok, err := accounts.VerifyPassword("correct horse", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = accounts.VerifyPassword("battery staple", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := accounts.HashPassword("correct horse", fastParams)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "соль случайная")
+
+	for _, bad := range []string{"", "plain", "$2a$10$bcrypt", "$argon2id$v=19$m=x$salt$hash"} {
+		_, err := accounts.VerifyPassword("x", bad)
+		assert.ErrorIs(t, err, accounts.ErrMalformedHash, bad)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	store := &accounts.FileStore{Path: path}
+
+	created, err := store.CreateAccount(model.Account{ID: "u1", Email: "a@example.com", PasswordHash: "h"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = store.CreateAccount(model.Account{ID: "u2", Email: "a@example.com", PasswordHash: "h"})
+	require.NoError(t, err)
+	assert.False(t, created, "адрес уже занят")
+
+	token, hash, err := accounts.NewSessionToken()
+	require.NoError(t, err)
+	assert.Equal(t, accounts.HashToken(token), hash)
+	require.NoError(t, store.CreateSession(model.Session{ID: "expired", UserID: "u1", ExpiresAt: time.Now().Add(-time.Minute)}))
+	require.NoError(t, store.CreateSession(model.Session{ID: hash, UserID: "u1", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	// Данные переживают перезапуск, истёкшие сессии вычищаются
+	reopened := &accounts.FileStore{Path: path}
+	acc, found, err := reopened.GetAccountByEmail("a@example.com")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "u1", acc.ID)
+	_, found, err = reopened.GetSession("expired")
+	require.NoError(t, err)
+	assert.False(t, found)
+	session, found, err := reopened.GetSession(hash)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "u1", session.UserID)
+
+	require.NoError(t, reopened.DeleteSession(hash))
+	_, found, err = reopened.GetSession(hash)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestFileStore_ResolveIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	store := &accounts.FileStore{Path: path}
+
+	userID, err := store.ResolveIdentity("https://idp", "sub-1", "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", userID)
+	userID, err = store.ResolveIdentity("https://idp", "sub-1", "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", userID, "субъект уже сопоставлен")
+	userID, err = store.ResolveIdentity("https://other", "sub-1", "u3")
+	require.NoError(t, err)
+	assert.Equal(t, "u3", userID, "субъекты разных издателей различаются")
+
+	reopened := &accounts.FileStore{Path: path}
+	userID, err = reopened.ResolveIdentity("https://idp", "sub-1", "u4")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", userID)
+}
@@ -0,0 +1,282 @@
+// Package analytics строит статистику переходов по ссылке: временной ряд
+// по часам или дням и распределения по источникам, браузерам, ОС и странам.
+package analytics
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/useragent"
+)
+
+// Интервалы агрегации.
+const (
+	IntervalHour = "hour"
+	IntervalDay  = "day"
+)
+
+// MaxBuckets ограничивает длину временного ряда в одном ответе.
+const MaxBuckets = 1000
+
+// TopN — количество значений в каждом распределении.
+const TopN = 10
+
+// Direct — имя источника для переходов без заголовка Referer.
+const Direct = "direct"
+
+// Unknown — имя страны, если прокси не передал её код.
+const Unknown = "unknown"
+
+var (
+	// ErrInvalidInterval — неизвестный интервал агрегации.
+	ErrInvalidInterval = errors.New("interval must be hour or day")
+	// ErrInvalidRange — некорректный или слишком длинный период.
+	ErrInvalidRange = errors.New("invalid time range")
+)
+
+// Query — параметры запроса статистики.
+type Query struct {
+	From     time.Time
+	To       time.Time
+	Interval string
+	// IncludeBots учитывает переходы ботов наравне с переходами людей.
+	IncludeBots bool
+}
+
+// ParseQuery разбирает параметры from, to и interval. Даты принимаются
+// в формате RFC 3339 или YYYY-MM-DD. По умолчанию interval=day, to — текущий
+// момент, from — 30 дней (для hour — 48 часов) до to.
+// Границы выравниваются по началу интервала в UTC.
+func ParseQuery(from, to, interval string, now time.Time) (Query, error) {
+	q := Query{Interval: strings.ToLower(interval)}
+	if q.Interval == "" {
+		q.Interval = IntervalDay
+	}
+	if q.Interval != IntervalHour && q.Interval != IntervalDay {
+		return Query{}, ErrInvalidInterval
+	}
+
+	var err error
+	q.To = now.UTC()
+	if to != "" {
+		if q.To, err = parseTime(to); err != nil {
+			return Query{}, fmt.Errorf("%w: to: %v", ErrInvalidRange, err)
+		}
+	}
+	if from != "" {
+		if q.From, err = parseTime(from); err != nil {
+			return Query{}, fmt.Errorf("%w: from: %v", ErrInvalidRange, err)
+		}
+	} else if q.Interval == IntervalHour {
+		q.From = q.To.Add(-48 * time.Hour)
+	} else {
+		q.From = q.To.AddDate(0, 0, -30)
+	}
+
+	q.From = q.Truncate(q.From)
+	if len(to) == len(time.DateOnly) {
+		// Дата в to включается целиком.
+		q.To = q.To.AddDate(0, 0, 1)
+	}
+	// Незавершённый интервал, в который попадает to, входит в период.
+	if end := q.Truncate(q.To); !end.Equal(q.To) {
+		q.To = q.next(end)
+	}
+	if !q.From.Before(q.To) {
+		return Query{}, fmt.Errorf("%w: from must be before to", ErrInvalidRange)
+	}
+	if q.bucketCount() > MaxBuckets {
+		return Query{}, fmt.Errorf("%w: more than %d buckets", ErrInvalidRange, MaxBuckets)
+	}
+	return q, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+// Truncate возвращает начало интервала, в который попадает t.
+func (q Query) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if q.Interval == IntervalHour {
+		return t.Truncate(time.Hour)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (q Query) next(t time.Time) time.Time {
+	if q.Interval == IntervalHour {
+		return t.Add(time.Hour)
+	}
+	return t.AddDate(0, 0, 1)
+}
+
+func (q Query) bucketCount() int {
+	n := 0
+	for t := q.From; t.Before(q.To) && n <= MaxBuckets; t = q.next(t) {
+		n++
+	}
+	return n
+}
+
+// Contains сообщает, попадает ли момент t в период запроса.
+func (q Query) Contains(t time.Time) bool {
+	return !t.Before(q.From) && t.Before(q.To)
+}
+
+// Add учитывает переход в сводке. Переходы ботов только подсчитываются,
+// если запрос их не включает.
+func Add(summary *model.ClickSummary, q Query, click model.Click) {
+	if click.Bot {
+		summary.Bots++
+		if !q.IncludeBots {
+			return
+		}
+	}
+	summary.Buckets[q.Truncate(click.Time)]++
+	summary.Referrers[click.Referrer]++
+	summary.UserAgents[click.UserAgent]++
+	summary.Countries[click.Country]++
+}
+
+// ScanFile собирает сводку по ссылке short из NDJSON-файла событий.
+func ScanFile(path, short string, q Query) (model.ClickSummary, error) {
+	summary := model.NewClickSummary()
+	err := scanFile(path, func(click model.Click) {
+		if click.Short == short && q.Contains(click.Time) {
+			Add(&summary, q, click)
+		}
+	})
+	return summary, err
+}
+
+// scanFile вызывает fn для каждого события из файла.
+// Отсутствующий файл означает, что переходов ещё не было.
+func scanFile(path string, fn func(model.Click)) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var click model.Click
+		if err := json.Unmarshal(scanner.Bytes(), &click); err != nil {
+			// Повреждённая строка, например оборванная при аварийной остановке.
+			continue
+		}
+		fn(click)
+	}
+	return scanner.Err()
+}
+
+// Build превращает сводку в статистику: заполняет пустые интервалы нулями,
+// группирует источники по хосту, а User-Agent — по браузеру и ОС.
+func Build(short string, q Query, summary model.ClickSummary) *model.LinkStats {
+	stats := &model.LinkStats{
+		Short:    short,
+		From:     q.From,
+		To:       q.To,
+		Interval: q.Interval,
+
+		IncludeBots: q.IncludeBots,
+		Bots:        summary.Bots,
+	}
+	stats.Buckets, stats.Total = Series(q, summary.Buckets)
+
+	referrers := map[string]int64{}
+	for ref, n := range summary.Referrers {
+		referrers[referrerHost(ref)] += n
+	}
+	browsers, systems := map[string]int64{}, map[string]int64{}
+	for ua, n := range summary.UserAgents {
+		browsers[useragent.Browser(ua)] += n
+		systems[useragent.OS(ua)] += n
+	}
+	countries := map[string]int64{}
+	for c, n := range summary.Countries {
+		if c == "" {
+			c = Unknown
+		}
+		countries[c] += n
+	}
+
+	stats.Referrers = top(referrers, TopN)
+	stats.Browsers = top(browsers, TopN)
+	stats.OS = top(systems, TopN)
+	stats.Countries = top(countries, TopN)
+	return stats
+}
+
+// Series возвращает временной ряд за период запроса, заполняя пустые
+// интервалы нулями, и сумму значений.
+func Series(q Query, counts map[time.Time]int64) ([]model.StatsBucket, int64) {
+	buckets := []model.StatsBucket{}
+	var total int64
+	for t := q.From; t.Before(q.To); t = q.next(t) {
+		total += counts[t]
+		buckets = append(buckets, model.StatsBucket{Start: t, Clicks: counts[t]})
+	}
+	return buckets, total
+}
+
+// CountFile подсчитывает переходы по каждой ссылке начиная с since в NDJSON-файле событий.
+// Переходы ботов учитываются, только если includeBots.
+func CountFile(path string, since time.Time, includeBots bool) (map[string]int64, error) {
+	counts := map[string]int64{}
+	err := scanFile(path, func(click model.Click) {
+		if !click.Time.Before(since) && (includeBots || !click.Bot) {
+			counts[click.Short]++
+		}
+	})
+	return counts, err
+}
+
+// referrerHost сводит адрес источника к имени хоста, чтобы переходы с разных
+// страниц одного сайта считались вместе.
+func referrerHost(ref string) string {
+	if ref == "" {
+		return Direct
+	}
+	parsed, err := url.Parse(ref)
+	if err != nil || parsed.Hostname() == "" {
+		return ref
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// top возвращает n самых частых значений, при равенстве — по алфавиту.
+func top(counts map[string]int64, n int) []model.StatsEntry {
+	entries := make([]model.StatsEntry, 0, len(counts))
+	for name, clicks := range counts {
+		if clicks > 0 {
+			entries = append(entries, model.StatsEntry{Name: name, Clicks: clicks})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Clicks != entries[j].Clicks {
+			return entries[i].Clicks > entries[j].Clicks
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
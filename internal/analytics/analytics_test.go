@@ -0,0 +1,69 @@
+package analytics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/analytics"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2026, 5, 20, 13, 45, 0, 0, time.UTC)
+
+	q, err := analytics.ParseQuery("", "", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, analytics.IntervalDay, q.Interval)
+	assert.Equal(t, time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC), q.From)
+	assert.Equal(t, time.Date(2026, 5, 21, 0, 0, 0, 0, time.UTC), q.To)
+
+	q, err = analytics.ParseQuery("", "", "hour", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 5, 18, 13, 0, 0, 0, time.UTC), q.From)
+	assert.Equal(t, time.Date(2026, 5, 20, 14, 0, 0, 0, time.UTC), q.To)
+
+	q, err = analytics.ParseQuery("2026-05-01", "2026-05-01", "day", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC), q.To)
+
+	_, err = analytics.ParseQuery("", "", "week", now)
+	assert.ErrorIs(t, err, analytics.ErrInvalidInterval)
+
+	_, err = analytics.ParseQuery("2026-05-10", "2026-05-01", "day", now)
+	assert.True(t, errors.Is(err, analytics.ErrInvalidRange))
+
+	_, err = analytics.ParseQuery("2020-01-01", "2026-01-01", "hour", now)
+	assert.ErrorIs(t, err, analytics.ErrInvalidRange)
+
+	_, err = analytics.ParseQuery("yesterday", "", "day", now)
+	assert.ErrorIs(t, err, analytics.ErrInvalidRange)
+}
+
+func TestBuild_TopN(t *testing.T) {
+	q, err := analytics.ParseQuery("2026-01-01", "2026-01-01", "day", time.Now())
+	require.NoError(t, err)
+
+	summary := model.NewClickSummary()
+	for i := 0; i < analytics.TopN+5; i++ {
+		analytics.Add(&summary, q, model.Click{
+			Time:     q.From.Add(time.Minute),
+			Referrer: "https://site" + string(rune('a'+i)) + ".example/",
+		})
+	}
+	stats := analytics.Build("abc", q, summary)
+	assert.Equal(t, int64(analytics.TopN+5), stats.Total)
+	assert.Len(t, stats.Referrers, analytics.TopN)
+	assert.Equal(t, "sitea.example", stats.Referrers[0].Name)
+	assert.Equal(t, []model.StatsEntry{{Name: "Unknown", Clicks: int64(analytics.TopN + 5)}}, stats.Browsers)
+}
+
+func TestScanFile_Missing(t *testing.T) {
+	q, err := analytics.ParseQuery("", "", "", time.Now())
+	require.NoError(t, err)
+	summary, err := analytics.ScanFile(t.TempDir()+"/none.ndjson", "abc", q)
+	require.NoError(t, err)
+	assert.Empty(t, summary.Buckets)
+}
@@ -0,0 +1,186 @@
+package analytics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/hll"
+	"github.com/Totarae/URLShortener/internal/model"
+)
+
+// AllLinks — ключ скетча уникальных посетителей всего сервиса.
+const AllLinks = "*"
+
+// SketchKey — скетч уникальных посетителей ссылки за день (UTC).
+type SketchKey struct {
+	Short string
+	Day   time.Time
+}
+
+// VisitorKey возвращает идентификатор посетителя: хеш IP и User-Agent.
+func VisitorKey(c model.Click) string {
+	return c.IPHash + "\x00" + c.UserAgent
+}
+
+// Day возвращает начало дня t в UTC.
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Sketches строит скетчи посетителей пачки переходов по ссылкам и дням,
+// а также общий скетч сервиса под ключом AllLinks. Боты посетителями не считаются.
+func Sketches(clicks []model.Click) map[SketchKey]*hll.Sketch {
+	sketches := make(map[SketchKey]*hll.Sketch)
+	for _, c := range clicks {
+		if c.Bot {
+			continue
+		}
+		hash := hll.Hash(VisitorKey(c))
+		day := Day(c.Time)
+		for _, key := range []SketchKey{{c.Short, day}, {AllLinks, day}} {
+			s, ok := sketches[key]
+			if !ok {
+				s = hll.New()
+				sketches[key] = s
+			}
+			s.Add(hash)
+		}
+	}
+	return sketches
+}
+
+// Visitors дополняет статистику оценкой уникальных посетителей по дневным
+// скетчам. При интервале day оценка добавляется и в каждый интервал ряда.
+func Visitors(stats *model.LinkStats, q Query, days map[time.Time]*hll.Sketch) {
+	stats.Visitors = Estimate(days)
+	if q.Interval != IntervalDay {
+		return
+	}
+	for i, b := range stats.Buckets {
+		if s, ok := days[b.Start]; ok {
+			stats.Buckets[i].Visitors = int64(s.Estimate())
+		}
+	}
+}
+
+// Estimate возвращает оценку уникальных посетителей по объединению скетчей.
+func Estimate(days map[time.Time]*hll.Sketch) int64 {
+	total := hll.New()
+	for _, s := range days {
+		total.Merge(s)
+	}
+	return int64(total.Estimate())
+}
+
+// SketchFile хранит дневные скетчи в каталоге, по JSON-файлу на день
+// (2006-01-02.json) с сериализованными скетчами по коду ссылки.
+// Реализует clicks.Sink.
+type SketchFile struct {
+	Dir string
+
+	mu sync.Mutex
+}
+
+// WriteClicks объединяет скетчи пачки переходов с сохранёнными.
+func (f *SketchFile) WriteClicks(_ context.Context, clicks []model.Click) error {
+	return f.Merge(Sketches(clicks))
+}
+
+// Merge объединяет скетчи с сохранёнными, переписывая файлы затронутых дней.
+func (f *SketchFile) Merge(sketches map[SketchKey]*hll.Sketch) error {
+	byDay := make(map[time.Time]map[string]*hll.Sketch)
+	for key, s := range sketches {
+		if byDay[key.Day] == nil {
+			byDay[key.Day] = make(map[string]*hll.Sketch)
+		}
+		byDay[key.Day][key.Short] = s
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(f.Dir, 0755); err != nil {
+		return err
+	}
+	for day, update := range byDay {
+		stored, err := f.readDay(day)
+		if err != nil {
+			return err
+		}
+		for short, s := range update {
+			if data, ok := stored[short]; ok {
+				old, err := hll.Decode(data)
+				if err != nil {
+					return err
+				}
+				s.Merge(old)
+			}
+			data, err := s.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			stored[short] = data
+		}
+		if err := f.writeDay(day, stored); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load возвращает скетчи ссылки за дни, пересекающиеся с периодом [from, to).
+func (f *SketchFile) Load(short string, from, to time.Time) (map[time.Time]*hll.Sketch, error) {
+	days := make(map[time.Time]*hll.Sketch)
+	for day := Day(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		stored, err := f.readDay(day)
+		if err != nil {
+			return nil, err
+		}
+		data, ok := stored[short]
+		if !ok {
+			continue
+		}
+		if days[day], err = hll.Decode(data); err != nil {
+			return nil, err
+		}
+	}
+	return days, nil
+}
+
+func (f *SketchFile) path(day time.Time) string {
+	return filepath.Join(f.Dir, day.Format("2006-01-02")+".json")
+}
+
+// readDay читает скетчи дня; отсутствующий файл означает пустой день.
+func (f *SketchFile) readDay(day time.Time) (map[string][]byte, error) {
+	stored := make(map[string][]byte)
+	data, err := os.ReadFile(f.path(day))
+	if errors.Is(err, os.ErrNotExist) {
+		return stored, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+// writeDay атомарно заменяет файл дня.
+func (f *SketchFile) writeDay(day time.Time, stored map[string][]byte) error {
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	tmp := f.path(day) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.path(day))
+}
@@ -0,0 +1,72 @@
+package analytics_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/analytics"
+	"github.com/Totarae/URLShortener/internal/hll"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSketchFile(t *testing.T) {
+	store := &analytics.SketchFile{Dir: filepath.Join(t.TempDir(), "sketches")}
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	visit := func(short, ip string, at time.Time) model.Click {
+		return model.Click{Short: short, Time: at, IPHash: ip, UserAgent: "Mozilla/5.0"}
+	}
+	// Две пачки за первый день: повторный визит не увеличивает оценку
+	require.NoError(t, store.WriteClicks(context.Background(), []model.Click{
+		visit("abc", "ip1", day.Add(time.Hour)),
+		visit("abc", "ip2", day.Add(2*time.Hour)),
+		visit("xyz", "ip3", day.Add(3*time.Hour)),
+	}))
+	require.NoError(t, store.WriteClicks(context.Background(), []model.Click{
+		visit("abc", "ip1", day.Add(5*time.Hour)),
+		visit("abc", "ip4", day.Add(25*time.Hour)),
+		visit("abc", "ip2", day.Add(26*time.Hour)),
+	}))
+
+	days, err := store.Load("abc", day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	assert.Equal(t, uint64(2), days[day].Estimate())
+	assert.Equal(t, uint64(2), days[day.AddDate(0, 0, 1)].Estimate())
+	assert.Equal(t, int64(3), analytics.Estimate(days))
+
+	// Начало периода внутри дня захватывает весь день
+	days, err = store.Load("abc", day.Add(12*time.Hour), day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Len(t, days, 1)
+
+	all, err := store.Load(analytics.AllLinks, day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), analytics.Estimate(all))
+
+	missing, err := store.Load("abc", day.AddDate(0, 0, 5), day.AddDate(0, 0, 6))
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestVisitors(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	var clicks []model.Click
+	for i := 0; i < 30; i++ {
+		clicks = append(clicks, model.Click{Short: "abc", Time: day, IPHash: fmt.Sprint(i % 10)})
+	}
+	sketches := analytics.Sketches(clicks)
+	days := map[time.Time]*hll.Sketch{day: sketches[analytics.SketchKey{Short: "abc", Day: day}]}
+
+	q := analytics.Query{From: day, To: day.AddDate(0, 0, 2), Interval: analytics.IntervalDay}
+	stats := &model.LinkStats{Buckets: []model.StatsBucket{{Start: day, Clicks: 30}, {Start: day.AddDate(0, 0, 1)}}}
+	analytics.Visitors(stats, q, days)
+	assert.Equal(t, int64(10), stats.Visitors)
+	assert.Equal(t, int64(10), stats.Buckets[0].Visitors)
+	assert.Zero(t, stats.Buckets[1].Visitors)
+}
@@ -0,0 +1,198 @@
+// Package apikeys выпускает и хранит ключи API.
+//
+// Ключ имеет вид sk_<id>_<secret>: по id ключ находится в хранилище,
+// секрет сверяется с сохранённым SHA-256. Секрет случайный и длинный,
+// поэтому медленная функция хеширования не нужна. Сам ключ показывается
+// пользователю один раз при создании.
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+)
+
+// Prefix — префикс, по которому ключ API отличается от JWT.
+const Prefix = "sk_"
+
+// Длины идентификатора и секрета в байтах до hex-кодирования.
+const (
+	idBytes     = 8
+	secretBytes = 24
+)
+
+// IsKey сообщает, похожа ли строка на ключ API.
+func IsKey(raw string) bool {
+	return strings.HasPrefix(raw, Prefix)
+}
+
+// Generate создаёт ключ и возвращает его вместе с идентификатором и хешем секрета.
+func Generate() (raw, id, hash string, err error) {
+	buf := make([]byte, idBytes+secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", "", err
+	}
+	id = hex.EncodeToString(buf[:idBytes])
+	secret := hex.EncodeToString(buf[idBytes:])
+	return Prefix + id + "_" + secret, id, Hash(secret), nil
+}
+
+// Split разбирает ключ на идентификатор и секрет.
+func Split(raw string) (id, secret string, ok bool) {
+	rest, found := strings.CutPrefix(raw, Prefix)
+	if !found {
+		return "", "", false
+	}
+	id, secret, found = strings.Cut(rest, "_")
+	return id, secret, found && id != "" && secret != ""
+}
+
+// Hash возвращает hex SHA-256 секрета.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Match сверяет секрет с хешем ключа за постоянное время.
+func Match(key model.APIKey, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(key.Hash), []byte(Hash(secret))) == 1
+}
+
+// FileStore хранит ключи в JSON-файле в режимах file и in-memory.
+// Пустой Path хранит ключи только в памяти.
+type FileStore struct {
+	Path string
+
+	mu     sync.RWMutex
+	loaded bool
+	keys   map[string]model.APIKey
+}
+
+// Create сохраняет новый ключ.
+func (f *FileStore) Create(key model.APIKey) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	f.keys[key.ID] = key
+	return f.save()
+}
+
+// Get возвращает ключ по идентификатору, включая отозванные.
+func (f *FileStore) Get(id string) (model.APIKey, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return model.APIKey{}, false, err
+	}
+	key, ok := f.keys[id]
+	return key, ok, nil
+}
+
+// List возвращает неотозванные ключи пользователя в порядке создания.
+func (f *FileStore) List(userID string) ([]model.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	var keys []model.APIKey
+	for _, k := range f.keys {
+		if k.UserID == userID && k.RevokedAt == nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Created.Before(keys[j].Created) })
+	return keys, nil
+}
+
+// Revoke отзывает ключ пользователя. Возвращает false, если активного ключа нет.
+func (f *FileStore) Revoke(userID, id string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return false, err
+	}
+	key, ok := f.keys[id]
+	if !ok || key.UserID != userID || key.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	key.RevokedAt = &now
+	f.keys[id] = key
+	return true, f.save()
+}
+
+// load читает файл при первом обращении; отсутствующий файл означает пустое хранилище.
+func (f *FileStore) load() error {
+	if f.loaded {
+		return nil
+	}
+	f.keys = make(map[string]model.APIKey)
+	if f.Path != "" {
+		data, err := os.ReadFile(f.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &f.keys); err != nil {
+				return err
+			}
+		}
+	}
+	f.loaded = true
+	return nil
+}
+
+// save атомарно переписывает файл.
+func (f *FileStore) save() error {
+	if f.Path == "" {
+		return nil
+	}
+	data, err := json.Marshal(f.keys)
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+// Reassign передаёт ключи пользователя from пользователю to.
+func (f *FileStore) Reassign(from, to string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	changed := false
+	for id, k := range f.keys {
+		if k.UserID == from {
+			k.UserID = to
+			f.keys[id] = k
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return f.save()
+}
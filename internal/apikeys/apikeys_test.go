@@ -0,0 +1,58 @@
+package apikeys_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/apikeys"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	raw, id, hash, err := apikeys.Generate()
+	require.NoError(t, err)
+	assert.True(t, apikeys.IsKey(raw))
+
+	gotID, secret, ok := apikeys.Split(raw)
+	require.True(t, ok)
+	assert.Equal(t, id, gotID)
+	assert.True(t, apikeys.Match(model.APIKey{Hash: hash}, secret))
+	assert.False(t, apikeys.Match(model.APIKey{Hash: hash}, secret+"0"))
+
+	for _, bad := range []string{"", "sk_", "sk_abc", "sk__secret", "eyJhbGciOi.x.y"} {
+		_, _, ok := apikeys.Split(bad)
+		assert.False(t, ok, bad)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	store := &apikeys.FileStore{Path: path}
+	created := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, store.Create(model.APIKey{ID: "k1", UserID: "u1", Hash: "h1", Scopes: []string{"links:read"}, Created: created}))
+	require.NoError(t, store.Create(model.APIKey{ID: "k2", UserID: "u1", Hash: "h2", Created: created.Add(time.Second)}))
+	require.NoError(t, store.Create(model.APIKey{ID: "k3", UserID: "u2", Hash: "h3", Created: created}))
+
+	ok, err := store.Revoke("u2", "k1")
+	require.NoError(t, err)
+	assert.False(t, ok, "чужой ключ не отзывается")
+	ok, err = store.Revoke("u1", "k2")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Ключи переживают перезапуск
+	reopened := &apikeys.FileStore{Path: path}
+	keys, err := reopened.List("u1")
+	require.NoError(t, err)
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, "k1", keys[0].ID)
+		assert.Equal(t, []string{"links:read"}, keys[0].Scopes)
+	}
+	revoked, found, err := reopened.Get("k2")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.NotNil(t, revoked.RevokedAt)
+}
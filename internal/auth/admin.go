@@ -0,0 +1,10 @@
+package auth
+
+import "slices"
+
+// IsAdmin сообщает, что пользователь — администратор. Роль действует только
+// после входа в учётную запись: по cookie сессии или токену, выпущенному по
+// сессии. Анонимная cookie, ключи API и выпущенные по ним токены её не дают.
+func (a *Auth) IsAdmin(id Identity) bool {
+	return id.Session && !id.APIKey && id.UserID != "" && slices.Contains(a.Admins, id.UserID)
+}
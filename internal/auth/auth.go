@@ -4,83 +4,196 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-const (
-	cookieName   = "auth_token"
-	cookieMaxAge = 365 * 24 * 60 * 60 // 1 год
-)
+const cookieName = "auth_token"
+
+// DefaultKeyID — идентификатор ключа, созданного New.
+const DefaultKeyID = "default"
+
+// LegacyKeyID — идентификатор ключа для проверки cookie старого формата
+// userID:signature, выпущенных до появления идентификаторов ключей.
+const LegacyKeyID = "legacy"
+
+// ErrNoKeys — не задан ни один ключ подписи.
+var ErrNoKeys = errors.New("auth: no signing keys")
+
+// Key — ключ подписи cookie.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// CookieOptions задаёт атрибуты cookie auth_token.
+type CookieOptions struct {
+	Secure   bool
+	SameSite http.SameSite
+	// Domain — домен cookie; пустая строка ограничивает cookie текущим хостом.
+	Domain string
+	MaxAge time.Duration
+}
+
+// DefaultCookieOptions возвращает атрибуты cookie по умолчанию: SameSite=Lax, срок — год.
+func DefaultCookieOptions() CookieOptions {
+	return CookieOptions{SameSite: http.SameSiteLaxMode, MaxAge: 365 * 24 * time.Hour}
+}
 
 // Auth представляет сервис аутентификации пользователей.
+// Cookie имеет вид userID:keyID:signature. Новые cookie подписываются первым
+// ключом, остальные ключи только проверяют подпись, что позволяет менять ключ
+// без разлогинивания пользователей.
 type Auth struct {
-	SecretKey string
+	keys   []Key
+	byID   map[string][]byte
+	Cookie CookieOptions
+	// TokenTTL — максимальный срок жизни токенов, выпускаемых IssueToken.
+	TokenTTL time.Duration
+	// APIKeys проверяет ключи API; nil отключает их приём.
+	APIKeys KeyVerifier
+	// Sessions проверяет серверные сессии учётных записей; nil отключает их.
+	Sessions SessionVerifier
+	// Admins — пользователи с ролью администратора.
+	Admins []string
 }
 
 // New создает новый экземпляр Auth с заданным секретным ключом.
 func New(secret string) *Auth {
-	return &Auth{SecretKey: secret}
+	a, _ := NewWithKeys([]Key{{ID: DefaultKeyID, Secret: []byte(secret)}}, DefaultCookieOptions())
+	return a
+}
+
+// NewWithKeys создаёт Auth с набором ключей; первый ключ подписывает новые cookie.
+func NewWithKeys(keys []Key, opts CookieOptions) (*Auth, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	a := &Auth{keys: keys, byID: make(map[string][]byte, len(keys)), Cookie: opts, TokenTTL: DefaultTokenTTL}
+	for _, k := range keys {
+		if k.ID == "" || strings.Contains(k.ID, ":") {
+			return nil, fmt.Errorf("auth: invalid key id %q", k.ID)
+		}
+		if len(k.Secret) == 0 {
+			return nil, fmt.Errorf("auth: empty secret for key %q", k.ID)
+		}
+		if _, dup := a.byID[k.ID]; dup {
+			return nil, fmt.Errorf("auth: duplicate key id %q", k.ID)
+		}
+		a.byID[k.ID] = k.Secret
+	}
+	return a, nil
+}
+
+// ActiveKeyID возвращает идентификатор ключа, которым подписываются новые cookie.
+func (a *Auth) ActiveKeyID() string {
+	return a.keys[0].ID
 }
 
 // Создать подпись
-func (a *Auth) sign(userID string) string {
-	mac := hmac.New(sha256.New, []byte(a.SecretKey))
+func sign(secret []byte, userID string) []byte {
+	mac := hmac.New(sha256.New, secret)
 	mac.Write([]byte(userID))
-	return hex.EncodeToString(mac.Sum(nil))
+	return mac.Sum(nil)
 }
 
-// Создать кукми типа: auth_token=userID:signature
-func (a *Auth) issueCookie(w http.ResponseWriter) string {
-	userID := uuid.NewString()
-	sig := a.sign(userID)
+// verify разбирает значение cookie и проверяет подпись за постоянное время.
+// Возвращает userID и идентификатор ключа, которым подписана cookie.
+func (a *Auth) verify(value string) (userID, keyID string, ok bool) {
+	parts := strings.Split(value, ":")
+	var sig string
+	switch len(parts) {
+	case 3:
+		userID, keyID, sig = parts[0], parts[1], parts[2]
+	case 2:
+		userID, keyID, sig = parts[0], LegacyKeyID, parts[1]
+	default:
+		return "", "", false
+	}
+	secret, known := a.byID[keyID]
+	if !known || userID == "" {
+		return "", "", false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, sign(secret, userID)) {
+		return "", "", false
+	}
+	return userID, keyID, true
+}
+
+// setCookie выдаёт cookie для userID, подписанную активным ключом.
+func (a *Auth) setCookie(w http.ResponseWriter, userID string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     cookieName,
-		Value:    fmt.Sprintf("%s:%s", userID, sig),
+		Value:    a.SignCookieValue(userID),
 		Path:     "/",
+		Domain:   a.Cookie.Domain,
 		HttpOnly: true,
-		MaxAge:   cookieMaxAge,
+		Secure:   a.Cookie.Secure,
+		SameSite: a.Cookie.SameSite,
+		MaxAge:   int(a.Cookie.MaxAge.Seconds()),
 	})
+}
+
+// Создать кукми типа: auth_token=userID:keyID:signature
+func (a *Auth) issueCookie(w http.ResponseWriter) string {
+	userID := uuid.NewString()
+	a.setCookie(w, userID)
 	return userID
 }
 
-// GetOrSetUserID возвращает идентификатор пользователя из cookie.
-// Если cookie отсутствует или повреждена — устанавливает новую.
-func (a *Auth) GetOrSetUserID(w http.ResponseWriter, r *http.Request) string {
+// verifyCookie проверяет cookie auth_token запроса.
+func (a *Auth) verifyCookie(r *http.Request) (userID, keyID string, ok bool) {
 	cookie, err := r.Cookie(cookieName)
 	if err != nil || cookie.Value == "" {
-		return a.issueCookie(w)
+		return "", "", false
 	}
+	return a.verify(cookie.Value)
+}
 
-	parts := strings.SplitN(cookie.Value, ":", 2)
-	if len(parts) != 2 || a.sign(parts[0]) != parts[1] {
-		return a.issueCookie(w)
+// GetOrSetUserID возвращает идентификатор пользователя из токена Authorization: Bearer,
+// сессии или cookie. Если ничего из этого нет, а cookie отсутствует или повреждена —
+// устанавливает новую. Cookie, подписанная неактивным ключом, переподписывается активным.
+func (a *Auth) GetOrSetUserID(w http.ResponseWriter, r *http.Request) string {
+	if id, ok := a.Identify(r); ok && (id.Bearer || id.Session) {
+		return id.UserID
 	}
 
-	return parts[0]
+	userID, keyID, ok := a.verifyCookie(r)
+	if !ok {
+		return a.issueCookie(w)
+	}
+	if keyID != a.ActiveKeyID() {
+		a.setCookie(w, userID)
+	}
+	return userID
 }
 
-// ValidateUserID проверяет корректность подписи куки и возвращает userID и флаг валидности.
+// ValidateUserID возвращает userID из токена Authorization: Bearer или из cookie
+// и флаг валидности. Недействительный токен не подменяется cookie.
 func (a *Auth) ValidateUserID(r *http.Request) (string, bool) {
-	cookie, err := r.Cookie(cookieName)
-	if err != nil || cookie.Value == "" {
-		return "", false
-	}
+	id, ok := a.Identify(r)
+	return id.UserID, ok
+}
 
-	parts := strings.SplitN(cookie.Value, ":", 2)
-	if len(parts) != 2 || a.sign(parts[0]) != parts[1] {
-		return "", false
+// VerifyUserToken проверяет значение в формате cookie auth_token, переданное
+// не в cookie, например в метаданных gRPC. Владелец значения обладает всеми областями.
+func (a *Auth) VerifyUserToken(value string) (Identity, bool) {
+	userID, _, ok := a.verify(value)
+	if !ok {
+		return Identity{}, false
 	}
-
-	return parts[0], true
+	return Identity{UserID: userID, Scopes: AllScopes()}, true
 }
 
 // SignCookieValue возвращает строку куки с валидной подписью для заданного userID.
 // Используется в тестах.
 func (a *Auth) SignCookieValue(userID string) string {
-	sig := a.sign(userID)
-	return fmt.Sprintf("%s:%s", userID, sig)
+	key := a.keys[0]
+	return fmt.Sprintf("%s:%s:%s", userID, key.ID, hex.EncodeToString(sign(key.Secret, userID)))
 }
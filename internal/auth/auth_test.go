@@ -1,13 +1,19 @@
 package auth_test
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Totarae/URLShortener/internal/auth"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSignAndValidate(t *testing.T) {
@@ -96,3 +102,203 @@ func TestValidateUserID_Invalid(t *testing.T) {
 	assert.False(t, ok)
 	assert.Empty(t, id)
 }
+
+func TestKeyRotation(t *testing.T) {
+	old, err := auth.NewWithKeys([]auth.Key{{ID: "k1", Secret: []byte("old-secret")}}, auth.DefaultCookieOptions())
+	require.NoError(t, err)
+	rotated, err := auth.NewWithKeys([]auth.Key{
+		{ID: "k2", Secret: []byte("new-secret")},
+		{ID: "k1", Secret: []byte("old-secret")},
+	}, auth.DefaultCookieOptions())
+	require.NoError(t, err)
+
+	oldCookie := old.SignCookieValue("user-1")
+	assert.Equal(t, "user-1:k1:", oldCookie[:len("user-1:k1:")])
+
+	// Cookie старого ключа принимается и переподписывается активным ключом
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: oldCookie})
+	rec := httptest.NewRecorder()
+	assert.Equal(t, "user-1", rotated.GetOrSetUserID(rec, req))
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, rotated.SignCookieValue("user-1"), cookies[0].Value)
+
+	// Cookie активного ключа не переподписывается
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: rotated.SignCookieValue("user-1")})
+	rec = httptest.NewRecorder()
+	assert.Equal(t, "user-1", rotated.GetOrSetUserID(rec, req))
+	assert.Empty(t, rec.Result().Cookies())
+
+	// После удаления старого ключа его cookie недействительны
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: oldCookie})
+	_, ok := auth.New("new-secret").ValidateUserID(req)
+	assert.False(t, ok)
+}
+
+func TestLegacyCookie(t *testing.T) {
+	legacy := hmac.New(sha256.New, []byte("rainbow-secret-key"))
+	legacy.Write([]byte("old-user"))
+	value := "old-user:" + hex.EncodeToString(legacy.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: value})
+
+	_, ok := auth.New("rainbow-secret-key").ValidateUserID(req)
+	assert.False(t, ok, "cookie без идентификатора ключа принимается только ключом legacy")
+
+	a, err := auth.NewWithKeys([]auth.Key{
+		{ID: "k2", Secret: []byte("new-secret")},
+		{ID: auth.LegacyKeyID, Secret: []byte("rainbow-secret-key")},
+	}, auth.DefaultCookieOptions())
+	require.NoError(t, err)
+	id, ok := a.ValidateUserID(req)
+	assert.True(t, ok)
+	assert.Equal(t, "old-user", id)
+}
+
+func TestCookieOptions(t *testing.T) {
+	a, err := auth.NewWithKeys([]auth.Key{{ID: "k1", Secret: []byte("secret")}}, auth.CookieOptions{
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Domain:   "short.example",
+		MaxAge:   time.Hour,
+	})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	a.GetOrSetUserID(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	c := cookies[0]
+	assert.True(t, c.Secure)
+	assert.True(t, c.HttpOnly)
+	assert.Equal(t, http.SameSiteStrictMode, c.SameSite)
+	assert.Equal(t, "short.example", c.Domain)
+	assert.Equal(t, 3600, c.MaxAge)
+}
+
+func TestNewWithKeys_Invalid(t *testing.T) {
+	opts := auth.DefaultCookieOptions()
+	_, err := auth.NewWithKeys(nil, opts)
+	assert.ErrorIs(t, err, auth.ErrNoKeys)
+	_, err = auth.NewWithKeys([]auth.Key{{ID: "a:b", Secret: []byte("x")}}, opts)
+	assert.Error(t, err)
+	_, err = auth.NewWithKeys([]auth.Key{{ID: "k", Secret: nil}}, opts)
+	assert.Error(t, err)
+	_, err = auth.NewWithKeys([]auth.Key{{ID: "k", Secret: []byte("x")}, {ID: "k", Secret: []byte("y")}}, opts)
+	assert.Error(t, err)
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := auth.ParseKeys(strings.NewReader("# активный ключ первым\n2026-10: new-secret\n\n2026-04:old:secret\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []auth.Key{
+		{ID: "2026-10", Secret: []byte("new-secret")},
+		{ID: "2026-04", Secret: []byte("old:secret")},
+	}, keys)
+
+	_, err = auth.ParseKeys(strings.NewReader("no-separator\n"))
+	assert.Error(t, err)
+	_, err = auth.ParseKeys(strings.NewReader("# пусто\n"))
+	assert.ErrorIs(t, err, auth.ErrNoKeys)
+
+	mode, err := auth.ParseSameSite("None")
+	require.NoError(t, err)
+	assert.Equal(t, http.SameSiteNoneMode, mode)
+	_, err = auth.ParseSameSite("sometimes")
+	assert.Error(t, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	a := auth.New("test-secret")
+	token, expires, err := a.IssueToken("token-user", []string{auth.ScopeLinksRead}, 10*time.Minute)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), expires, 2*time.Second)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: a.SignCookieValue("cookie-user")})
+
+	userID, ok := a.ValidateUserID(req)
+	assert.True(t, ok)
+	assert.Equal(t, "token-user", userID, "токен важнее cookie")
+
+	rec := httptest.NewRecorder()
+	assert.Equal(t, "token-user", a.GetOrSetUserID(rec, req))
+	assert.Empty(t, rec.Result().Cookies())
+
+	id, ok := a.Identify(req)
+	require.True(t, ok)
+	assert.True(t, id.Bearer)
+	assert.True(t, id.HasScope(auth.ScopeLinksRead))
+	assert.False(t, id.HasScope(auth.ScopeLinksWrite))
+
+	_, _, err = a.IssueToken("token-user", []string{"admin"}, 0)
+	assert.ErrorIs(t, err, auth.ErrInvalidScope)
+}
+
+func TestBearerToken_Invalid(t *testing.T) {
+	a := auth.New("test-secret")
+	a.TokenTTL = time.Millisecond
+	expired, _, err := a.IssueToken("user", nil, 0)
+	require.NoError(t, err)
+	other, _, err := auth.New("other-secret").IssueToken("user", nil, 0)
+	require.NoError(t, err)
+	time.Sleep(time.Second)
+
+	for name, token := range map[string]string{"expired": expired, "foreign": other, "garbage": "abc.def.ghi"} {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.AddCookie(&http.Cookie{Name: "auth_token", Value: a.SignCookieValue("cookie-user")})
+
+			_, ok := a.ValidateUserID(req)
+			assert.False(t, ok, "недействительный токен не подменяется cookie")
+
+			rec := httptest.NewRecorder()
+			a.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("запрос не должен дойти до обработчика")
+			})).ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "invalid_token")
+		})
+	}
+}
+
+func TestBearerToken_RotatedKey(t *testing.T) {
+	old, err := auth.NewWithKeys([]auth.Key{{ID: "k1", Secret: []byte("one")}}, auth.DefaultCookieOptions())
+	require.NoError(t, err)
+	token, _, err := old.IssueToken("user", nil, 0)
+	require.NoError(t, err)
+
+	rotated, err := auth.NewWithKeys([]auth.Key{{ID: "k2", Secret: []byte("two")}, {ID: "k1", Secret: []byte("one")}}, auth.DefaultCookieOptions())
+	require.NoError(t, err)
+	claims, err := rotated.ParseToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims.Subject)
+}
+
+func TestIsAdmin(t *testing.T) {
+	a := auth.New("test-secret")
+	a.Admins = []string{"admin"}
+
+	assert.True(t, a.IsAdmin(auth.Identity{UserID: "admin", Session: true}))
+	assert.False(t, a.IsAdmin(auth.Identity{UserID: "admin"}), "anonymous cookie")
+	assert.False(t, a.IsAdmin(auth.Identity{UserID: "admin", Bearer: true, APIKey: true}), "API key")
+	assert.False(t, a.IsAdmin(auth.Identity{UserID: "user", Session: true}))
+
+	// Роль даёт только токен, выпущенный по сессии
+	plain, _, err := a.IssueToken("admin", nil, 0)
+	require.NoError(t, err)
+	id, err := a.IdentifyBearer(context.Background(), plain)
+	require.NoError(t, err)
+	assert.False(t, a.IsAdmin(id))
+	session, _, err := a.IssueSessionToken("admin", nil, 0)
+	require.NoError(t, err)
+	id, err = a.IdentifyBearer(context.Background(), session)
+	require.NoError(t, err)
+	assert.True(t, a.IsAdmin(id))
+}
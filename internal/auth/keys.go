@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// ParseKeys разбирает файл ключей: по ключу в строке в виде keyID:secret,
+// пустые строки и строки с # игнорируются. Первый ключ — активный, остальные
+// принимаются только для проверки уже выданных cookie.
+func ParseKeys(r io.Reader) ([]Key, error) {
+	var keys []Key
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		id, secret, found := strings.Cut(line, ":")
+		id, secret = strings.TrimSpace(id), strings.TrimSpace(secret)
+		if !found || id == "" || secret == "" {
+			return nil, fmt.Errorf("line %d: expected keyID:secret", lineNo)
+		}
+		keys = append(keys, Key{ID: id, Secret: []byte(secret)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	return keys, nil
+}
+
+// LoadKeys читает ключи из файла в формате ParseKeys.
+func LoadKeys(path string) ([]Key, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys, err := ParseKeys(f)
+	if err != nil {
+		return nil, fmt.Errorf("auth keys %s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// ParseSameSite разбирает значение атрибута SameSite: lax, strict, none
+// или пустую строку (атрибут не передаётся).
+func ParseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return http.SameSiteDefaultMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	}
+	return 0, fmt.Errorf("unknown SameSite mode %q", s)
+}
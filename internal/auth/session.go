@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	sessionCookieName = "session"
+	// ssoStateCookieName связывает вход через провайдер с браузером, который его начал.
+	ssoStateCookieName = "sso_state"
+	ssoStatePath       = "/api/auth/oidc/"
+)
+
+// ErrInvalidSession — сессия не найдена, истекла или завершена.
+var ErrInvalidSession = errors.New("invalid or expired session")
+
+// SessionVerifier проверяет токены серверных сессий. Для неизвестной или
+// истёкшей сессии возвращает ErrInvalidSession.
+type SessionVerifier interface {
+	VerifySession(ctx context.Context, token string) (userID string, err error)
+}
+
+// SetSession выдаёт cookie сессии до expires.
+func (a *Auth) SetSession(w http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Domain:   a.Cookie.Domain,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   a.Cookie.Secure,
+		SameSite: a.Cookie.SameSite,
+	})
+}
+
+// ClearSession удаляет cookie сессии.
+func (a *Auth) ClearSession(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		Domain:   a.Cookie.Domain,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.Cookie.Secure,
+		SameSite: a.Cookie.SameSite,
+	})
+}
+
+// SetSSOState запоминает в браузере state начатого входа через провайдер.
+// SameSite=Lax нужен, чтобы cookie пришла при возврате с сайта провайдера.
+func (a *Auth) SetSSOState(w http.ResponseWriter, state string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookieName,
+		Value:    state,
+		Path:     ssoStatePath,
+		Domain:   a.Cookie.Domain,
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   a.Cookie.Secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// SSOState возвращает state из cookie и удаляет её: state используется один раз.
+func (a *Auth) SSOState(w http.ResponseWriter, r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(ssoStateCookieName)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookieName,
+		Value:    "",
+		Path:     ssoStatePath,
+		Domain:   a.Cookie.Domain,
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.Cookie.Secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return cookie.Value, true
+}
+
+// SessionToken возвращает токен сессии из cookie.
+func SessionToken(r *http.Request) (string, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return "", false
+	}
+	return cookie.Value, true
+}
+
+// AnonymousUserID возвращает идентификатор из подписанной cookie auth_token,
+// не учитывая сессию и токены. Нужен для переноса ссылок в учётную запись.
+func (a *Auth) AnonymousUserID(r *http.Request) (string, bool) {
+	userID, _, ok := a.verifyCookie(r)
+	return userID, ok
+}
+
+// ResetUserID выдаёт новую анонимную cookie. Вызывается после входа, чтобы
+// после выхода браузер не сохранил доступ к перенесённым в учётную запись ссылкам.
+func (a *Auth) ResetUserID(w http.ResponseWriter) string {
+	return a.issueCookie(w)
+}
+
+// identifySession проверяет токен сессии.
+func (a *Auth) identifySession(ctx context.Context, token string) (Identity, error) {
+	if a.Sessions == nil {
+		return Identity{}, ErrInvalidSession
+	}
+	userID, err := a.Sessions.VerifySession(ctx, token)
+	if err != nil {
+		return Identity{}, err
+	}
+	return Identity{UserID: userID, Scopes: AllScopes(), Session: true}, nil
+}
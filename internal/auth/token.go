@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/apikeys"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Области доступа токенов.
+const (
+	ScopeLinksRead   = "links:read"
+	ScopeLinksWrite  = "links:write"
+	ScopeLinksDelete = "links:delete"
+	ScopeStatsRead   = "stats:read"
+)
+
+// TokenIssuer — значение iss выпускаемых токенов.
+const TokenIssuer = "shortener"
+
+// DefaultTokenTTL — максимальный срок жизни токена по умолчанию.
+const DefaultTokenTTL = time.Hour
+
+var (
+	// ErrInvalidToken — токен повреждён, просрочен или подписан неизвестным ключом.
+	ErrInvalidToken = errors.New("invalid bearer token")
+	// ErrInvalidScope — запрошена неизвестная или недоступная область.
+	ErrInvalidScope = errors.New("invalid scope")
+)
+
+// AllScopes возвращает все области доступа; ими обладает владелец cookie.
+func AllScopes() []string {
+	return []string{ScopeLinksRead, ScopeLinksWrite, ScopeLinksDelete, ScopeStatsRead}
+}
+
+// ValidateScopes проверяет, что все области известны.
+func ValidateScopes(scopes []string) error {
+	for _, s := range scopes {
+		if !slices.Contains(AllScopes(), s) {
+			return fmt.Errorf("%w: %q", ErrInvalidScope, s)
+		}
+	}
+	return nil
+}
+
+// KeyVerifier проверяет ключи API. Для неизвестного или отозванного ключа
+// возвращает ErrInvalidToken.
+type KeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, raw string) (userID string, scopes []string, err error)
+}
+
+// Claims — содержимое токена: sub — идентификатор пользователя,
+// scope — области доступа через пробел.
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope,omitempty"`
+	// Session — токен выпущен по сессии учётной записи.
+	Session bool `json:"session,omitempty"`
+}
+
+// Scopes возвращает области доступа токена.
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// Identity — пользователь, от имени которого выполняется запрос.
+type Identity struct {
+	UserID string
+	Scopes []string
+	// Bearer — пользователь определён по токену или ключу API, а не по cookie.
+	Bearer bool
+	// APIKey — пользователь определён по ключу API.
+	APIKey bool
+	// Session — пользователь вошёл в учётную запись: по cookie сессии
+	// или токеном, выпущенным по сессии.
+	Session bool
+}
+
+type identityKey struct{}
+
+// WithIdentity сохраняет проверенного пользователя в контексте.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext возвращает пользователя, сохранённый WithIdentity.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// HasScope сообщает, разрешена ли пользователю область доступа.
+func (i Identity) HasScope(scope string) bool {
+	return slices.Contains(i.Scopes, scope)
+}
+
+// IssueToken выпускает JWT (HS256) для пользователя, подписанный активным ключом.
+// Срок жизни ограничен TokenTTL; ttl <= 0 означает максимальный срок.
+func (a *Auth) IssueToken(userID string, scopes []string, ttl time.Duration) (string, time.Time, error) {
+	return a.issueToken(userID, scopes, ttl, false)
+}
+
+// IssueSessionToken выпускает токен, как IssueToken, для пользователя, вошедшего
+// в учётную запись. Только такие токены дают роль администратора.
+func (a *Auth) IssueSessionToken(userID string, scopes []string, ttl time.Duration) (string, time.Time, error) {
+	return a.issueToken(userID, scopes, ttl, true)
+}
+
+func (a *Auth) issueToken(userID string, scopes []string, ttl time.Duration, session bool) (string, time.Time, error) {
+	if err := ValidateScopes(scopes); err != nil {
+		return "", time.Time{}, err
+	}
+	if ttl <= 0 || ttl > a.TokenTTL {
+		ttl = a.TokenTTL
+	}
+	now := time.Now()
+	expires := now.Add(ttl).Truncate(time.Second)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    TokenIssuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expires),
+		},
+		Scope:   strings.Join(scopes, " "),
+		Session: session,
+	})
+	key := a.keys[0]
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.Secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expires, nil
+}
+
+// ParseToken проверяет подпись, алгоритм, издателя и срок действия токена.
+func (a *Auth) ParseToken(raw string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		secret, ok := a.byID[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(TokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.Subject == "" {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// bearerToken возвращает токен из заголовка Authorization: Bearer.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// IdentifyBearer проверяет значение Authorization: Bearer — ключ API или JWT.
+// Ошибка ErrInvalidToken означает недействительные учётные данные.
+func (a *Auth) IdentifyBearer(ctx context.Context, raw string) (Identity, error) {
+	if apikeys.IsKey(raw) {
+		if a.APIKeys == nil {
+			return Identity{}, ErrInvalidToken
+		}
+		userID, scopes, err := a.APIKeys.VerifyAPIKey(ctx, raw)
+		if err != nil {
+			return Identity{}, err
+		}
+		return Identity{UserID: userID, Scopes: scopes, Bearer: true, APIKey: true}, nil
+	}
+	claims, err := a.ParseToken(raw)
+	if err != nil {
+		return Identity{}, err
+	}
+	return Identity{UserID: claims.Subject, Scopes: claims.Scopes(), Bearer: true, Session: claims.Session}, nil
+}
+
+// Identify определяет пользователя по контексту запроса, Authorization: Bearer,
+// cookie сессии или cookie auth_token — в этом порядке. Если передан токен или
+// сессия, следующие источники не проверяются. Сессии и cookie дают все области.
+func (a *Auth) Identify(r *http.Request) (Identity, bool) {
+	if id, ok := FromContext(r.Context()); ok {
+		return id, true
+	}
+	if raw, ok := bearerToken(r); ok {
+		id, err := a.IdentifyBearer(r.Context(), raw)
+		return id, err == nil
+	}
+	if token, ok := SessionToken(r); ok && a.Sessions != nil {
+		id, err := a.identifySession(r.Context(), token)
+		return id, err == nil
+	}
+	userID, _, ok := a.verifyCookie(r)
+	if !ok {
+		return Identity{}, false
+	}
+	return Identity{UserID: userID, Scopes: AllScopes()}, true
+}
+
+// Authenticate проверяет Authorization: Bearer и cookie сессии и сохраняет
+// пользователя в контексте. На недействительные учётные данные отвечает 401,
+// чтобы пользователь не получил вместо ошибки новую анонимную cookie;
+// cookie истёкшей сессии при этом удаляется.
+func (a *Auth) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			id  Identity
+			err error
+		)
+		if raw, ok := bearerToken(r); ok {
+			id, err = a.IdentifyBearer(r.Context(), raw)
+		} else if token, ok := SessionToken(r); ok && a.Sessions != nil {
+			id, err = a.identifySession(r.Context(), token)
+		} else {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch {
+		case errors.Is(err, ErrInvalidToken):
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, "invalid bearer token", http.StatusUnauthorized)
+			return
+		case errors.Is(err, ErrInvalidSession):
+			a.ClearSession(w)
+			http.Error(w, "session expired, log in again", http.StatusUnauthorized)
+			return
+		case err != nil:
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithIdentity(r.Context(), id)))
+	})
+}
+
+// RequireScope отвечает 403, если у пользователя нет области scope.
+// Запросы без учётных данных пропускаются: им выдаётся анонимная cookie.
+func (a *Auth) RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id, ok := a.Identify(r); ok && !id.HasScope(scope) {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+				http.Error(w, "insufficient scope", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
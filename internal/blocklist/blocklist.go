@@ -0,0 +1,230 @@
+// Package blocklist загружает правила блокировки адресов назначения из файла
+// и перечитывает их при изменении файла.
+//
+// Формат файла — по одному правилу в строке, пустые строки и строки с # игнорируются:
+//
+//	domain:example.com      хост или любой его поддомен
+//	suffix:.zip             окончание имени хоста
+//	regex:^https?://.*/wp-login  регулярное выражение по всему URL
+//
+// Строка без префикса считается правилом domain.
+package blocklist
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// Виды правил.
+const (
+	KindDomain = "domain"
+	KindSuffix = "suffix"
+	KindRegex  = "regex"
+)
+
+// reloadDelay сглаживает серию событий при перезаписи файла редактором.
+const reloadDelay = 200 * time.Millisecond
+
+// Rule — одно правило блокировки.
+type Rule struct {
+	Kind    string
+	Pattern string
+	re      *regexp.Regexp
+}
+
+// String возвращает правило в том виде, в котором оно записано в файле.
+func (r Rule) String() string {
+	return r.Kind + ":" + r.Pattern
+}
+
+// Match сообщает, подпадает ли URL под правило.
+func (r Rule) Match(raw string) bool {
+	switch r.Kind {
+	case KindRegex:
+		return r.re.MatchString(raw)
+	case KindDomain, KindSuffix:
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return false
+		}
+		host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+		if r.Kind == KindSuffix {
+			return strings.HasSuffix(host, r.Pattern)
+		}
+		return host == r.Pattern || strings.HasSuffix(host, "."+r.Pattern)
+	}
+	return false
+}
+
+// Parse разбирает правила из r.
+func Parse(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kind, pattern, found := strings.Cut(line, ":")
+		if !found || (kind != KindDomain && kind != KindSuffix && kind != KindRegex) {
+			kind, pattern = KindDomain, line
+		}
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			return nil, fmt.Errorf("line %d: empty pattern", lineNo)
+		}
+
+		rule := Rule{Kind: kind, Pattern: pattern}
+		if kind == KindRegex {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			rule.re = re
+		} else {
+			rule.Pattern = strings.TrimSuffix(strings.ToLower(pattern), ".")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, scanner.Err()
+}
+
+// List — потокобезопасный набор правил, загруженный из файла.
+type List struct {
+	path   string
+	logger *zap.Logger
+
+	mu       sync.RWMutex
+	rules    []Rule
+	onReload func(added []Rule)
+}
+
+// Load читает правила из файла.
+func Load(path string, logger *zap.Logger) (*List, error) {
+	l := &List{path: path, logger: logger}
+	if err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Match возвращает первое правило, под которое подпадает URL.
+func (l *List) Match(raw string) (Rule, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, rule := range l.rules {
+		if rule.Match(raw) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Rules возвращает копию текущего набора правил.
+func (l *List) Rules() []Rule {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return append([]Rule(nil), l.rules...)
+}
+
+// OnReload задаёт обработчик, получающий правила, добавленные при перезагрузке.
+func (l *List) OnReload(fn func(added []Rule)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.onReload = fn
+}
+
+// Reload перечитывает файл. При ошибке разбора прежние правила сохраняются.
+func (l *List) Reload() error {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rules, err := Parse(f)
+	if err != nil {
+		return fmt.Errorf("blocklist %s: %w", l.path, err)
+	}
+
+	l.mu.Lock()
+	known := make(map[string]struct{}, len(l.rules))
+	for _, r := range l.rules {
+		known[r.String()] = struct{}{}
+	}
+	var added []Rule
+	for _, r := range rules {
+		if _, ok := known[r.String()]; !ok {
+			added = append(added, r)
+		}
+	}
+	initial := l.rules == nil
+	l.rules = rules
+	if l.rules == nil {
+		l.rules = []Rule{}
+	}
+	onReload := l.onReload
+	l.mu.Unlock()
+
+	if !initial && onReload != nil && len(added) > 0 {
+		onReload(added)
+	}
+	return nil
+}
+
+// Watch перечитывает файл при каждом изменении, пока не отменён ctx.
+// Следит за каталогом, чтобы переживать атомарную замену файла через rename.
+func (l *List) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(l.path)); err != nil {
+		return err
+	}
+	target := filepath.Clean(l.path)
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(ev.Name) == target && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				timer = time.After(reloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			l.logger.Warn("Blocklist watcher error", zap.Error(err))
+		case <-timer:
+			timer = nil
+			if err := l.Reload(); err != nil {
+				l.logger.Error("Blocklist reload failed", zap.Error(err))
+				continue
+			}
+			l.logger.Info("Blocklist reloaded", zap.String("path", l.path), zap.Int("rules", len(l.Rules())))
+		}
+	}
+}
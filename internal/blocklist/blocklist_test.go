@@ -0,0 +1,87 @@
+package blocklist_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/blocklist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseAndMatch(t *testing.T) {
+	rules, err := blocklist.Parse(strings.NewReader(`
+# комментарий
+evil.com
+domain:Phish.example
+suffix:.zip
+regex:^https?://[^/]+/wp-login\.php
+`))
+	require.NoError(t, err)
+	require.Len(t, rules, 4)
+
+	match := func(raw string) string {
+		for _, r := range rules {
+			if r.Match(raw) {
+				return r.String()
+			}
+		}
+		return ""
+	}
+	assert.Equal(t, "domain:evil.com", match("https://evil.com/x"))
+	assert.Equal(t, "domain:evil.com", match("https://cdn.evil.com/x"))
+	assert.Equal(t, "", match("https://notevil.com/x"))
+	assert.Equal(t, "domain:phish.example", match("http://PHISH.example"))
+	assert.Equal(t, "suffix:.zip", match("https://download.zip/file"))
+	assert.Equal(t, `regex:^https?://[^/]+/wp-login\.php`, match("https://blog.example.org/wp-login.php"))
+}
+
+func TestParse_InvalidRegex(t *testing.T) {
+	_, err := blocklist.Parse(strings.NewReader("regex:(unclosed"))
+	assert.Error(t, err)
+}
+
+func TestWatch_ReloadsAndReportsAddedRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(path, []byte("evil.com\n"), 0644))
+
+	list, err := blocklist.Load(path, zap.NewNop())
+	require.NoError(t, err)
+
+	var (
+		mu    sync.Mutex
+		added []string
+	)
+	list.OnReload(func(rules []blocklist.Rule) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, r := range rules {
+			added = append(added, r.String())
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go list.Watch(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	_, blocked := list.Match("https://bad.example/page")
+	assert.False(t, blocked)
+
+	require.NoError(t, os.WriteFile(path, []byte("evil.com\nbad.example\n"), 0644))
+
+	assert.Eventually(t, func() bool {
+		_, ok := list.Match("https://bad.example/page")
+		return ok
+	}, 3*time.Second, 50*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"domain:bad.example"}, added)
+}
@@ -0,0 +1,246 @@
+// Package botdetect отличает переходы ботов и сборщиков превью от переходов людей.
+//
+// Запрос считается ботом, если это HEAD-запрос, предзагрузка браузера
+// (заголовки Purpose, Sec-Purpose, X-Purpose, X-Moz), у него нет User-Agent
+// или User-Agent подпадает под одно из правил. Встроенные правила DefaultPatterns
+// действуют всегда; файл правил дополняет их и перечитывается при изменении.
+//
+// Формат файла — по одному правилу в строке, пустые строки и строки с # игнорируются:
+//
+//	Slackbot               подстрока User-Agent без учёта регистра
+//	regex:^curl/[0-9.]+$   регулярное выражение без учёта регистра
+package botdetect
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// DefaultPatterns — подстроки User-Agent известных ботов и сборщиков превью.
+var DefaultPatterns = []string{
+	"bot", "crawler", "spider", "slurp", "preview",
+	"facebookexternalhit", "facebookcatalog", "whatsapp", "telegrambot",
+	"slack-imgproxy", "skypeuripreview", "vkshare", "embedly", "quora link preview",
+	"headlesschrome", "python-requests", "go-http-client", "curl/", "wget/",
+}
+
+// Причины, по которым запрос признан ботом, кроме правил User-Agent.
+const (
+	ReasonHead     = "method:HEAD"
+	ReasonPrefetch = "prefetch"
+	ReasonNoAgent  = "empty-user-agent"
+)
+
+// reloadDelay сглаживает серию событий при перезаписи файла редактором.
+const reloadDelay = 200 * time.Millisecond
+
+// Rule — правило User-Agent.
+type Rule struct {
+	Pattern string
+	re      *regexp.Regexp
+}
+
+// String возвращает правило в том виде, в котором оно записано в файле.
+func (r Rule) String() string {
+	if r.re != nil {
+		return "regex:" + r.Pattern
+	}
+	return r.Pattern
+}
+
+// Match сообщает, подпадает ли User-Agent в нижнем регистре под правило.
+func (r Rule) Match(ua string) bool {
+	if r.re != nil {
+		return r.re.MatchString(ua)
+	}
+	return strings.Contains(ua, r.Pattern)
+}
+
+// Parse разбирает правила из r.
+func Parse(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if expr, ok := strings.CutPrefix(line, "regex:"); ok {
+			re, err := regexp.Compile("(?i)" + strings.TrimSpace(expr))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			rules = append(rules, Rule{Pattern: strings.TrimSpace(expr), re: re})
+			continue
+		}
+		rules = append(rules, Rule{Pattern: strings.ToLower(line)})
+	}
+	return rules, scanner.Err()
+}
+
+// Classifier — потокобезопасный классификатор запросов.
+type Classifier struct {
+	path   string
+	logger *zap.Logger
+
+	defaults []Rule
+
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+// New создаёт классификатор со встроенными правилами.
+func New() *Classifier {
+	c := &Classifier{logger: zap.NewNop()}
+	for _, p := range DefaultPatterns {
+		c.defaults = append(c.defaults, Rule{Pattern: p})
+	}
+	return c
+}
+
+// Load создаёт классификатор со встроенными правилами и правилами из файла.
+func Load(path string, logger *zap.Logger) (*Classifier, error) {
+	c := New()
+	c.path, c.logger = path, logger
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Classify сообщает, похож ли запрос на бота, и возвращает причину.
+func (c *Classifier) Classify(r *http.Request) (bool, string) {
+	if r.Method == http.MethodHead {
+		return true, ReasonHead
+	}
+	if isPrefetch(r.Header) {
+		return true, ReasonPrefetch
+	}
+	ua := strings.ToLower(strings.TrimSpace(r.UserAgent()))
+	if ua == "" {
+		return true, ReasonNoAgent
+	}
+	if rule, ok := c.match(ua); ok {
+		return true, rule.String()
+	}
+	return false, ""
+}
+
+// IsBot сообщает, похож ли запрос на бота. Нулевой классификатор считает всех людьми.
+func (c *Classifier) IsBot(r *http.Request) bool {
+	if c == nil {
+		return false
+	}
+	bot, _ := c.Classify(r)
+	return bot
+}
+
+func (c *Classifier) match(ua string) (Rule, bool) {
+	for _, rule := range c.defaults {
+		if rule.Match(ua) {
+			return rule, true
+		}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, rule := range c.rules {
+		if rule.Match(ua) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// isPrefetch распознаёт предзагрузку и предварительную отрисовку страниц браузером.
+func isPrefetch(h http.Header) bool {
+	for _, name := range []string{"Purpose", "Sec-Purpose", "X-Purpose", "X-Moz"} {
+		v := strings.ToLower(h.Get(name))
+		if strings.Contains(v, "prefetch") || strings.Contains(v, "prerender") || strings.Contains(v, "preview") {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules возвращает копию правил из файла.
+func (c *Classifier) Rules() []Rule {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]Rule(nil), c.rules...)
+}
+
+// Reload перечитывает файл. При ошибке разбора прежние правила сохраняются.
+func (c *Classifier) Reload() error {
+	f, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rules, err := Parse(f)
+	if err != nil {
+		return fmt.Errorf("bot rules %s: %w", c.path, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules = rules
+	return nil
+}
+
+// Watch перечитывает файл при каждом изменении, пока не отменён ctx.
+// Следит за каталогом, чтобы переживать атомарную замену файла через rename.
+func (c *Classifier) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(c.path)); err != nil {
+		return err
+	}
+	target := filepath.Clean(c.path)
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(ev.Name) == target && ev.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				timer = time.After(reloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			c.logger.Warn("Bot rules watcher error", zap.Error(err))
+		case <-timer:
+			timer = nil
+			if err := c.Reload(); err != nil {
+				c.logger.Error("Bot rules reload failed", zap.Error(err))
+				continue
+			}
+			c.logger.Info("Bot rules reloaded", zap.String("path", c.path), zap.Int("rules", len(c.Rules())))
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package botdetect_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/botdetect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const chrome = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+
+func request(method, ua string, headers ...string) *http.Request {
+	r := httptest.NewRequest(method, "/abc", nil)
+	r.Header.Set("User-Agent", ua)
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+	return r
+}
+
+func TestClassify(t *testing.T) {
+	c := botdetect.New()
+	tests := []struct {
+		name   string
+		req    *http.Request
+		bot    bool
+		reason string
+	}{
+		{"browser", request(http.MethodGet, chrome), false, ""},
+		{"slack", request(http.MethodGet, "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)"), true, "bot"},
+		{"telegram", request(http.MethodGet, "TelegramBot (like TwitterBot)"), true, "bot"},
+		{"facebook", request(http.MethodGet, "facebookexternalhit/1.1"), true, "facebookexternalhit"},
+		{"head", request(http.MethodHead, chrome), true, botdetect.ReasonHead},
+		{"prefetch", request(http.MethodGet, chrome, "Sec-Purpose", "prefetch;prerender"), true, botdetect.ReasonPrefetch},
+		{"firefox prefetch", request(http.MethodGet, chrome, "X-Moz", "prefetch"), true, botdetect.ReasonPrefetch},
+		{"no user agent", request(http.MethodGet, ""), true, botdetect.ReasonNoAgent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, reason := c.Classify(tt.req)
+			assert.Equal(t, tt.bot, bot)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	var nilClassifier *botdetect.Classifier
+	assert.False(t, nilClassifier.IsBot(request(http.MethodHead, "")))
+}
+
+func TestWatch_ReloadsRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bots.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# собственный мониторинг\nUptimeChecker\n"), 0644))
+
+	c, err := botdetect.Load(path, zap.NewNop())
+	require.NoError(t, err)
+	assert.True(t, c.IsBot(request(http.MethodGet, "uptimechecker/2.0")))
+	assert.False(t, c.IsBot(request(http.MethodGet, "LinkInspector/1.0")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Watch(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("regex:^linkinspector/[0-9.]+$\n"), 0644))
+	assert.Eventually(t, func() bool {
+		return c.IsBot(request(http.MethodGet, "LinkInspector/1.0"))
+	}, 2*time.Second, 20*time.Millisecond)
+	assert.False(t, c.IsBot(request(http.MethodGet, "uptimechecker/2.0")))
+
+	// Ошибка разбора не сбрасывает действующие правила
+	require.NoError(t, os.WriteFile(path, []byte("regex:([\n"), 0644))
+	time.Sleep(400 * time.Millisecond)
+	assert.True(t, c.IsBot(request(http.MethodGet, "LinkInspector/1.0")))
+}
@@ -0,0 +1,57 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.New[int](2, 0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	_, _ = c.Get("a")
+	c.Set("c", 3)
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	v, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 2, c.Len())
+}
+
+func TestCache_TTLAndDelete(t *testing.T) {
+	c := cache.New[string](10, 20*time.Millisecond)
+	c.Set("a", "x")
+	c.Set("b", "y")
+	c.Delete("b")
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+
+	hits, misses := c.Stats()
+	assert.Equal(t, uint64(1), hits)
+	assert.Equal(t, uint64(2), misses)
+}
+
+func TestCache_Clear(t *testing.T) {
+	c := cache.New[string](10, 0)
+	c.Set("a", "x")
+	c.Set("b", "y")
+	c.Clear()
+
+	assert.Zero(t, c.Len())
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+	c.Set("c", "z")
+	assert.Equal(t, 1, c.Len())
+}
@@ -0,0 +1,206 @@
+// Package canonical приводит URL к каноническому виду перед хешированием,
+// чтобы эквивалентные адреса получали один и тот же короткий идентификатор.
+package canonical
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// DefaultStripParams — параметры отслеживания, удаляемые по умолчанию.
+var DefaultStripParams = []string{"utm_*", "fbclid", "gclid", "yclid", "mc_cid", "mc_eid"}
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Options задаёт правила канонизации для конкретного развёртывания.
+type Options struct {
+	// SortQuery сортирует параметры запроса по имени и значению.
+	SortQuery bool
+	// StripParams — имена удаляемых параметров, допускаются шаблоны path.Match ("utm_*").
+	StripParams []string
+}
+
+// Canonicalizer приводит URL к каноническому виду.
+type Canonicalizer struct {
+	opts Options
+}
+
+// New создаёт Canonicalizer с заданными правилами.
+func New(opts Options) *Canonicalizer {
+	normalized := make([]string, 0, len(opts.StripParams))
+	for _, p := range opts.StripParams {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			normalized = append(normalized, p)
+		}
+	}
+	opts.StripParams = normalized
+	return &Canonicalizer{opts: opts}
+}
+
+// Canonicalize возвращает канонический вид URL:
+// схема и хост в нижнем регистре, IDN в punycode, без порта по умолчанию,
+// с нормализованным percent-encoding и, по настройке, отсортированными
+// параметрами без меток отслеживания.
+func (c *Canonicalizer) Canonicalize(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("absolute URL required: %q", raw)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host, err := normalizeHost(u.Hostname())
+	if err != nil {
+		return "", err
+	}
+	if port := u.Port(); port != "" && port != defaultPorts[scheme] {
+		host += ":" + port
+	}
+
+	var b strings.Builder
+	b.WriteString(scheme)
+	b.WriteString("://")
+	if u.User != nil {
+		b.WriteString(u.User.String())
+		b.WriteByte('@')
+	}
+	b.WriteString(host)
+
+	p := normalizePercent(u.EscapedPath())
+	if p == "" {
+		p = "/"
+	}
+	b.WriteString(p)
+
+	if query := c.normalizeQuery(u.RawQuery); query != "" {
+		b.WriteByte('?')
+		b.WriteString(query)
+	}
+	if u.Fragment != "" {
+		b.WriteByte('#')
+		b.WriteString(normalizePercent(u.EscapedFragment()))
+	}
+	return b.String(), nil
+}
+
+func normalizeHost(host string) (string, error) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if strings.Contains(host, ":") {
+		// IPv6-литерал
+		return "[" + host + "]", nil
+	}
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", host, err)
+	}
+	return ascii, nil
+}
+
+func (c *Canonicalizer) normalizeQuery(raw string) string {
+	if raw == "" {
+		return ""
+	}
+	type pair struct {
+		key, value string
+		hasValue   bool
+	}
+	var pairs []pair
+	for _, part := range strings.Split(raw, "&") {
+		if part == "" {
+			continue
+		}
+		key, value, hasValue := strings.Cut(part, "=")
+		key = normalizePercent(key)
+		if c.stripped(key) {
+			continue
+		}
+		pairs = append(pairs, pair{key: key, value: normalizePercent(value), hasValue: hasValue})
+	}
+	if c.opts.SortQuery {
+		sort.SliceStable(pairs, func(i, j int) bool {
+			if pairs[i].key != pairs[j].key {
+				return pairs[i].key < pairs[j].key
+			}
+			return pairs[i].value < pairs[j].value
+		})
+	}
+
+	parts := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if p.hasValue {
+			parts = append(parts, p.key+"="+p.value)
+		} else {
+			parts = append(parts, p.key)
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func (c *Canonicalizer) stripped(key string) bool {
+	name, err := url.QueryUnescape(key)
+	if err != nil {
+		name = key
+	}
+	name = strings.ToLower(name)
+	for _, pattern := range c.opts.StripParams {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizePercent декодирует percent-кодированные незарезервированные символы
+// и приводит остальные escape-последовательности к верхнему регистру.
+func normalizePercent(s string) string {
+	if !strings.Contains(s, "%") {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+			decoded := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if isUnreserved(decoded) {
+				b.WriteByte(decoded)
+			} else {
+				b.WriteByte('%')
+				b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+			}
+			i += 2
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
@@ -0,0 +1,47 @@
+package canonical_test
+
+import (
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/canonical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	c := canonical.New(canonical.Options{SortQuery: true, StripParams: canonical.DefaultStripParams})
+
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{"https://Example.com:443/a?b=1&a=2", "https://example.com/a?a=2&b=1"},
+		{"https://example.com/a?a=2&b=1", "https://example.com/a?a=2&b=1"},
+		{"HTTP://EXAMPLE.com:80", "http://example.com/"},
+		{"http://example.com:8080/x", "http://example.com:8080/x"},
+		{"https://example.com/%7euser/%e2%82%ac", "https://example.com/~user/%E2%82%AC"},
+		{"https://пример.рф/путь", "https://xn--e1afmkfd.xn--p1ai/%D0%BF%D1%83%D1%82%D1%8C"},
+		{"https://example.com/?utm_source=x&id=5&fbclid=abc&UTM_Medium=y", "https://example.com/?id=5"},
+		{"https://example.com./a#Frag", "https://example.com/a#Frag"},
+	}
+	for _, tt := range tests {
+		got, err := c.Canonicalize(tt.raw)
+		require.NoError(t, err, tt.raw)
+		assert.Equal(t, tt.want, got, tt.raw)
+	}
+}
+
+func TestCanonicalize_KeepsQueryOrderWhenSortingDisabled(t *testing.T) {
+	c := canonical.New(canonical.Options{})
+
+	got, err := c.Canonicalize("https://example.com/a?b=1&a=2&utm_source=x")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/a?b=1&a=2&utm_source=x", got)
+}
+
+func TestCanonicalize_Invalid(t *testing.T) {
+	c := canonical.New(canonical.Options{})
+
+	_, err := c.Canonicalize("/relative/path")
+	assert.Error(t, err)
+}
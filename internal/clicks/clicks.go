@@ -0,0 +1,230 @@
+// Package clicks собирает события переходов по ссылкам в ограниченный буфер
+// и пакетами записывает их в хранилище фоновым воркером.
+package clicks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"go.uber.org/zap"
+)
+
+// Sink записывает пачку событий.
+type Sink interface {
+	WriteClicks(ctx context.Context, clicks []model.Click) error
+}
+
+// SinkFunc позволяет использовать функцию как Sink.
+type SinkFunc func(ctx context.Context, clicks []model.Click) error
+
+// WriteClicks вызывает f.
+func (f SinkFunc) WriteClicks(ctx context.Context, clicks []model.Click) error {
+	return f(ctx, clicks)
+}
+
+// MultiSink записывает пачку в каждый Sink по очереди и останавливается на первой ошибке.
+type MultiSink []Sink
+
+// WriteClicks реализует Sink.
+func (m MultiSink) WriteClicks(ctx context.Context, clicks []model.Click) error {
+	for _, s := range m {
+		if err := s.WriteClicks(ctx, clicks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Stats — счётчики работы Tracker.
+type Stats struct {
+	// Dropped — события, отброшенные из-за переполнения буфера.
+	Dropped uint64
+	// Written — события, успешно записанные в Sink.
+	Written uint64
+	// Failed — события, потерянные из-за ошибки записи.
+	Failed uint64
+	// Buffered — события, ожидающие записи.
+	Buffered int
+}
+
+// Tracker принимает события без блокировки и записывает их пачками.
+type Tracker struct {
+	// Salt добавляется к IP-адресу перед хешированием.
+	Salt string
+
+	sink          Sink
+	logger        *zap.Logger
+	batchSize     int
+	flushInterval time.Duration
+
+	events chan model.Click
+	quit   chan struct{}
+	done   chan struct{}
+
+	dropped atomic.Uint64
+	written atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// DefaultFlushInterval — период записи, если flushInterval не положителен.
+const DefaultFlushInterval = time.Second
+
+// NewTracker создаёт Tracker с буфером bufferSize событий.
+// Пачка записывается при накоплении batchSize событий или раз в flushInterval.
+func NewTracker(sink Sink, bufferSize, batchSize int, flushInterval time.Duration, logger *zap.Logger) *Tracker {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	return &Tracker{
+		sink:          sink,
+		logger:        logger,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		events:        make(chan model.Click, bufferSize),
+		quit:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Track ставит событие в буфер. Если буфер заполнен, событие отбрасывается
+// и учитывается в счётчике Dropped — запрос пользователя не ждёт записи.
+func (t *Tracker) Track(click model.Click) bool {
+	select {
+	case t.events <- click:
+		return true
+	default:
+		t.dropped.Add(1)
+		return false
+	}
+}
+
+// Stats возвращает текущие значения счётчиков.
+func (t *Tracker) Stats() Stats {
+	return Stats{
+		Dropped:  t.dropped.Load(),
+		Written:  t.written.Load(),
+		Failed:   t.failed.Load(),
+		Buffered: len(t.events),
+	}
+}
+
+// Start запускает фоновую запись.
+func (t *Tracker) Start() {
+	go t.run()
+}
+
+// Shutdown прекращает фоновую запись, предварительно записав все события из буфера.
+// Возвращает ошибку ctx, если запись не успела завершиться.
+func (t *Tracker) Shutdown(ctx context.Context) error {
+	close(t.quit)
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *Tracker) run() {
+	defer close(t.done)
+
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]model.Click, 0, t.batchSize)
+	for {
+		select {
+		case click := <-t.events:
+			batch = append(batch, click)
+			if len(batch) >= t.batchSize {
+				batch = t.flush(batch)
+			}
+		case <-ticker.C:
+			batch = t.flush(batch)
+		case <-t.quit:
+			for {
+				select {
+				case click := <-t.events:
+					batch = append(batch, click)
+					if len(batch) >= t.batchSize {
+						batch = t.flush(batch)
+					}
+				default:
+					t.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush записывает пачку и возвращает пустой срез для следующей.
+func (t *Tracker) flush(batch []model.Click) []model.Click {
+	if len(batch) == 0 {
+		return batch
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := t.sink.WriteClicks(ctx, batch); err != nil {
+		t.failed.Add(uint64(len(batch)))
+		t.logger.Error("Failed to write clicks", zap.Int("count", len(batch)), zap.Error(err))
+	} else {
+		t.written.Add(uint64(len(batch)))
+	}
+	return batch[:0]
+}
+
+// HashIP возвращает хеш IP-адреса с солью трекера.
+func (t *Tracker) HashIP(ip string) string {
+	return HashIP(t.Salt, ip)
+}
+
+// HashIP возвращает хеш IP-адреса с солью, чтобы не хранить адрес в открытом виде.
+func HashIP(salt, ip string) string {
+	if ip == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(salt + ip))
+	return hex.EncodeToString(sum[:16])
+}
+
+// ClientIP возвращает адрес клиента из X-Real-IP или адреса соединения.
+func ClientIP(r *http.Request) string {
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// countryHeaders — заголовки, в которых CDN и обратные прокси передают страну клиента.
+var countryHeaders = []string{"CF-IPCountry", "CloudFront-Viewer-Country", "X-Country-Code"}
+
+// Country возвращает код страны клиента из заголовков прокси или пустую строку.
+// Служебные коды Cloudflare (XX — неизвестно, T1 — Tor) не считаются страной.
+func Country(r *http.Request) string {
+	for _, h := range countryHeaders {
+		code := strings.ToUpper(strings.TrimSpace(r.Header.Get(h)))
+		if len(code) != 2 || code == "XX" || code == "T1" {
+			continue
+		}
+		if code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z' {
+			return code
+		}
+	}
+	return ""
+}
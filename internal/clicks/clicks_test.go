@@ -0,0 +1,131 @@
+package clicks_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/clicks"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	batches [][]model.Click
+	block   chan struct{}
+}
+
+func (s *memorySink) WriteClicks(_ context.Context, batch []model.Click) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, append([]model.Click(nil), batch...))
+	return nil
+}
+
+func (s *memorySink) total() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, b := range s.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func TestTracker_BatchesAndDrainsOnShutdown(t *testing.T) {
+	sink := &memorySink{}
+	tracker := clicks.NewTracker(sink, 100, 3, time.Hour, zap.NewNop())
+	tracker.Start()
+
+	for i := 0; i < 7; i++ {
+		require.True(t, tracker.Track(model.Click{Short: "abc", Time: time.Now()}))
+	}
+	assert.Eventually(t, func() bool { return sink.total() == 6 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, tracker.Shutdown(context.Background()))
+	assert.Equal(t, 7, sink.total())
+	assert.Equal(t, uint64(7), tracker.Stats().Written)
+	for _, b := range sink.batches {
+		assert.LessOrEqual(t, len(b), 3)
+	}
+}
+
+func TestTracker_FlushInterval(t *testing.T) {
+	sink := &memorySink{}
+	tracker := clicks.NewTracker(sink, 100, 100, 20*time.Millisecond, zap.NewNop())
+	tracker.Start()
+	defer tracker.Shutdown(context.Background())
+
+	tracker.Track(model.Click{Short: "abc"})
+	assert.Eventually(t, func() bool { return sink.total() == 1 }, time.Second, 10*time.Millisecond)
+}
+
+func TestTracker_NonPositiveFlushInterval(t *testing.T) {
+	sink := &memorySink{}
+	tracker := clicks.NewTracker(sink, 100, 1, 0, zap.NewNop())
+	tracker.Start()
+
+	tracker.Track(model.Click{Short: "abc"})
+	assert.Eventually(t, func() bool { return sink.total() == 1 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, tracker.Shutdown(context.Background()))
+}
+
+func TestTracker_DropsWhenFull(t *testing.T) {
+	sink := &memorySink{block: make(chan struct{})}
+	tracker := clicks.NewTracker(sink, 2, 1, time.Hour, zap.NewNop())
+	tracker.Start()
+
+	// Первое событие занимает воркер, заблокированный в Sink, следующие два заполняют буфер.
+	tracker.Track(model.Click{Short: "a"})
+	assert.Eventually(t, func() bool { return tracker.Stats().Buffered == 0 }, time.Second, 5*time.Millisecond)
+	assert.True(t, tracker.Track(model.Click{Short: "b"}))
+	assert.True(t, tracker.Track(model.Click{Short: "c"}))
+
+	start := time.Now()
+	assert.False(t, tracker.Track(model.Click{Short: "d"}))
+	assert.Less(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, uint64(1), tracker.Stats().Dropped)
+
+	close(sink.block)
+	require.NoError(t, tracker.Shutdown(context.Background()))
+	assert.Equal(t, 3, sink.total())
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clicks.ndjson")
+	sink := &clicks.FileSink{Path: path}
+
+	require.NoError(t, sink.WriteClicks(context.Background(), []model.Click{{Short: "a", Variant: "default"}, {Short: "b"}}))
+	require.NoError(t, sink.WriteClicks(context.Background(), []model.Click{{Short: "c"}}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var shorts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var c model.Click
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &c))
+		shorts = append(shorts, c.Short)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, shorts)
+}
+
+func TestHashIP(t *testing.T) {
+	assert.Equal(t, clicks.HashIP("salt", "1.2.3.4"), clicks.HashIP("salt", "1.2.3.4"))
+	assert.NotEqual(t, clicks.HashIP("salt", "1.2.3.4"), clicks.HashIP("other", "1.2.3.4"))
+	assert.NotContains(t, clicks.HashIP("salt", "1.2.3.4"), "1.2.3.4")
+	assert.Empty(t, clicks.HashIP("salt", ""))
+}
@@ -0,0 +1,33 @@
+package clicks
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/Totarae/URLShortener/internal/model"
+)
+
+// FileSink дописывает события в файл в формате NDJSON.
+type FileSink struct {
+	Path string
+}
+
+// WriteClicks дописывает пачку событий в файл, по одному JSON-объекту в строке.
+func (s *FileSink) WriteClicks(_ context.Context, clicks []model.Click) error {
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+	enc := json.NewEncoder(w)
+	for _, c := range clicks {
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -6,7 +6,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/Totarae/URLShortener/internal/canonical"
+	"github.com/Totarae/URLShortener/internal/linkguard"
 	"github.com/spf13/viper"
 )
 
@@ -23,6 +27,108 @@ type Config struct {
 	Mode             string `json:"-"`
 	TrustedSubnet    string `json:"trusted_subnet"`
 	GRPCAddress      string `json:"grpc_address"`
+	// OwnDomains — дополнительные домены сервиса помимо BaseURL (через запятую).
+	OwnDomains string `json:"own_domains"`
+	// KnownShorteners — домены сторонних сокращателей (через запятую).
+	KnownShorteners  string `json:"known_shorteners"`
+	UnwrapShorteners bool   `json:"unwrap_shorteners"`
+	// CanonicalSortQuery включает сортировку параметров запроса при канонизации.
+	CanonicalSortQuery bool `json:"canonical_sort_query"`
+	// CanonicalStripParams — удаляемые параметры отслеживания (через запятую, допускается "utm_*").
+	CanonicalStripParams string `json:"canonical_strip_params"`
+	// AuthSecret — ключ подписи cookie auth_token, если не задан AuthKeyFile.
+	AuthSecret string `json:"auth_secret"`
+	// AuthKeyFile — файл ключей подписи (keyID:secret по строке, первый — активный).
+	AuthKeyFile string `json:"auth_key_file"`
+	// AuthCookieSecure выставляет флаг Secure; при ENABLE_HTTPS включается всегда.
+	AuthCookieSecure bool `json:"auth_cookie_secure"`
+	// AuthCookieSameSite — атрибут SameSite: lax, strict, none или пусто.
+	AuthCookieSameSite string `json:"auth_cookie_samesite"`
+	// AuthCookieDomain — домен cookie; пусто — только текущий хост.
+	AuthCookieDomain string `json:"auth_cookie_domain"`
+	// AuthCookieMaxAge — срок жизни cookie.
+	AuthCookieMaxAge time.Duration `json:"auth_cookie_max_age"`
+	// AuthTokenTTL — максимальный срок жизни токенов Authorization: Bearer.
+	AuthTokenTTL time.Duration `json:"auth_token_ttl"`
+	// APIKeysFile — файл ключей API в режиме file; в режиме in-memory ключи живут в памяти.
+	APIKeysFile string `json:"api_keys_file"`
+	// AccountsFile — файл учётных записей и сессий в режиме file.
+	AccountsFile string `json:"accounts_file"`
+	// OrgsFile — файл организаций и их участников в режиме file.
+	OrgsFile string `json:"orgs_file"`
+	// BansFile — файл запретов пользователей в режиме file.
+	BansFile string `json:"bans_file"`
+	// AdminUsers — идентификаторы учётных записей администраторов через запятую.
+	AdminUsers string `json:"admin_users"`
+	// SessionTTL — срок жизни сессии учётной записи.
+	SessionTTL time.Duration `json:"session_ttl"`
+	// OIDCIssuerURL — издатель OpenID Connect; пусто отключает вход через провайдер.
+	OIDCIssuerURL string `json:"oidc_issuer_url"`
+	// OIDCClientID — идентификатор клиента у провайдера.
+	OIDCClientID string `json:"oidc_client_id"`
+	// OIDCClientSecret — секрет клиента; пусто для публичного клиента.
+	OIDCClientSecret string `json:"oidc_client_secret"`
+	// OIDCRedirectURL — адрес возврата; пусто — BASE_URL + /api/auth/oidc/callback.
+	OIDCRedirectURL string `json:"oidc_redirect_url"`
+	// OIDCScopes — области помимо openid через запятую.
+	OIDCScopes string `json:"oidc_scopes"`
+	// BlocklistPath — файл правил блокировки адресов назначения.
+	BlocklistPath string `json:"blocklist_path"`
+	// BlocklistRetroactive отключает существующие ссылки при добавлении новых правил.
+	BlocklistRetroactive bool `json:"blocklist_retroactive"`
+	// HealthCheckInterval — период проверки доступности адресов назначения, 0 отключает проверку.
+	HealthCheckInterval time.Duration `json:"health_check_interval"`
+	// HealthCheckConcurrency ограничивает количество одновременных проверок.
+	HealthCheckConcurrency int `json:"health_check_concurrency"`
+	// HealthCheckHostInterval — минимальный интервал между запросами к одному хосту.
+	HealthCheckHostInterval time.Duration `json:"health_check_host_interval"`
+	// HealthCheckTimeout — таймаут одной проверки.
+	HealthCheckTimeout time.Duration `json:"health_check_timeout"`
+	// MetadataWorkers — количество воркеров загрузки метаданных, 0 отключает загрузку.
+	MetadataWorkers int `json:"metadata_workers"`
+	// MetadataQueueSize — размер очереди заданий на загрузку метаданных.
+	MetadataQueueSize int `json:"metadata_queue_size"`
+	// MetadataTimeout — таймаут загрузки страницы назначения.
+	MetadataTimeout time.Duration `json:"metadata_timeout"`
+	// MetadataMaxBytes ограничивает объём читаемой страницы.
+	MetadataMaxBytes int64 `json:"metadata_max_bytes"`
+	// ResolveCacheSize — количество ссылок в кеше разрешения, 0 отключает кеш.
+	ResolveCacheSize int `json:"resolve_cache_size"`
+	// ResolveCacheTTL ограничивает время, в течение которого другие экземпляры
+	// сервиса могут отдавать устаревший адрес после изменения ссылки.
+	ResolveCacheTTL time.Duration `json:"resolve_cache_ttl"`
+	// TrashRetention — срок, в течение которого удалённую ссылку можно восстановить.
+	TrashRetention time.Duration `json:"trash_retention"`
+	// ClicksBufferSize — размер буфера событий переходов, 0 отключает сбор.
+	ClicksBufferSize int `json:"clicks_buffer_size"`
+	// ClicksBatchSize — количество событий в одной записи.
+	ClicksBatchSize int `json:"clicks_batch_size"`
+	// ClicksFlushInterval — максимальная задержка записи накопленных событий.
+	ClicksFlushInterval time.Duration `json:"clicks_flush_interval"`
+	// ClicksFilePath — NDJSON-файл событий в режимах file и in-memory.
+	ClicksFilePath string `json:"clicks_file_path"`
+	// ClicksIPSalt — соль хеширования IP-адресов; если не задана, генерируется при запуске.
+	ClicksIPSalt string `json:"clicks_ip_salt"`
+	// BotRulesPath — файл дополнительных правил User-Agent ботов; перечитывается при изменении.
+	BotRulesPath string `json:"bot_rules_path"`
+	// ClicksSketchDir — каталог скетчей уникальных посетителей в режимах file и in-memory.
+	ClicksSketchDir string `json:"clicks_sketch_dir"`
+	// StreamBufferSize — буфер событий на подписчика потока переходов, 0 отключает поток.
+	StreamBufferSize int `json:"stream_buffer_size"`
+	// StreamHeartbeat — интервал служебных сообщений в потоке переходов.
+	StreamHeartbeat time.Duration `json:"stream_heartbeat"`
+	// MetricsEnabled включает сбор метрик Prometheus.
+	MetricsEnabled bool `json:"metrics_enabled"`
+	// MetricsAddress — отдельный адрес для /metrics; если пуст, метрики отдаются основным сервером.
+	MetricsAddress string `json:"metrics_address"`
+	// TracingExporter — экспортёр трассировки: stdout, otlp или пусто (отключено).
+	TracingExporter string `json:"tracing_exporter"`
+	// TracingEndpoint — адрес OTLP/gRPC коллектора.
+	TracingEndpoint string `json:"tracing_endpoint"`
+	// TracingInsecure отключает TLS при подключении к коллектору.
+	TracingInsecure bool `json:"tracing_insecure"`
+	// TracingSampleRatio — доля трасс, попадающих в выборку.
+	TracingSampleRatio float64 `json:"tracing_sample_ratio"`
 }
 
 // NewConfig инициализирует конфигурацию на основе аргументов командной строки
@@ -38,6 +144,57 @@ func NewConfig() *Config {
 	viper.SetDefault("TLS_KEY_PATH", "key.pem")
 	viper.SetDefault("TRUSTED_SUBNET", "")
 	viper.SetDefault("GRPC_ADDRESS", ":3200")
+	viper.SetDefault("OWN_DOMAINS", "")
+	viper.SetDefault("KNOWN_SHORTENERS", strings.Join(linkguard.DefaultShorteners, ","))
+	viper.SetDefault("UNWRAP_SHORTENERS", false)
+	viper.SetDefault("CANONICAL_SORT_QUERY", true)
+	viper.SetDefault("CANONICAL_STRIP_PARAMS", strings.Join(canonical.DefaultStripParams, ","))
+	viper.SetDefault("AUTH_SECRET", "")
+	viper.SetDefault("AUTH_KEY_FILE", "")
+	viper.SetDefault("AUTH_COOKIE_SECURE", false)
+	viper.SetDefault("AUTH_COOKIE_SAMESITE", "lax")
+	viper.SetDefault("AUTH_COOKIE_DOMAIN", "")
+	viper.SetDefault("AUTH_COOKIE_MAX_AGE", "8760h")
+	viper.SetDefault("AUTH_TOKEN_TTL", "1h")
+	viper.SetDefault("API_KEYS_FILE", "api_keys.json")
+	viper.SetDefault("ACCOUNTS_FILE", "accounts.json")
+	viper.SetDefault("ORGS_FILE", "orgs.json")
+	viper.SetDefault("BANS_FILE", "bans.json")
+	viper.SetDefault("ADMIN_USERS", "")
+	viper.SetDefault("SESSION_TTL", "720h")
+	viper.SetDefault("OIDC_ISSUER_URL", "")
+	viper.SetDefault("OIDC_CLIENT_ID", "")
+	viper.SetDefault("OIDC_CLIENT_SECRET", "")
+	viper.SetDefault("OIDC_REDIRECT_URL", "")
+	viper.SetDefault("OIDC_SCOPES", "email")
+	viper.SetDefault("BLOCKLIST_PATH", "")
+	viper.SetDefault("BLOCKLIST_RETROACTIVE", false)
+	viper.SetDefault("HEALTH_CHECK_INTERVAL", "6h")
+	viper.SetDefault("HEALTH_CHECK_CONCURRENCY", 8)
+	viper.SetDefault("HEALTH_CHECK_HOST_INTERVAL", "1s")
+	viper.SetDefault("HEALTH_CHECK_TIMEOUT", "10s")
+	viper.SetDefault("METADATA_WORKERS", 2)
+	viper.SetDefault("METADATA_QUEUE_SIZE", 1000)
+	viper.SetDefault("METADATA_TIMEOUT", "5s")
+	viper.SetDefault("METADATA_MAX_BYTES", 512<<10)
+	viper.SetDefault("RESOLVE_CACHE_SIZE", 10000)
+	viper.SetDefault("RESOLVE_CACHE_TTL", "1m")
+	viper.SetDefault("TRASH_RETENTION", "720h")
+	viper.SetDefault("CLICKS_BUFFER_SIZE", 10000)
+	viper.SetDefault("CLICKS_BATCH_SIZE", 500)
+	viper.SetDefault("CLICKS_FLUSH_INTERVAL", "1s")
+	viper.SetDefault("CLICKS_FILE_PATH", "clicks.ndjson")
+	viper.SetDefault("CLICKS_IP_SALT", "")
+	viper.SetDefault("CLICKS_SKETCH_DIR", "click_sketches")
+	viper.SetDefault("BOT_RULES_PATH", "")
+	viper.SetDefault("STREAM_BUFFER_SIZE", 64)
+	viper.SetDefault("STREAM_HEARTBEAT", "15s")
+	viper.SetDefault("METRICS_ENABLED", true)
+	viper.SetDefault("METRICS_ADDRESS", "")
+	viper.SetDefault("TRACING_EXPORTER", "")
+	viper.SetDefault("TRACING_ENDPOINT", "localhost:4317")
+	viper.SetDefault("TRACING_INSECURE", true)
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
 
 	viper.AutomaticEnv()
 
@@ -88,6 +245,65 @@ func NewConfig() *Config {
 		TLSKeyPath:       viper.GetString("TLS_KEY_PATH"),
 		TrustedSubnet:    viper.GetString("TRUSTED_SUBNET"),
 		GRPCAddress:      viper.GetString("GRPC_ADDRESS"),
+		OwnDomains:       viper.GetString("OWN_DOMAINS"),
+		KnownShorteners:  viper.GetString("KNOWN_SHORTENERS"),
+		UnwrapShorteners: viper.GetBool("UNWRAP_SHORTENERS"),
+
+		AuthSecret:         viper.GetString("AUTH_SECRET"),
+		AuthKeyFile:        viper.GetString("AUTH_KEY_FILE"),
+		AuthCookieSecure:   viper.GetBool("AUTH_COOKIE_SECURE"),
+		AuthCookieSameSite: viper.GetString("AUTH_COOKIE_SAMESITE"),
+		AuthCookieDomain:   viper.GetString("AUTH_COOKIE_DOMAIN"),
+		AuthCookieMaxAge:   viper.GetDuration("AUTH_COOKIE_MAX_AGE"),
+		AuthTokenTTL:       viper.GetDuration("AUTH_TOKEN_TTL"),
+		APIKeysFile:        viper.GetString("API_KEYS_FILE"),
+		AccountsFile:       viper.GetString("ACCOUNTS_FILE"),
+		OrgsFile:           viper.GetString("ORGS_FILE"),
+		BansFile:           viper.GetString("BANS_FILE"),
+		AdminUsers:         viper.GetString("ADMIN_USERS"),
+		SessionTTL:         viper.GetDuration("SESSION_TTL"),
+		OIDCIssuerURL:      viper.GetString("OIDC_ISSUER_URL"),
+		OIDCClientID:       viper.GetString("OIDC_CLIENT_ID"),
+		OIDCClientSecret:   viper.GetString("OIDC_CLIENT_SECRET"),
+		OIDCRedirectURL:    viper.GetString("OIDC_REDIRECT_URL"),
+		OIDCScopes:         viper.GetString("OIDC_SCOPES"),
+
+		CanonicalSortQuery:   viper.GetBool("CANONICAL_SORT_QUERY"),
+		CanonicalStripParams: viper.GetString("CANONICAL_STRIP_PARAMS"),
+		BlocklistPath:        viper.GetString("BLOCKLIST_PATH"),
+		BlocklistRetroactive: viper.GetBool("BLOCKLIST_RETROACTIVE"),
+
+		HealthCheckInterval:     viper.GetDuration("HEALTH_CHECK_INTERVAL"),
+		HealthCheckConcurrency:  viper.GetInt("HEALTH_CHECK_CONCURRENCY"),
+		HealthCheckHostInterval: viper.GetDuration("HEALTH_CHECK_HOST_INTERVAL"),
+		HealthCheckTimeout:      viper.GetDuration("HEALTH_CHECK_TIMEOUT"),
+
+		MetadataWorkers:   viper.GetInt("METADATA_WORKERS"),
+		MetadataQueueSize: viper.GetInt("METADATA_QUEUE_SIZE"),
+		MetadataTimeout:   viper.GetDuration("METADATA_TIMEOUT"),
+		MetadataMaxBytes:  viper.GetInt64("METADATA_MAX_BYTES"),
+		ResolveCacheSize:  viper.GetInt("RESOLVE_CACHE_SIZE"),
+		ResolveCacheTTL:   viper.GetDuration("RESOLVE_CACHE_TTL"),
+		TrashRetention:    viper.GetDuration("TRASH_RETENTION"),
+
+		ClicksBufferSize:    viper.GetInt("CLICKS_BUFFER_SIZE"),
+		ClicksBatchSize:     viper.GetInt("CLICKS_BATCH_SIZE"),
+		ClicksFlushInterval: viper.GetDuration("CLICKS_FLUSH_INTERVAL"),
+		ClicksFilePath:      viper.GetString("CLICKS_FILE_PATH"),
+		ClicksIPSalt:        viper.GetString("CLICKS_IP_SALT"),
+		ClicksSketchDir:     viper.GetString("CLICKS_SKETCH_DIR"),
+		BotRulesPath:        viper.GetString("BOT_RULES_PATH"),
+
+		StreamBufferSize: viper.GetInt("STREAM_BUFFER_SIZE"),
+		StreamHeartbeat:  viper.GetDuration("STREAM_HEARTBEAT"),
+
+		MetricsEnabled: viper.GetBool("METRICS_ENABLED"),
+		MetricsAddress: viper.GetString("METRICS_ADDRESS"),
+
+		TracingExporter:    viper.GetString("TRACING_EXPORTER"),
+		TracingEndpoint:    viper.GetString("TRACING_ENDPOINT"),
+		TracingInsecure:    viper.GetBool("TRACING_INSECURE"),
+		TracingSampleRatio: viper.GetFloat64("TRACING_SAMPLE_RATIO"),
 	}
 
 	// Переопределяем значениями из переменных окружения (viper)
@@ -166,6 +382,17 @@ func NewConfig() *Config {
 	return cfg
 }
 
+// SplitList разбирает список значений, перечисленных через запятую.
+func SplitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // Validate проверяет корректность конфигурации
 func (cfg *Config) Validate() error {
 	if cfg.ServerAddress == "" {
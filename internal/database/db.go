@@ -5,6 +5,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.uber.org/zap"
 )
@@ -22,7 +23,8 @@ type DB struct {
 }
 
 // NewDB создает новое подключение к БД.
-func NewDB(logger *zap.Logger) (*DB, error) {
+// tracers получают события о каждом запросе (метрики, трассировка).
+func NewDB(logger *zap.Logger, tracers ...pgx.QueryTracer) (*DB, error) {
 	dsn := os.Getenv("DATABASE_DSN")
 	if dsn == "" {
 		logger.Fatal("DATABASE_DSN is not set")
@@ -33,6 +35,10 @@ func NewDB(logger *zap.Logger) (*DB, error) {
 		return nil, err
 	}
 
+	if len(tracers) > 0 {
+		config.ConnConfig.Tracer = Tracers(tracers)
+	}
+
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, err
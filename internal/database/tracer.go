@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Tracers передаёт события pgx нескольким трассировщикам по порядку.
+// Трассировщики, не поддерживающие COPY, его пропускают.
+type Tracers []pgx.QueryTracer
+
+// TraceQueryStart реализует pgx.QueryTracer.
+func (t Tracers) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	for _, tr := range t {
+		ctx = tr.TraceQueryStart(ctx, conn, data)
+	}
+	return ctx
+}
+
+// TraceQueryEnd реализует pgx.QueryTracer.
+func (t Tracers) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	for _, tr := range t {
+		tr.TraceQueryEnd(ctx, conn, data)
+	}
+}
+
+// TraceCopyFromStart реализует pgx.CopyFromTracer.
+func (t Tracers) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	for _, tr := range t {
+		if ct, ok := tr.(pgx.CopyFromTracer); ok {
+			ctx = ct.TraceCopyFromStart(ctx, conn, data)
+		}
+	}
+	return ctx
+}
+
+// TraceCopyFromEnd реализует pgx.CopyFromTracer.
+func (t Tracers) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	for _, tr := range t {
+		if ct, ok := tr.(pgx.CopyFromTracer); ok {
+			ct.TraceCopyFromEnd(ctx, conn, data)
+		}
+	}
+}
+
+// QueryName сводит текст SQL к виду "операция таблица", например "select urls".
+// Используется как метка метрик и имя span, поэтому число значений ограничено.
+func QueryName(sql string) string {
+	fields := strings.Fields(strings.ToLower(sql))
+	if len(fields) == 0 {
+		return "unknown"
+	}
+	verb := fields[0]
+	for i, f := range fields[:len(fields)-1] {
+		if f == "from" || f == "into" || f == "update" {
+			table := strings.Trim(fields[i+1], "(),;")
+			if table != "" && table != "select" {
+				return verb + " " + table
+			}
+		}
+	}
+	return verb
+}
+
+// CopyName возвращает имя операции COPY в том же виде, что и QueryName.
+func CopyName(table pgx.Identifier) string {
+	return "copy " + strings.Join(table, ".")
+}
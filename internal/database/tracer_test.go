@@ -0,0 +1,23 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryName(t *testing.T) {
+	tests := map[string]string{
+		"SELECT origin FROM urls WHERE shorten = $1":                  "select urls",
+		"INSERT INTO urls (origin, shorten) VALUES ($1, $2)":          "insert urls",
+		"UPDATE urls SET is_deleted = TRUE":                           "update urls",
+		"SELECT COUNT(*) FROM (SELECT 1 FROM clicks) t":               "select clicks",
+		"SELECT c.shorten FROM clicks c LEFT JOIN urls u ON u.id = 1": "select clicks",
+		"begin": "begin",
+		"   ":   "unknown",
+	}
+	for sql, want := range tests {
+		assert.Equal(t, want, database.QueryName(sql), sql)
+	}
+}
@@ -0,0 +1,136 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/Totarae/URLShortener/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodScopes — методы, работающие со ссылками пользователя, и области доступа,
+// которые для них нужны. Остальные методы (Resolve, GetStats) учётных данных не требуют.
+var methodScopes = map[string]string{
+	"Shorten":          auth.ScopeLinksWrite,
+	"BatchShorten":     auth.ScopeLinksWrite,
+	"SetRedirectRules": auth.ScopeLinksWrite,
+	"SetPreview":       auth.ScopeLinksWrite,
+	"UpdateURL":        auth.ScopeLinksWrite,
+	"GetUserURLs":      auth.ScopeLinksRead,
+	"GetRedirectRules": auth.ScopeLinksRead,
+	"ListBrokenURLs":   auth.ScopeLinksRead,
+	"ListRevisions":    auth.ScopeLinksRead,
+	"ListTrash":        auth.ScopeLinksRead,
+	"DeleteUserURLs":   auth.ScopeLinksDelete,
+	"RestoreURLs":      auth.ScopeLinksDelete,
+	"GetLinkStats":     auth.ScopeStatsRead,
+	"WatchClicks":      auth.ScopeStatsRead,
+
+	"CreateOrganization": auth.ScopeLinksWrite,
+	"SetMember":          auth.ScopeLinksWrite,
+	"RemoveMember":       auth.ScopeLinksWrite,
+	"ListOrganizations":  auth.ScopeLinksRead,
+	"ListMembers":        auth.ScopeLinksRead,
+}
+
+// userTokenKey — ключ метаданных со значением в формате cookie auth_token.
+const userTokenKey = "x-auth-token"
+
+// authenticate проверяет учётные данные вызова и области доступа метода
+// и сохраняет пользователя в контексте. Методы из methodScopes без учётных
+// данных отклоняются.
+func authenticate(ctx context.Context, a *auth.Auth, fullMethod string) (context.Context, error) {
+	id, ok, err := credentials(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	scope, private := methodScopes[path.Base(fullMethod)]
+	if !ok {
+		if private {
+			return nil, status.Error(codes.Unauthenticated, "credentials required: authorization or "+userTokenKey+" metadata")
+		}
+		return ctx, nil
+	}
+	if scope != "" && !id.HasScope(scope) {
+		return nil, status.Errorf(codes.PermissionDenied, "insufficient scope: %s required", scope)
+	}
+	return auth.WithIdentity(ctx, id), nil
+}
+
+// credentials разбирает метаданные вызова: authorization: Bearer с JWT или ключом API
+// либо x-auth-token с подписанным значением, как в cookie auth_token.
+func credentials(ctx context.Context, a *auth.Auth) (auth.Identity, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, raw, found := strings.Cut(values[0], " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return auth.Identity{}, false, status.Error(codes.Unauthenticated, "authorization must be a Bearer token")
+		}
+		id, err := a.IdentifyBearer(ctx, strings.TrimSpace(raw))
+		if errors.Is(err, auth.ErrInvalidToken) {
+			return auth.Identity{}, false, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		if err != nil {
+			return auth.Identity{}, false, status.Errorf(codes.Internal, "authentication failed: %v", err)
+		}
+		return id, true, nil
+	}
+	if values := md.Get(userTokenKey); len(values) > 0 {
+		id, ok := a.VerifyUserToken(values[0])
+		if !ok {
+			return auth.Identity{}, false, status.Error(codes.Unauthenticated, "invalid "+userTokenKey)
+		}
+		return id, true, nil
+	}
+	return auth.Identity{}, false, nil
+}
+
+// callerID возвращает пользователя из учётных данных вызова. Поле user_id
+// запроса необязательно, но если задано, должно с ним совпадать.
+func callerID(ctx context.Context, requested string) (string, error) {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "credentials required")
+	}
+	if requested != "" && requested != id.UserID {
+		return "", status.Error(codes.PermissionDenied, "user_id does not match credentials")
+	}
+	return id.UserID, nil
+}
+
+// UnaryAuthInterceptor проверяет Bearer-токены и ключи API унарных вызовов.
+func UnaryAuthInterceptor(a *auth.Auth) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, a, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor проверяет Bearer-токены и ключи API потоковых вызовов.
+func StreamAuthInterceptor(a *auth.Auth) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), a, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authStream подменяет контекст потока контекстом с пользователем.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,229 @@
+package v2
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/auth"
+	pb "github.com/Totarae/URLShortener/internal/pkg/proto_gen"
+	"github.com/Totarae/URLShortener/internal/service"
+	"github.com/Totarae/URLShortener/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestClient(t *testing.T, a *auth.Auth, svc *service.ShortenerService) pb.ShortenerServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryAuthInterceptor(a)),
+		grpc.ChainStreamInterceptor(StreamAuthInterceptor(a)),
+	)
+	handler := NewGRPCServer(svc)
+	handler.Auth = a
+	// Доверенная подсеть не даёт прав администратора, даже если x-real-ip в неё попадает
+	_, handler.TrustedSubnet, _ = net.ParseCIDR("127.0.0.0/8")
+	pb.RegisterShortenerServiceServer(srv, handler)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewShortenerServiceClient(conn)
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	a := auth.New("test-secret")
+	client := newTestClient(t, a, svc)
+
+	_, err := svc.ShortenURL(context.Background(), "victim", "https://example.com/private")
+	require.NoError(t, err)
+	_, err = svc.ShortenURL(context.Background(), "owner", "https://example.com/own")
+	require.NoError(t, err)
+
+	with := func(kv ...string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), kv...)
+	}
+	code := func(err error) codes.Code { return status.Code(err) }
+
+	// Без учётных данных чужие ссылки недоступны
+	_, err = client.GetUserURLs(context.Background(), &pb.GetUserURLsRequest{UserId: "victim"})
+	assert.Equal(t, codes.Unauthenticated, code(err))
+	_, err = client.GetUserURLs(with("x-auth-token", "victim:default:00"), &pb.GetUserURLsRequest{})
+	assert.Equal(t, codes.Unauthenticated, code(err))
+
+	owner := with("x-auth-token", a.SignCookieValue("owner"))
+	resp, err := client.GetUserURLs(owner, &pb.GetUserURLsRequest{})
+	require.NoError(t, err)
+	if assert.Len(t, resp.Urls, 1) {
+		assert.Equal(t, "https://example.com/own", resp.Urls[0].OriginalUrl)
+	}
+	_, err = client.GetUserURLs(owner, &pb.GetUserURLsRequest{UserId: "victim"})
+	assert.Equal(t, codes.PermissionDenied, code(err))
+	_, err = client.DeleteUserURLs(owner, &pb.DeleteUserURLsRequest{UserId: "victim", ShortUrls: []string{"abc"}})
+	assert.Equal(t, codes.PermissionDenied, code(err))
+
+	// Токен с областью links:read не даёт сокращать
+	token, _, err := a.IssueToken("owner", []string{auth.ScopeLinksRead}, 0)
+	require.NoError(t, err)
+	bearer := with("authorization", "Bearer "+token)
+	_, err = client.GetUserURLs(bearer, &pb.GetUserURLsRequest{UserId: "owner"})
+	assert.NoError(t, err)
+	_, err = client.Shorten(bearer, &pb.ShortenRequest{Url: "https://example.com/new"})
+	assert.Equal(t, codes.PermissionDenied, code(err))
+
+	stream, err := client.WatchClicks(context.Background(), &pb.WatchClicksRequest{UserId: "victim"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, code(err))
+
+	// Resolve открыт всем
+	_, err = client.Resolve(context.Background(), &pb.ResolveRequest{ShortUrl: "missing"})
+	assert.Equal(t, codes.NotFound, code(err))
+}
+
+func TestOrganizationRoles(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	a := auth.New("test-secret")
+	client := newTestClient(t, a, svc)
+
+	as := func(user string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-auth-token", a.SignCookieValue(user))
+	}
+	code := func(err error) codes.Code { return status.Code(err) }
+
+	org, err := client.CreateOrganization(as("alice"), &pb.CreateOrganizationRequest{Name: "Sales"})
+	require.NoError(t, err)
+	assert.Equal(t, "owner", org.Role)
+	_, err = client.SetMember(as("alice"), &pb.SetMemberRequest{OrgId: org.Id, UserId: "carol", Role: "viewer"})
+	require.NoError(t, err)
+
+	short, err := client.Shorten(as("alice"), &pb.ShortenRequest{OrgId: org.Id, Url: "https://example.com/deal"})
+	require.NoError(t, err)
+	resp, err := client.GetUserURLs(as("carol"), &pb.GetUserURLsRequest{OrgId: org.Id})
+	require.NoError(t, err)
+	assert.Len(t, resp.Urls, 1)
+	personal, err := client.GetUserURLs(as("alice"), &pb.GetUserURLsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, personal.Urls, "ссылка принадлежит организации")
+
+	_, err = client.Shorten(as("carol"), &pb.ShortenRequest{OrgId: org.Id, Url: "https://example.com/x"})
+	assert.Equal(t, codes.PermissionDenied, code(err))
+	_, err = client.DeleteUserURLs(as("carol"), &pb.DeleteUserURLsRequest{OrgId: org.Id, ShortUrls: []string{short.ShortUrl}})
+	assert.Equal(t, codes.PermissionDenied, code(err))
+	_, err = client.GetUserURLs(as("mallory"), &pb.GetUserURLsRequest{OrgId: org.Id})
+	assert.Equal(t, codes.NotFound, code(err))
+	_, err = client.RemoveMember(as("alice"), &pb.RemoveMemberRequest{OrgId: org.Id, UserId: "alice"})
+	assert.Equal(t, codes.FailedPrecondition, code(err))
+
+	members, err := client.ListMembers(as("carol"), &pb.ListMembersRequest{OrgId: org.Id})
+	require.NoError(t, err)
+	assert.Len(t, members.Members, 2)
+}
+
+func TestAdminModeration(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	a := auth.New("test-secret")
+	a.Admins = []string{"root"}
+	client := newTestClient(t, a, svc)
+
+	as := func(user string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-auth-token", a.SignCookieValue(user))
+	}
+	token, _, err := a.IssueSessionToken("root", nil, 0)
+	require.NoError(t, err)
+	admin := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	plain, _, err := a.IssueToken("root", nil, 0)
+	require.NoError(t, err)
+
+	spam, err := client.Shorten(as("spammer"), &pb.ShortenRequest{Url: "https://spam.example.com/win"})
+	require.NoError(t, err)
+	_, err = client.Shorten(as("alice"), &pb.ShortenRequest{Url: "https://example.org/"})
+	require.NoError(t, err)
+
+	_, err = client.AdminSearchLinks(as("alice"), &pb.AdminSearchLinksRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.AdminSearchLinks(as("root"), &pb.AdminSearchLinksRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "анонимная cookie не даёт роли администратора")
+	_, err = client.AdminSearchLinks(metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+plain), &pb.AdminSearchLinksRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "токен выпущен не по сессии")
+	_, err = client.AdminSearchLinks(metadata.AppendToOutgoingContext(context.Background(), "x-real-ip", "127.0.0.1"), &pb.AdminSearchLinksRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	found, err := client.AdminSearchLinks(admin, &pb.AdminSearchLinksRequest{Domain: "example.com"})
+	require.NoError(t, err)
+	require.Len(t, found.Links, 1)
+	assert.Equal(t, spam.ShortUrl, found.Links[0].ShortUrl)
+	assert.Equal(t, "spammer", found.Links[0].UserId)
+
+	banned, err := client.BanUser(admin, &pb.BanUserRequest{UserId: "spammer", Reason: "spam", DisableLinks: true})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), banned.DisabledLinks)
+	assert.Equal(t, "root", banned.Ban.By)
+
+	_, err = client.Shorten(as("spammer"), &pb.ShortenRequest{Url: "https://spam.example.com/again"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = client.Resolve(context.Background(), &pb.ResolveRequest{ShortUrl: spam.ShortUrl})
+	assert.Error(t, err)
+
+	activity, err := client.GetUserActivity(admin, &pb.GetUserActivityRequest{UserId: "spammer"})
+	require.NoError(t, err)
+	assert.Equal(t, "spam", activity.Ban.GetReason())
+	assert.Equal(t, int64(1), activity.Disabled)
+	assert.Len(t, activity.Recent, 1)
+
+	_, err = client.UnbanUser(admin, &pb.UnbanUserRequest{UserId: "spammer"})
+	require.NoError(t, err)
+	_, err = client.UnbanUser(admin, &pb.UnbanUserRequest{UserId: "spammer"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.AdminDeleteLink(admin, &pb.AdminDeleteLinkRequest{ShortUrl: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetStats_TrustedSubnet(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+
+	// Статистику получает только соединение из подсети; x-real-ip задаёт сам клиент
+	dial := func(subnet string) pb.ShortenerServiceClient {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		srv := grpc.NewServer()
+		handler := NewGRPCServer(svc)
+		_, handler.TrustedSubnet, _ = net.ParseCIDR(subnet)
+		pb.RegisterShortenerServiceServer(srv, handler)
+		go srv.Serve(lis)
+		t.Cleanup(srv.Stop)
+
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		return pb.NewShortenerServiceClient(conn)
+	}
+
+	spoofed := metadata.AppendToOutgoingContext(context.Background(), "x-real-ip", "10.0.0.1")
+	_, err := dial("10.0.0.0/8").GetStats(spoofed, &pb.GetStatsRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = dial("127.0.0.0/8").GetStats(context.Background(), &pb.GetStatsRequest{})
+	assert.NoError(t, err)
+}
@@ -2,17 +2,31 @@ package v2
 
 import (
 	"context"
+	"errors"
+	"github.com/Totarae/URLShortener/internal/analytics"
+	"github.com/Totarae/URLShortener/internal/auth"
 	"github.com/Totarae/URLShortener/internal/model"
 	pb "github.com/Totarae/URLShortener/internal/pkg/proto_gen"
+	"github.com/Totarae/URLShortener/internal/rules"
 	"github.com/Totarae/URLShortener/internal/service"
+	"github.com/Totarae/URLShortener/internal/useragent"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"net"
 	"net/url"
+	"strings"
+	"time"
 )
 
 type GRPCServer struct {
 	pb.UnimplementedShortenerServiceServer
 	Service *service.ShortenerService
+	// TrustedSubnet разрешает вызов GetStats; nil запрещает его всем.
+	TrustedSubnet *net.IPNet
+	// Auth определяет администраторов; nil запрещает методы модерации всем.
+	Auth *auth.Auth
 }
 
 func NewGRPCServer(svc *service.ShortenerService) *GRPCServer {
@@ -20,6 +34,11 @@ func NewGRPCServer(svc *service.ShortenerService) *GRPCServer {
 }
 
 func (s *GRPCServer) Shorten(ctx context.Context, req *pb.ShortenRequest) (*pb.ShortenResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
 	if req.GetUrl() == "" {
 
 		return nil, status.Errorf(codes.InvalidArgument, "URL is empty")
@@ -29,9 +48,14 @@ func (s *GRPCServer) Shorten(ctx context.Context, req *pb.ShortenRequest) (*pb.S
 		return nil, status.Errorf(codes.InvalidArgument, "invalid URL")
 	}
 
-	short, err := s.Service.ShortenURL(ctx, req.GetUserId(), req.GetUrl())
+	var short string
+	if orgID := req.GetOrgId(); orgID != "" {
+		short, err = s.Service.ShortenForOrganization(ctx, userID, orgID, req.GetUrl())
+	} else {
+		short, err = s.Service.ShortenURL(ctx, userID, req.GetUrl())
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "shorten failed: %v", err)
+		return nil, serviceError("shorten failed", err)
 	}
 
 	return &pb.ShortenResponse{ShortUrl: short}, nil
@@ -49,10 +73,23 @@ func (s *GRPCServer) Resolve(ctx context.Context, req *pb.ResolveRequest) (*pb.R
 	if urlObj == nil || urlObj.IsDeleted {
 		return nil, status.Error(codes.NotFound, "not found")
 	}
-	return &pb.ResolveResponse{OriginalUrl: urlObj.Origin}, nil
+	if urlObj.IsDisabled {
+		return nil, status.Error(codes.PermissionDenied, "link disabled")
+	}
+	target, _ := rules.Resolve(urlObj, rules.Request{
+		UserAgent:      req.GetUserAgent(),
+		AcceptLanguage: req.GetAcceptLanguage(),
+		Time:           time.Now(),
+	})
+	return &pb.ResolveResponse{OriginalUrl: target, ForcePreview: urlObj.ForcePreview}, nil
 }
 
 func (s *GRPCServer) BatchShorten(ctx context.Context, req *pb.BatchShortenRequest) (*pb.BatchShortenResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
 	if len(req.Urls) == 0 {
 		return nil, status.Error(codes.InvalidArgument, "no URLs provided")
 	}
@@ -67,9 +104,9 @@ func (s *GRPCServer) BatchShorten(ctx context.Context, req *pb.BatchShortenReque
 			OriginalURL:   item.OriginalUrl,
 		})
 	}
-	results, err := s.Service.BatchShorten(ctx, req.UserId, items)
+	results, err := s.Service.BatchShorten(ctx, userID, items)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "batch shorten failed: %v", err)
+		return nil, serviceError("batch shorten failed", err)
 	}
 	resp := make([]*pb.BatchShortenResult, 0, len(results))
 	for _, r := range results {
@@ -82,29 +119,653 @@ func (s *GRPCServer) BatchShorten(ctx context.Context, req *pb.BatchShortenReque
 }
 
 func (s *GRPCServer) GetUserURLs(ctx context.Context, req *pb.GetUserURLsRequest) (*pb.GetUserURLsResponse, error) {
-	if req.UserId == "" {
-		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
 	}
 
-	results, err := s.Service.GetUserURLs(ctx, req.UserId)
+	var results []model.BatchResult
+	if orgID := req.GetOrgId(); orgID != "" {
+		results, err = s.Service.GetOrganizationURLs(ctx, userID, orgID)
+	} else {
+		results, err = s.Service.GetUserURLs(ctx, userID)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get user urls failed: %v", err)
+		return nil, serviceError("get user urls failed", err)
 	}
 	items := make([]*pb.GetUserURLsResponseItem, 0, len(results))
 	for _, r := range results {
-		items = append(items, &pb.GetUserURLsResponseItem{
+		item := &pb.GetUserURLsResponseItem{
 			ShortUrl:    r.ShortURL,
 			OriginalUrl: r.OriginalURL,
-		})
+		}
+		if r.Metadata != nil {
+			item.Metadata = &pb.LinkMetadata{
+				Title:       r.Metadata.Title,
+				Description: r.Metadata.Description,
+				OpenGraph:   r.Metadata.OpenGraph,
+				FetchedAt:   r.Metadata.FetchedAt.Unix(),
+			}
+		}
+		items = append(items, item)
 	}
 	return &pb.GetUserURLsResponse{Urls: items}, nil
 }
 
 func (s *GRPCServer) DeleteUserURLs(ctx context.Context, req *pb.DeleteUserURLsRequest) (*pb.DeleteUserURLsResponse, error) {
-	if req.UserId == "" || len(req.ShortUrls) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "user_id and short_urls are required")
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.ShortUrls) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "short_urls are required")
 	}
 
-	go s.Service.DeleteURLs(context.Background(), req.UserId, req.ShortUrls)
+	if orgID := req.GetOrgId(); orgID != "" {
+		if err := s.Service.DeleteOrganizationURLsAsync(ctx, userID, orgID, req.ShortUrls); err != nil {
+			return nil, serviceError("delete urls failed", err)
+		}
+	} else {
+		s.Service.DeleteURLsAsync(userID, req.ShortUrls)
+	}
 	return &pb.DeleteUserURLsResponse{Status: "accepted"}, nil
 }
+
+func (s *GRPCServer) GetRedirectRules(ctx context.Context, req *pb.GetRedirectRulesRequest) (*pb.GetRedirectRulesResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ShortUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "short_url is required")
+	}
+
+	list, err := s.Service.GetRedirectRules(ctx, userID, req.ShortUrl)
+	if err != nil {
+		return nil, serviceError("get redirect rules failed", err)
+	}
+	resp := make([]*pb.RedirectRule, 0, len(list))
+	for _, r := range list {
+		resp = append(resp, &pb.RedirectRule{
+			Name:      r.Name,
+			Devices:   r.Devices,
+			Languages: r.Languages,
+			Weekdays:  r.Weekdays,
+			TimeFrom:  r.TimeFrom,
+			TimeTo:    r.TimeTo,
+			DateFrom:  r.DateFrom,
+			DateTo:    r.DateTo,
+			Timezone:  r.Timezone,
+			Target:    r.Target,
+		})
+	}
+	return &pb.GetRedirectRulesResponse{Rules: resp}, nil
+}
+
+func (s *GRPCServer) SetRedirectRules(ctx context.Context, req *pb.SetRedirectRulesRequest) (*pb.SetRedirectRulesResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ShortUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "short_url is required")
+	}
+
+	list := make([]model.RedirectRule, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		list = append(list, model.RedirectRule{
+			Name:      r.GetName(),
+			Devices:   r.GetDevices(),
+			Languages: r.GetLanguages(),
+			Weekdays:  r.GetWeekdays(),
+			TimeFrom:  r.GetTimeFrom(),
+			TimeTo:    r.GetTimeTo(),
+			DateFrom:  r.GetDateFrom(),
+			DateTo:    r.GetDateTo(),
+			Timezone:  r.GetTimezone(),
+			Target:    r.GetTarget(),
+		})
+	}
+	if err := s.Service.SetRedirectRules(ctx, userID, req.ShortUrl, list); err != nil {
+		return nil, serviceError("set redirect rules failed", err)
+	}
+	return &pb.SetRedirectRulesResponse{Status: "ok"}, nil
+}
+
+func (s *GRPCServer) SetPreview(ctx context.Context, req *pb.SetPreviewRequest) (*pb.SetPreviewResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ShortUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "short_url is required")
+	}
+
+	if err := s.Service.SetForcePreview(ctx, userID, req.ShortUrl, req.Enabled); err != nil {
+		return nil, serviceError("set preview failed", err)
+	}
+	return &pb.SetPreviewResponse{Status: "ok"}, nil
+}
+
+func (s *GRPCServer) ListBrokenURLs(ctx context.Context, req *pb.ListBrokenURLsRequest) (*pb.ListBrokenURLsResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := s.Service.GetBrokenURLs(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list broken urls failed: %v", err)
+	}
+	items := make([]*pb.BrokenURL, 0, len(urls))
+	for _, u := range urls {
+		items = append(items, &pb.BrokenURL{
+			ShortUrl:    u.Shorten,
+			OriginalUrl: u.Origin,
+			Status:      int32(u.Health.Status),
+			LatencyMs:   u.Health.LatencyMs,
+			CheckedAt:   u.Health.CheckedAt.Unix(),
+			Error:       u.Health.Error,
+		})
+	}
+	return &pb.ListBrokenURLsResponse{Urls: items}, nil
+}
+
+func (s *GRPCServer) UpdateURL(ctx context.Context, req *pb.UpdateURLRequest) (*pb.UpdateURLResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ShortUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "short_url is required")
+	}
+	parsed, err := url.ParseRequestURI(req.GetUrl())
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid URL")
+	}
+
+	rev, err := s.Service.UpdateDestination(ctx, userID, req.ShortUrl, req.Url)
+	if err != nil {
+		return nil, serviceError("update url failed", err)
+	}
+	return &pb.UpdateURLResponse{ShortUrl: req.ShortUrl, OriginalUrl: rev.NewURL, PreviousUrl: rev.OldURL}, nil
+}
+
+func (s *GRPCServer) ListRevisions(ctx context.Context, req *pb.ListRevisionsRequest) (*pb.ListRevisionsResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ShortUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "short_url is required")
+	}
+
+	revisions, err := s.Service.GetRevisions(ctx, userID, req.ShortUrl)
+	if err != nil {
+		return nil, serviceError("list revisions failed", err)
+	}
+	items := make([]*pb.Revision, 0, len(revisions))
+	for _, r := range revisions {
+		items = append(items, &pb.Revision{
+			ChangedBy: r.ChangedBy,
+			ChangedAt: r.ChangedAt.Unix(),
+			OldUrl:    r.OldURL,
+			NewUrl:    r.NewURL,
+		})
+	}
+	return &pb.ListRevisionsResponse{Revisions: items}, nil
+}
+
+func (s *GRPCServer) GetLinkStats(ctx context.Context, req *pb.GetLinkStatsRequest) (*pb.GetLinkStatsResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ShortUrl == "" {
+		return nil, status.Error(codes.InvalidArgument, "short_url is required")
+	}
+
+	var from, to string
+	if req.From != 0 {
+		from = time.Unix(req.From, 0).UTC().Format(time.RFC3339)
+	}
+	if req.To != 0 {
+		to = time.Unix(req.To, 0).UTC().Format(time.RFC3339)
+	}
+	query, err := analytics.ParseQuery(from, to, req.Interval, time.Now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	query.IncludeBots = req.IncludeBots
+
+	stats, err := s.Service.GetLinkStats(ctx, userID, req.ShortUrl, query)
+	if err != nil {
+		return nil, serviceError("get link stats failed", err)
+	}
+	resp := &pb.GetLinkStatsResponse{
+		ShortUrl: stats.Short,
+		From:     stats.From.Unix(),
+		To:       stats.To.Unix(),
+		Interval: stats.Interval,
+		Total:    stats.Total,
+		Visitors: stats.Visitors,
+
+		IncludeBots: stats.IncludeBots,
+		Bots:        stats.Bots,
+		Buckets:     make([]*pb.StatsBucket, 0, len(stats.Buckets)),
+		Referrers:   statsEntries(stats.Referrers),
+		Browsers:    statsEntries(stats.Browsers),
+		Os:          statsEntries(stats.OS),
+		Countries:   statsEntries(stats.Countries),
+	}
+	for _, b := range stats.Buckets {
+		resp.Buckets = append(resp.Buckets, &pb.StatsBucket{Start: b.Start.Unix(), Clicks: b.Clicks, Visitors: b.Visitors})
+	}
+	return resp, nil
+}
+
+// GetStats возвращает сводную статистику сервиса клиентам из доверенной подсети.
+func (s *GRPCServer) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.GetStatsResponse, error) {
+	if !s.fromTrustedSubnet(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	days := int(req.Days)
+	if days == 0 {
+		days = service.DefaultStatsDays
+	}
+	if days < 1 || days > service.MaxStatsDays {
+		return nil, status.Errorf(codes.InvalidArgument, "days must be between 1 and %d", service.MaxStatsDays)
+	}
+
+	stats, err := s.Service.GetStats(ctx, days, req.IncludeBots)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get stats failed: %v", err)
+	}
+	resp := &pb.GetStatsResponse{
+		Total:         stats.Total,
+		Active:        stats.Active,
+		Deleted:       stats.Deleted,
+		Disabled:      stats.Disabled,
+		Users:         stats.Users,
+		Since:         stats.Since.Unix(),
+		Visitors:      stats.Visitors,
+		IncludeBots:   stats.IncludeBots,
+		CreatedPerDay: make([]*pb.DayCount, 0, len(stats.CreatedPerDay)),
+		TopLinks:      make([]*pb.TopLink, 0, len(stats.TopLinks)),
+	}
+	for _, d := range stats.CreatedPerDay {
+		resp.CreatedPerDay = append(resp.CreatedPerDay, &pb.DayCount{Day: d.Day.Unix(), Count: d.Count})
+	}
+	for _, l := range stats.TopLinks {
+		resp.TopLinks = append(resp.TopLinks, &pb.TopLink{ShortUrl: l.Short, OriginalUrl: l.Origin, Clicks: l.Clicks})
+	}
+	return resp, nil
+}
+
+// fromTrustedSubnet проверяет адрес соединения. Метаданные x-real-ip не учитываются:
+// перед gRPC нет прокси, который бы их переписывал, и их задаёт сам клиент.
+func (s *GRPCServer) fromTrustedSubnet(ctx context.Context) bool {
+	if s.TrustedSubnet == nil {
+		return false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && s.TrustedSubnet.Contains(ip)
+}
+
+func statsEntries(entries []model.StatsEntry) []*pb.StatsEntry {
+	result := make([]*pb.StatsEntry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, &pb.StatsEntry{Name: e.Name, Clicks: e.Clicks})
+	}
+	return result
+}
+
+func (s *GRPCServer) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	urls, err := s.Service.GetTrash(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "list trash failed: %v", err)
+	}
+	items := make([]*pb.TrashItem, 0, len(urls))
+	for _, u := range urls {
+		items = append(items, &pb.TrashItem{
+			ShortUrl:    u.Shorten,
+			OriginalUrl: u.Origin,
+			DeletedAt:   u.DeletedAt.Unix(),
+			ExpiresAt:   u.DeletedAt.Add(s.Service.TrashRetention).Unix(),
+		})
+	}
+	return &pb.ListTrashResponse{Items: items}, nil
+}
+
+func (s *GRPCServer) RestoreURLs(ctx context.Context, req *pb.RestoreURLsRequest) (*pb.RestoreURLsResponse, error) {
+	userID, err := callerID(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.ShortUrls) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "short_urls are required")
+	}
+
+	restored, err := s.Service.RestoreURLs(ctx, userID, req.ShortUrls)
+	if err != nil {
+		return nil, serviceError("restore urls failed", err)
+	}
+	return &pb.RestoreURLsResponse{Restored: restored}, nil
+}
+
+func (s *GRPCServer) CreateOrganization(ctx context.Context, req *pb.CreateOrganizationRequest) (*pb.Organization, error) {
+	userID, err := callerID(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.Service.CreateOrganization(ctx, userID, req.GetName())
+	if err != nil {
+		return nil, serviceError("create organization failed", err)
+	}
+	return organization(org), nil
+}
+
+func (s *GRPCServer) ListOrganizations(ctx context.Context, _ *pb.ListOrganizationsRequest) (*pb.ListOrganizationsResponse, error) {
+	userID, err := callerID(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.Service.ListOrganizations(ctx, userID)
+	if err != nil {
+		return nil, serviceError("list organizations failed", err)
+	}
+	items := make([]*pb.Organization, 0, len(list))
+	for _, w := range list {
+		items = append(items, organization(w))
+	}
+	return &pb.ListOrganizationsResponse{Organizations: items}, nil
+}
+
+func (s *GRPCServer) ListMembers(ctx context.Context, req *pb.ListMembersRequest) (*pb.ListMembersResponse, error) {
+	userID, err := callerID(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.Service.ListMembers(ctx, userID, req.GetOrgId())
+	if err != nil {
+		return nil, serviceError("list members failed", err)
+	}
+	items := make([]*pb.Member, 0, len(members))
+	for _, m := range members {
+		items = append(items, member(m))
+	}
+	return &pb.ListMembersResponse{Members: items}, nil
+}
+
+func (s *GRPCServer) SetMember(ctx context.Context, req *pb.SetMemberRequest) (*pb.Member, error) {
+	userID, err := callerID(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	m, err := s.Service.SetMember(ctx, userID, req.GetOrgId(), req.GetUserId(), req.GetRole())
+	if err != nil {
+		return nil, serviceError("set member failed", err)
+	}
+	return member(m), nil
+}
+
+func (s *GRPCServer) RemoveMember(ctx context.Context, req *pb.RemoveMemberRequest) (*pb.RemoveMemberResponse, error) {
+	userID, err := callerID(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Service.RemoveMember(ctx, userID, req.GetOrgId(), req.GetUserId()); err != nil {
+		return nil, serviceError("remove member failed", err)
+	}
+	return &pb.RemoveMemberResponse{}, nil
+}
+
+func organization(w model.Workspace) *pb.Organization {
+	return &pb.Organization{Id: w.ID, Name: w.Name, Role: w.Role, Created: w.Created.Unix()}
+}
+
+func member(m model.Membership) *pb.Member {
+	return &pb.Member{UserId: m.UserID, Role: m.Role, Created: m.Created.Unix()}
+}
+
+// requireAdmin пропускает только администраторов и возвращает, от чьего имени
+// выполняется действие. Доверенная подсеть прав не даёт: x-real-ip задаёт сам клиент.
+func (s *GRPCServer) requireAdmin(ctx context.Context) (string, error) {
+	if id, ok := auth.FromContext(ctx); ok && s.Auth != nil && s.Auth.IsAdmin(id) {
+		return id.UserID, nil
+	}
+	return "", status.Error(codes.PermissionDenied, "forbidden")
+}
+
+func (s *GRPCServer) AdminSearchLinks(ctx context.Context, req *pb.AdminSearchLinksRequest) (*pb.AdminSearchLinksResponse, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	q := model.LinkSearch{
+		Domain:         req.GetDomain(),
+		UserID:         req.GetUserId(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+		Limit:          int(req.GetLimit()),
+		Offset:         int(req.GetOffset()),
+	}
+	if req.GetFrom() != 0 {
+		q.From = time.Unix(req.GetFrom(), 0)
+	}
+	if req.GetTo() != 0 {
+		q.To = time.Unix(req.GetTo(), 0)
+	}
+	urls, err := s.Service.SearchLinks(ctx, q)
+	if err != nil {
+		return nil, serviceError("search links failed", err)
+	}
+	return &pb.AdminSearchLinksResponse{Links: adminLinks(urls)}, nil
+}
+
+func (s *GRPCServer) AdminDisableLink(ctx context.Context, req *pb.AdminDisableLinkRequest) (*pb.AdminDisableLinkResponse, error) {
+	actor, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Service.DisableLink(ctx, actor, req.GetShortUrl(), req.GetReason()); err != nil {
+		return nil, serviceError("disable link failed", err)
+	}
+	return &pb.AdminDisableLinkResponse{}, nil
+}
+
+func (s *GRPCServer) AdminDeleteLink(ctx context.Context, req *pb.AdminDeleteLinkRequest) (*pb.AdminDeleteLinkResponse, error) {
+	actor, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Service.DeleteLink(ctx, actor, req.GetShortUrl()); err != nil {
+		return nil, serviceError("delete link failed", err)
+	}
+	return &pb.AdminDeleteLinkResponse{}, nil
+}
+
+func (s *GRPCServer) BanUser(ctx context.Context, req *pb.BanUserRequest) (*pb.BanUserResponse, error) {
+	actor, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b, disabled, err := s.Service.BanUser(ctx, actor, req.GetUserId(), req.GetReason(), req.GetDisableLinks())
+	if err != nil {
+		return nil, serviceError("ban user failed", err)
+	}
+	return &pb.BanUserResponse{Ban: ban(&b), DisabledLinks: int32(disabled)}, nil
+}
+
+func (s *GRPCServer) UnbanUser(ctx context.Context, req *pb.UnbanUserRequest) (*pb.UnbanUserResponse, error) {
+	actor, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Service.UnbanUser(ctx, actor, req.GetUserId()); err != nil {
+		return nil, serviceError("unban user failed", err)
+	}
+	return &pb.UnbanUserResponse{}, nil
+}
+
+func (s *GRPCServer) GetUserActivity(ctx context.Context, req *pb.GetUserActivityRequest) (*pb.GetUserActivityResponse, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	activity, err := s.Service.UserActivity(ctx, req.GetUserId())
+	if err != nil {
+		return nil, serviceError("user activity failed", err)
+	}
+	resp := &pb.GetUserActivityResponse{
+		UserId:        activity.UserID,
+		Ban:           ban(activity.Ban),
+		Links:         activity.Links,
+		Active:        activity.Active,
+		Deleted:       activity.Deleted,
+		Disabled:      activity.Disabled,
+		Recent:        adminLinks(activity.Recent),
+		ApiKeys:       int32(activity.APIKeys),
+		Organizations: make([]*pb.Organization, 0, len(activity.Organizations)),
+	}
+	if activity.LastCreated != nil {
+		resp.LastCreated = activity.LastCreated.Unix()
+	}
+	for _, w := range activity.Organizations {
+		resp.Organizations = append(resp.Organizations, organization(w))
+	}
+	return resp, nil
+}
+
+func ban(b *model.Ban) *pb.Ban {
+	if b == nil {
+		return nil
+	}
+	return &pb.Ban{UserId: b.UserID, Reason: b.Reason, By: b.By, Created: b.Created.Unix()}
+}
+
+func adminLinks(urls []*model.URLObject) []*pb.AdminLink {
+	links := make([]*pb.AdminLink, 0, len(urls))
+	for _, u := range urls {
+		links = append(links, &pb.AdminLink{
+			ShortUrl:       u.Shorten,
+			OriginalUrl:    u.Origin,
+			UserId:         u.UserID,
+			Created:        u.Created.Unix(),
+			Deleted:        u.IsDeleted,
+			Disabled:       u.IsDisabled,
+			DisabledReason: u.DisabledReason,
+		})
+	}
+	return links
+}
+
+// WatchClicks отправляет переходы по ссылкам пользователя по мере их появления
+// и служебные сообщения heartbeat при отсутствии переходов. Если клиент не
+// успевает читать поток, вызов завершается с кодом ResourceExhausted.
+func (s *GRPCServer) WatchClicks(req *pb.WatchClicksRequest, stream pb.ShortenerService_WatchClicksServer) error {
+	userID, err := callerID(stream.Context(), req.GetUserId())
+	if err != nil {
+		return err
+	}
+
+	sub, err := s.Service.SubscribeClicks(userID)
+	if err != nil {
+		return serviceError("watch clicks failed", err)
+	}
+	defer sub.Close()
+
+	heartbeat := time.NewTicker(s.Service.Heartbeat())
+	defer heartbeat.Stop()
+
+	for {
+		var resp *pb.WatchClicksResponse
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.Done():
+			if sub.Evicted() {
+				return status.Error(codes.ResourceExhausted, "slow consumer evicted")
+			}
+			return nil
+		case click := <-sub.Events():
+			resp = &pb.WatchClicksResponse{Event: &pb.WatchClicksResponse_Click{Click: &pb.ClickEvent{
+				ShortUrl: click.Short,
+				Time:     click.Time.Unix(),
+				Referrer: click.Referrer,
+				Browser:  useragent.Browser(click.UserAgent),
+				Os:       useragent.OS(click.UserAgent),
+				Country:  click.Country,
+				Variant:  click.Variant,
+				Bot:      click.Bot,
+			}}}
+		case t := <-heartbeat.C:
+			resp = &pb.WatchClicksResponse{Event: &pb.WatchClicksResponse_Heartbeat{Heartbeat: t.Unix()}}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// serviceError преобразует ошибку сервиса в gRPC-статус.
+// Отклонённый адрес назначения возвращается как InvalidArgument с ErrorInfo,
+// где Reason содержит код причины в верхнем регистре.
+func serviceError(msg string, err error) error {
+	var destErr *service.DestinationError
+	if errors.As(err, &destErr) {
+		st := status.New(codes.InvalidArgument, destErr.Error())
+		info := &errdetails.ErrorInfo{
+			Reason:   strings.ToUpper(destErr.Code),
+			Domain:   "shortener",
+			Metadata: map[string]string{"url": destErr.URL},
+		}
+		if destErr.CorrelationID != "" {
+			info.Metadata["correlation_id"] = destErr.CorrelationID
+		}
+		if destErr.Rule != "" {
+			info.Metadata["rule"] = destErr.Rule
+		}
+		if detailed, detErr := st.WithDetails(info); detErr == nil {
+			st = detailed
+		}
+		return st.Err()
+	}
+	switch {
+	case errors.Is(err, service.ErrURLNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrBanNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrForbidden), errors.Is(err, service.ErrUserBanned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrInvalidRules), errors.Is(err, service.ErrInvalidOrganization),
+		errors.Is(err, service.ErrInvalidAdminRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrLastOwner):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrDestinationTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrStreamUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
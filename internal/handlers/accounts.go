@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/auth"
+	"github.com/Totarae/URLShortener/internal/service"
+)
+
+// CredentialsRequest — адрес и пароль для регистрации и входа.
+type CredentialsRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// AccountResponse — учётная запись, в которую выполнен вход.
+type AccountResponse struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// Claimed — число анонимных ссылок, перенесённых в учётную запись.
+	Claimed int64 `json:"claimed"`
+}
+
+// Register создаёт учётную запись и открывает сессию.
+// Ссылки из анонимной cookie переносятся в учётную запись.
+func (h *Handler) Register(res http.ResponseWriter, req *http.Request) {
+	h.login(res, req, http.StatusCreated, h.Service.Register)
+}
+
+// Login открывает сессию учётной записи.
+// Ссылки из анонимной cookie переносятся в учётную запись.
+func (h *Handler) Login(res http.ResponseWriter, req *http.Request) {
+	h.login(res, req, http.StatusOK, h.Service.Login)
+}
+
+// Logout завершает сессию и удаляет её cookie.
+func (h *Handler) Logout(res http.ResponseWriter, req *http.Request) {
+	if token, ok := auth.SessionToken(req); ok {
+		if err := h.Service.Logout(req.Context(), token); err != nil {
+			h.writeServiceError(res, "Logout error", err)
+			return
+		}
+	}
+	h.Auth.ClearSession(res)
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// login разбирает учётные данные, вызывает open и выдаёт cookie сессии.
+// Анонимная cookie заменяется новой, чтобы после выхода браузер не сохранил
+// доступ к перенесённым ссылкам.
+func (h *Handler) login(res http.ResponseWriter, req *http.Request, status int,
+	open func(ctx context.Context, email, password, anonUserID string) (*service.Login, error)) {
+	var body CredentialsRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	anonUserID, _ := h.Auth.AnonymousUserID(req)
+	login, err := open(req.Context(), body.Email, body.Password, anonUserID)
+	if err != nil {
+		h.writeServiceError(res, "Login error", err)
+		return
+	}
+
+	h.Auth.SetSession(res, login.Token, login.ExpiresAt)
+	if anonUserID != "" {
+		h.Auth.ResetUserID(res)
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(status)
+	json.NewEncoder(res).Encode(AccountResponse{
+		UserID:    login.Account.ID,
+		Email:     login.Account.Email,
+		ExpiresAt: login.ExpiresAt,
+		Claimed:   login.Claimed,
+	})
+}
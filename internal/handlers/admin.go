@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminLinkResponse — ссылка любого пользователя в ответах административного API.
+type AdminLinkResponse struct {
+	ShortURL       string     `json:"short_url"`
+	OriginalURL    string     `json:"original_url"`
+	UserID         string     `json:"user_id"`
+	Created        time.Time  `json:"created"`
+	Deleted        bool       `json:"deleted,omitempty"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+	Disabled       bool       `json:"disabled,omitempty"`
+	DisabledReason string     `json:"disabled_reason,omitempty"`
+}
+
+// DisableLinkRequest — причина отключения ссылки.
+type DisableLinkRequest struct {
+	Reason string `json:"reason"`
+}
+
+// BanRequest — запрет пользователя; DisableLinks отключает его активные ссылки.
+type BanRequest struct {
+	Reason       string `json:"reason"`
+	DisableLinks bool   `json:"disable_links"`
+}
+
+// BanResponse — выставленный запрет и число отключённых ссылок.
+type BanResponse struct {
+	model.Ban
+	DisabledLinks int `json:"disabled_links"`
+}
+
+// UserActivityResponse — сводка по пользователю с его последними ссылками.
+type UserActivityResponse struct {
+	*model.UserActivity
+	Recent []AdminLinkResponse `json:"recent"`
+}
+
+func (h *Handler) adminLinks(urls []*model.URLObject) []AdminLinkResponse {
+	resp := make([]AdminLinkResponse, 0, len(urls))
+	for _, u := range urls {
+		resp = append(resp, AdminLinkResponse{
+			ShortURL:       fmt.Sprintf("%s/%s", h.Service.BaseURL, u.Shorten),
+			OriginalURL:    u.Origin,
+			UserID:         u.UserID,
+			Created:        u.Created,
+			Deleted:        u.IsDeleted,
+			DeletedAt:      u.DeletedAt,
+			Disabled:       u.IsDisabled,
+			DisabledReason: u.DisabledReason,
+		})
+	}
+	return resp
+}
+
+// admin пропускает только администраторов и возвращает, от чьего имени
+// выполняется действие; остальным отвечает 403. Доверенная подсеть прав не даёт:
+// X-Real-IP задаёт сам клиент.
+func (h *Handler) admin(res http.ResponseWriter, req *http.Request) (string, bool) {
+	if h.Auth != nil {
+		if id, ok := h.Auth.Identify(req); ok && h.Auth.IsAdmin(id) {
+			return id.UserID, true
+		}
+	}
+	http.Error(res, "forbidden", http.StatusForbidden)
+	return "", false
+}
+
+// parseAdminTime принимает время в RFC 3339 или дату YYYY-MM-DD (UTC).
+func parseAdminTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, raw)
+}
+
+// SearchLinks ищет ссылки всех пользователей по домену назначения, владельцу и дате создания.
+func (h *Handler) SearchLinks(res http.ResponseWriter, req *http.Request) {
+	if _, ok := h.admin(res, req); !ok {
+		return
+	}
+	query := req.URL.Query()
+	q := model.LinkSearch{Domain: query.Get("domain"), UserID: query.Get("user")}
+	var err error
+	if q.From, err = parseAdminTime(query.Get("from")); err != nil {
+		http.Error(res, "from must be RFC 3339 time or YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if q.To, err = parseAdminTime(query.Get("to")); err != nil {
+		http.Error(res, "to must be RFC 3339 time or YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if raw := query.Get("deleted"); raw != "" {
+		if q.IncludeDeleted, err = strconv.ParseBool(raw); err != nil {
+			http.Error(res, "deleted must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+	for name, target := range map[string]*int{"limit": &q.Limit, "offset": &q.Offset} {
+		if raw := query.Get(name); raw != "" {
+			if *target, err = strconv.Atoi(raw); err != nil {
+				http.Error(res, name+" must be a number", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	urls, err := h.Service.SearchLinks(req.Context(), q)
+	if err != nil {
+		h.writeServiceError(res, "SearchLinks error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(h.adminLinks(urls))
+}
+
+// DisableLink отключает ссылку любого пользователя.
+func (h *Handler) DisableLink(res http.ResponseWriter, req *http.Request) {
+	actor, ok := h.admin(res, req)
+	if !ok {
+		return
+	}
+	var body DisableLinkRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.DisableLink(req.Context(), actor, chi.URLParam(req, "id"), body.Reason); err != nil {
+		h.writeServiceError(res, "DisableLink error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteLink удаляет ссылку любого пользователя.
+func (h *Handler) DeleteLink(res http.ResponseWriter, req *http.Request) {
+	actor, ok := h.admin(res, req)
+	if !ok {
+		return
+	}
+	if err := h.Service.DeleteLink(req.Context(), actor, chi.URLParam(req, "id")); err != nil {
+		h.writeServiceError(res, "DeleteLink error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// BanUser запрещает пользователю создавать ссылки.
+func (h *Handler) BanUser(res http.ResponseWriter, req *http.Request) {
+	actor, ok := h.admin(res, req)
+	if !ok {
+		return
+	}
+	var body BanRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	ban, disabled, err := h.Service.BanUser(req.Context(), actor, chi.URLParam(req, "user"), body.Reason, body.DisableLinks)
+	if err != nil {
+		h.writeServiceError(res, "BanUser error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(BanResponse{Ban: ban, DisabledLinks: disabled})
+}
+
+// UnbanUser снимает запрет с пользователя.
+func (h *Handler) UnbanUser(res http.ResponseWriter, req *http.Request) {
+	actor, ok := h.admin(res, req)
+	if !ok {
+		return
+	}
+	if err := h.Service.UnbanUser(req.Context(), actor, chi.URLParam(req, "user")); err != nil {
+		h.writeServiceError(res, "UnbanUser error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserActivity возвращает сводку по пользователю.
+func (h *Handler) GetUserActivity(res http.ResponseWriter, req *http.Request) {
+	if _, ok := h.admin(res, req); !ok {
+		return
+	}
+	activity, err := h.Service.UserActivity(req.Context(), chi.URLParam(req, "user"))
+	if err != nil {
+		h.writeServiceError(res, "UserActivity error", err)
+		return
+	}
+	if activity.Organizations == nil {
+		activity.Organizations = []model.Workspace{}
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(UserActivityResponse{UserActivity: activity, Recent: h.adminLinks(activity.Recent)})
+}
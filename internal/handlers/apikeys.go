@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// APIKeyRequest — запрос на создание ключа API.
+type APIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyResponse — ключ API. Key заполняется только в ответе на создание.
+type APIKeyResponse struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Scopes  []string  `json:"scopes"`
+	Created time.Time `json:"created"`
+	Key     string    `json:"key,omitempty"`
+}
+
+func apiKeyResponse(key model.APIKey) APIKeyResponse {
+	return APIKeyResponse{ID: key.ID, Name: key.Name, Scopes: key.Scopes, Created: key.Created}
+}
+
+// CreateAPIKey создаёт ключ API пользователя. Ключ возвращается один раз.
+// Ключом API нельзя создать другой ключ, а токен — ключ с областями шире своих.
+func (h *Handler) CreateAPIKey(res http.ResponseWriter, req *http.Request) {
+	var body APIKeyRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if identity, ok := h.Auth.Identify(req); ok {
+		if identity.APIKey {
+			http.Error(res, "API keys cannot manage API keys", http.StatusForbidden)
+			return
+		}
+		for _, s := range body.Scopes {
+			if !identity.HasScope(s) {
+				http.Error(res, "scope not allowed: "+s, http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	userID := h.Auth.GetOrSetUserID(res, req)
+	key, raw, err := h.Service.CreateAPIKey(req.Context(), userID, body.Name, body.Scopes)
+	if err != nil {
+		h.writeServiceError(res, "CreateAPIKey error", err)
+		return
+	}
+	resp := apiKeyResponse(key)
+	resp.Key = raw
+
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(http.StatusCreated)
+	json.NewEncoder(res).Encode(resp)
+}
+
+// ListAPIKeys возвращает неотозванные ключи API пользователя без самих ключей.
+// Ключом API список не доступен, как и управление ключами.
+func (h *Handler) ListAPIKeys(res http.ResponseWriter, req *http.Request) {
+	if identity, ok := h.Auth.Identify(req); ok && identity.APIKey {
+		http.Error(res, "API keys cannot manage API keys", http.StatusForbidden)
+		return
+	}
+	userID := h.Auth.GetOrSetUserID(res, req)
+	keys, err := h.Service.ListAPIKeys(req.Context(), userID)
+	if err != nil {
+		h.Logger.Error("ListAPIKeys error", zap.Error(err))
+		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	resp := make([]APIKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		resp = append(resp, apiKeyResponse(k))
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(resp)
+}
+
+// RevokeAPIKey отзывает ключ API пользователя.
+func (h *Handler) RevokeAPIKey(res http.ResponseWriter, req *http.Request) {
+	if identity, ok := h.Auth.Identify(req); ok && identity.APIKey {
+		http.Error(res, "API keys cannot manage API keys", http.StatusForbidden)
+		return
+	}
+	userID := h.Auth.GetOrSetUserID(res, req)
+	if err := h.Service.RevokeAPIKey(req.Context(), userID, chi.URLParam(req, "id")); err != nil {
+		h.writeServiceError(res, "RevokeAPIKey error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
@@ -5,11 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/Totarae/URLShortener/internal/hll"
 	"github.com/Totarae/URLShortener/internal/model"
 	"github.com/Totarae/URLShortener/internal/service"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	"github.com/Totarae/URLShortener/internal/auth"
 	"github.com/Totarae/URLShortener/internal/util"
@@ -38,6 +40,150 @@ func (m *mockRepo) CountUsers(ctx context.Context) (int, error) { return 0, nil
 func (m *mockRepo) GetStats(ctx context.Context) (urlCount int, userCount int, err error) {
 	return 0, 0, nil
 }
+func (m *mockRepo) UpdateRules(ctx context.Context, short, userID string, rules []model.RedirectRule) (bool, error) {
+	return true, nil
+}
+func (m *mockRepo) SetForcePreview(ctx context.Context, short, userID string, enabled bool) (bool, error) {
+	return true, nil
+}
+func (m *mockRepo) ForEachActiveURL(ctx context.Context, fn func(*model.URLObject) error) error {
+	return nil
+}
+func (m *mockRepo) DisableURLs(ctx context.Context, ids []string, reason string) error {
+	return nil
+}
+func (m *mockRepo) SaveHealth(ctx context.Context, results map[string]model.LinkHealth) error {
+	return nil
+}
+func (m *mockRepo) GetBrokenURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error) {
+	return nil, nil
+}
+func (m *mockRepo) SaveMetadata(ctx context.Context, short, origin string, meta model.LinkMetadata) (bool, error) {
+	return true, nil
+}
+func (m *mockRepo) UpdateOrigin(ctx context.Context, short, userID, origin string) (model.Revision, bool, error) {
+	return model.Revision{ChangedBy: userID, OldURL: origin, NewURL: origin}, true, nil
+}
+func (m *mockRepo) GetRevisions(ctx context.Context, short string) ([]model.Revision, error) {
+	return nil, nil
+}
+func (m *mockRepo) GetDeletedURLsByUserID(ctx context.Context, userID string, since time.Time) ([]*model.URLObject, error) {
+	return nil, nil
+}
+func (m *mockRepo) RestoreURLs(ctx context.Context, ids []string, userID string, since time.Time) ([]string, error) {
+	return nil, nil
+}
+func (m *mockRepo) SaveClicks(ctx context.Context, clicks []model.Click) error {
+	return nil
+}
+
+func (m *mockRepo) GetClickSummary(ctx context.Context, shorten string, from, to time.Time, interval string, includeBots bool) (model.ClickSummary, error) {
+	return model.NewClickSummary(), nil
+}
+
+func (m *mockRepo) CountLinks(ctx context.Context) (model.LinkCounts, error) {
+	return model.LinkCounts{}, nil
+}
+
+func (m *mockRepo) CountCreatedByDay(ctx context.Context, since time.Time) (map[time.Time]int64, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) TopClickedURLs(ctx context.Context, since time.Time, limit int, includeBots bool) ([]model.TopLink, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) GetSketches(ctx context.Context, shorten string, from, to time.Time) (map[time.Time]*hll.Sketch, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) CreateAPIKey(ctx context.Context, key model.APIKey) error {
+	return nil
+}
+
+func (m *mockRepo) GetAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) ListAPIKeys(ctx context.Context, userID string) ([]model.APIKey, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) RevokeAPIKey(ctx context.Context, userID, id string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockRepo) CreateAccount(ctx context.Context, acc model.Account) (bool, error) {
+	return true, nil
+}
+
+func (m *mockRepo) GetAccountByEmail(ctx context.Context, email string) (*model.Account, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) CreateSession(ctx context.Context, s model.Session) error {
+	return nil
+}
+
+func (m *mockRepo) GetSession(ctx context.Context, id string) (*model.Session, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) DeleteSession(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockRepo) ReassignUser(ctx context.Context, from, to string) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockRepo) ResolveIdentity(ctx context.Context, issuer, subject, newUserID string) (string, error) {
+	return newUserID, nil
+}
+
+func (m *mockRepo) CreateOrganization(ctx context.Context, org model.Organization, owner model.Membership) error {
+	return nil
+}
+
+func (m *mockRepo) GetMembership(ctx context.Context, orgID, userID string) (*model.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) ListWorkspaces(ctx context.Context, userID string) ([]model.Workspace, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) ListMembers(ctx context.Context, orgID string) ([]model.Membership, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) SetMembership(ctx context.Context, ms model.Membership) error {
+	return nil
+}
+
+func (m *mockRepo) DeleteMembership(ctx context.Context, orgID, userID string) (bool, error) {
+	return false, nil
+}
+
+func (m *mockRepo) SearchURLs(ctx context.Context, q model.LinkSearch) ([]*model.URLObject, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) CountUserLinks(ctx context.Context, userID string) (model.LinkCounts, error) {
+	return model.LinkCounts{}, nil
+}
+
+func (m *mockRepo) SetBan(ctx context.Context, ban model.Ban) error {
+	return nil
+}
+
+func (m *mockRepo) GetBan(ctx context.Context, userID string) (*model.Ban, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) DeleteBan(ctx context.Context, userID string) (bool, error) {
+	return false, nil
+}
 
 // ExampleHandler_ReceiveShorten демонстрирует работу метода ReceiveShorten.
 func ExampleHandler_ReceiveShorten() {
@@ -1,12 +1,16 @@
 package handlers
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/Totarae/URLShortener/internal/analytics"
 	"github.com/Totarae/URLShortener/internal/auth"
+	"github.com/Totarae/URLShortener/internal/clicks"
 	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/rules"
 	"github.com/Totarae/URLShortener/internal/service"
+	"github.com/Totarae/URLShortener/internal/sso"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 	"io"
@@ -14,7 +18,9 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Handler содержит зависимости и реализует HTTP-обработчики
@@ -24,12 +30,15 @@ type Handler struct {
 	Logger        *zap.Logger
 	Auth          *auth.Auth
 	TrustedSubnet *net.IPNet
+	// SSO — провайдер OpenID Connect; nil, если вход через провайдер не настроен.
+	SSO *sso.Provider
 }
 
 // UserURLResponse представляет пару оригинального и сокращённого URL пользователя.
 type UserURLResponse struct {
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
+	ShortURL    string              `json:"short_url"`
+	OriginalURL string              `json:"original_url"`
+	Metadata    *model.LinkMetadata `json:"metadata,omitempty"`
 }
 
 var validIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{6,22}$`)
@@ -63,8 +72,7 @@ func (h *Handler) ReceiveURL(res http.ResponseWriter, req *http.Request) {
 	userID := h.Auth.GetOrSetUserID(res, req)
 	short, err := h.Service.ShortenURL(req.Context(), userID, originalURL)
 	if err != nil {
-		h.Logger.Error("Shorten error", zap.Error(err))
-		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		h.writeServiceError(res, "Shorten error", err)
 		return
 	}
 
@@ -75,9 +83,15 @@ func (h *Handler) ReceiveURL(res http.ResponseWriter, req *http.Request) {
 }
 
 // ResponseURL перенаправляет по сокращённому идентификатору на оригинальный URL,
-// если он существует и не удалён.
+// если он существует и не удалён. Для /{id}+, ?preview=1 и ссылок с обязательным
+// предпросмотром вместо перенаправления отдаётся HTML-страница.
 func (h *Handler) ResponseURL(res http.ResponseWriter, req *http.Request) {
 	id := chi.URLParam(req, "id")
+	preview := req.URL.Query().Get("preview") == "1"
+	if trimmed, ok := strings.CutSuffix(id, "+"); ok {
+		id = trimmed
+		preview = true
+	}
 	if id == "" {
 		http.Error(res, "Missing ID", http.StatusBadRequest)
 		return
@@ -97,8 +111,26 @@ func (h *Handler) ResponseURL(res http.ResponseWriter, req *http.Request) {
 		http.Error(res, "Gone", http.StatusGone)
 		return
 	}
+	if urlObj.IsDisabled {
+		http.Error(res, "Link disabled", http.StatusForbidden)
+		return
+	}
 
-	res.Header().Set("Location", urlObj.Origin)
+	target, variant := rules.Resolve(urlObj, rules.NewRequest(req))
+	if preview || urlObj.ForcePreview {
+		h.renderPreview(res, urlObj, target)
+		return
+	}
+	h.Service.RecordClick(model.Click{
+		Time:      time.Now(),
+		Short:     urlObj.Shorten,
+		Referrer:  req.Referer(),
+		UserAgent: req.UserAgent(),
+		Variant:   variant,
+		Country:   clicks.Country(req),
+		Bot:       h.Service.Bots.IsBot(req),
+	}, urlObj.UserID, clicks.ClientIP(req))
+	res.Header().Set("Location", target)
 	res.WriteHeader(http.StatusTemporaryRedirect)
 }
 
@@ -120,8 +152,7 @@ func (h *Handler) ReceiveShorten(res http.ResponseWriter, req *http.Request) {
 	userID := h.Auth.GetOrSetUserID(res, req)
 	short, err := h.Service.ShortenURL(req.Context(), userID, request.URL)
 	if err != nil {
-		h.Logger.Error("Shorten error", zap.Error(err))
-		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		h.writeServiceError(res, "Shorten error", err)
 		return
 	}
 
@@ -161,8 +192,7 @@ func (h *Handler) BatchShortenHandler(res http.ResponseWriter, req *http.Request
 	
 	results, err := h.Service.CreateBatchShortURLs(req.Context(), userID, items)
 	if err != nil {
-		h.Logger.Error("Batch shorten error", zap.Error(err))
-		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		h.writeServiceError(res, "Batch shorten error", err)
 		return
 	}
 
@@ -188,36 +218,65 @@ func (h *Handler) DeleteUserURLs(res http.ResponseWriter, req *http.Request) {
 		http.Error(res, "Invalid JSON", http.StatusBadRequest)
 		return
 	}
-	go h.Service.DeleteURLs(context.Background(), userID, ids)
+	h.Service.DeleteURLsAsync(userID, ids)
 	res.WriteHeader(http.StatusAccepted)
 }
 
-// GetStatsHandler для статистики
+// GetStatsHandler для статистики.
+// Параметр days задаёт окно для созданных по дням и популярных ссылок,
+// include_bots=true учитывает переходы ботов в популярности ссылок.
 func (h *Handler) GetStatsHandler(w http.ResponseWriter, r *http.Request) {
-	ipStr := r.Header.Get("X-Real-IP")
-	if ipStr == "" || h.TrustedSubnet == nil {
+	if !h.fromTrustedSubnet(r) {
 		http.Error(w, "forbidden", http.StatusForbidden)
 		return
 	}
-	ip := net.ParseIP(ipStr)
-	if ip == nil || !h.TrustedSubnet.Contains(ip) {
-		http.Error(w, "forbidden", http.StatusForbidden)
+
+	days := service.DefaultStatsDays
+	if raw := r.URL.Query().Get("days"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 || n > service.MaxStatsDays {
+			http.Error(w, fmt.Sprintf("days must be between 1 and %d", service.MaxStatsDays), http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+	withBots, err := includeBots(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	urlCount, userCount, err := h.Service.GetStats(r.Context())
+	stats, err := h.Service.GetStats(r.Context(), days, withBots)
 	if err != nil {
 		h.Logger.Error("Failed to get stats", zap.Error(err))
 		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
 
-	resp := map[string]int{
-		"urls":  urlCount,
-		"users": userCount,
+// includeBots разбирает параметр include_bots; по умолчанию учитываются только люди.
+func includeBots(r *http.Request) (bool, error) {
+	raw := r.URL.Query().Get("include_bots")
+	if raw == "" {
+		return false, nil
 	}
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, errors.New("include_bots must be true or false")
+	}
+	return v, nil
+}
+
+// fromTrustedSubnet сообщает, пришёл ли запрос из доверенной подсети по X-Real-IP.
+func (h *Handler) fromTrustedSubnet(r *http.Request) bool {
+	ipStr := r.Header.Get("X-Real-IP")
+	if ipStr == "" || h.TrustedSubnet == nil {
+		return false
+	}
+	ip := net.ParseIP(ipStr)
+	return ip != nil && h.TrustedSubnet.Contains(ip)
 }
 
 func (h *Handler) GetUserURLs(res http.ResponseWriter, req *http.Request) {
@@ -237,8 +296,278 @@ func (h *Handler) GetUserURLs(res http.ResponseWriter, req *http.Request) {
 		resp = append(resp, UserURLResponse{
 			ShortURL:    fmt.Sprintf("%s/%s", h.Service.BaseURL, r.ShortURL),
 			OriginalURL: r.OriginalURL,
+			Metadata:    r.Metadata,
+		})
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(resp)
+}
+
+// BrokenURLResponse описывает ссылку, адрес назначения которой недоступен.
+type BrokenURLResponse struct {
+	ShortURL    string    `json:"short_url"`
+	OriginalURL string    `json:"original_url"`
+	Status      int       `json:"status"`
+	LatencyMs   int64     `json:"latency_ms"`
+	CheckedAt   time.Time `json:"checked_at"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// GetBrokenURLs возвращает ссылки пользователя, не прошедшие последнюю проверку доступности.
+func (h *Handler) GetBrokenURLs(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	urls, err := h.Service.GetBrokenURLs(req.Context(), userID)
+	if err != nil {
+		h.Logger.Error("GetBrokenURLs error", zap.Error(err))
+		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if len(urls) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	resp := make([]BrokenURLResponse, 0, len(urls))
+	for _, u := range urls {
+		resp = append(resp, BrokenURLResponse{
+			ShortURL:    fmt.Sprintf("%s/%s", h.Service.BaseURL, u.Shorten),
+			OriginalURL: u.Origin,
+			Status:      u.Health.Status,
+			LatencyMs:   u.Health.LatencyMs,
+			CheckedAt:   u.Health.CheckedAt,
+			Error:       u.Health.Error,
 		})
 	}
 	res.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(res).Encode(resp)
 }
+
+// TrashItemResponse описывает удалённую ссылку, которую ещё можно восстановить.
+type TrashItemResponse struct {
+	ShortURL    string    `json:"short_url"`
+	OriginalURL string    `json:"original_url"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// GetTrash возвращает удалённые ссылки пользователя в пределах срока хранения.
+func (h *Handler) GetTrash(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	urls, err := h.Service.GetTrash(req.Context(), userID)
+	if err != nil {
+		h.Logger.Error("GetTrash error", zap.Error(err))
+		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if len(urls) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	resp := make([]TrashItemResponse, 0, len(urls))
+	for _, u := range urls {
+		resp = append(resp, TrashItemResponse{
+			ShortURL:    fmt.Sprintf("%s/%s", h.Service.BaseURL, u.Shorten),
+			OriginalURL: u.Origin,
+			DeletedAt:   *u.DeletedAt,
+			ExpiresAt:   u.DeletedAt.Add(h.Service.TrashRetention),
+		})
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(resp)
+}
+
+// RestoreURLs восстанавливает удалённые ссылки пользователя.
+// Принимает JSON-массив сокращённых ID, возвращает массив восстановленных.
+func (h *Handler) RestoreURLs(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	var ids []string
+	if err := json.NewDecoder(req.Body).Decode(&ids); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	restored, err := h.Service.RestoreURLs(req.Context(), userID, ids)
+	if err != nil {
+		h.writeServiceError(res, "RestoreURLs error", err)
+		return
+	}
+	if restored == nil {
+		restored = []string{}
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(restored)
+}
+
+// RestoreURL восстанавливает одну удалённую ссылку пользователя.
+func (h *Handler) RestoreURL(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	restored, err := h.Service.RestoreURLs(req.Context(), userID, []string{chi.URLParam(req, "id")})
+	if err != nil {
+		h.writeServiceError(res, "RestoreURL error", err)
+		return
+	}
+	if len(restored) == 0 {
+		http.Error(res, "Not Found", http.StatusNotFound)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// UpdateURLRequest — новый адрес назначения ссылки.
+type UpdateURLRequest struct {
+	URL string `json:"url"`
+}
+
+// UpdateURL меняет адрес назначения ссылки пользователя, сохраняя короткий код.
+func (h *Handler) UpdateURL(res http.ResponseWriter, req *http.Request) {
+	var request UpdateURLRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil || request.URL == "" {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	parsedURL, err := url.ParseRequestURI(request.URL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		http.Error(res, "Invalid URL", http.StatusBadRequest)
+		return
+	}
+
+	userID := h.Auth.GetOrSetUserID(res, req)
+	id := chi.URLParam(req, "id")
+	rev, err := h.Service.UpdateDestination(req.Context(), userID, id, request.URL)
+	if err != nil {
+		h.writeServiceError(res, "UpdateURL error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(UserURLResponse{
+		ShortURL:    fmt.Sprintf("%s/%s", h.Service.BaseURL, id),
+		OriginalURL: rev.NewURL,
+	})
+}
+
+// GetRevisions возвращает историю изменений адреса назначения ссылки пользователя.
+func (h *Handler) GetRevisions(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	revisions, err := h.Service.GetRevisions(req.Context(), userID, chi.URLParam(req, "id"))
+	if err != nil {
+		h.writeServiceError(res, "GetRevisions error", err)
+		return
+	}
+	if revisions == nil {
+		revisions = []model.Revision{}
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(revisions)
+}
+
+// GetLinkStats возвращает статистику переходов по ссылке пользователя.
+// Параметры запроса: from, to (RFC 3339 или YYYY-MM-DD), interval (hour или day)
+// и include_bots — учитывать ли переходы ботов (по умолчанию нет).
+func (h *Handler) GetLinkStats(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	params := req.URL.Query()
+	query, err := analytics.ParseQuery(params.Get("from"), params.Get("to"), params.Get("interval"), time.Now())
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if query.IncludeBots, err = includeBots(req); err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.Service.GetLinkStats(req.Context(), userID, chi.URLParam(req, "id"), query)
+	if err != nil {
+		h.writeServiceError(res, "GetLinkStats error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(stats)
+}
+
+// GetRedirectRules возвращает правила условного перенаправления ссылки пользователя.
+func (h *Handler) GetRedirectRules(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	list, err := h.Service.GetRedirectRules(req.Context(), userID, chi.URLParam(req, "id"))
+	if err != nil {
+		h.writeServiceError(res, "GetRedirectRules error", err)
+		return
+	}
+	if list == nil {
+		list = []model.RedirectRule{}
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(list)
+}
+
+// SetRedirectRules заменяет правила условного перенаправления ссылки пользователя.
+// Принимает JSON-массив правил; пустой массив отключает правила.
+func (h *Handler) SetRedirectRules(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	var list []model.RedirectRule
+	if err := json.NewDecoder(req.Body).Decode(&list); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.SetRedirectRules(req.Context(), userID, chi.URLParam(req, "id"), list); err != nil {
+		h.writeServiceError(res, "SetRedirectRules error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// DestinationErrorResponse описывает отказ в сокращении адреса назначения.
+type DestinationErrorResponse struct {
+	Code          string `json:"code"`
+	Message       string `json:"message"`
+	URL           string `json:"url"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	Rule          string `json:"rule,omitempty"`
+}
+
+// writeDestinationError отвечает 422 с кодом причины, если адрес назначения отклонён.
+// Возвращает false, если ошибка другого типа.
+func (h *Handler) writeDestinationError(res http.ResponseWriter, err error) bool {
+	var destErr *service.DestinationError
+	if !errors.As(err, &destErr) {
+		return false
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(res).Encode(DestinationErrorResponse{
+		Code:          destErr.Code,
+		Message:       destErr.Err.Error(),
+		URL:           destErr.URL,
+		CorrelationID: destErr.CorrelationID,
+		Rule:          destErr.Rule,
+	})
+	return true
+}
+
+// writeServiceError преобразует ошибку сервиса в HTTP-ответ.
+func (h *Handler) writeServiceError(res http.ResponseWriter, msg string, err error) {
+	if h.writeDestinationError(res, err) {
+		return
+	}
+	switch {
+	case errors.Is(err, service.ErrURLNotFound), errors.Is(err, service.ErrAPIKeyNotFound):
+		http.Error(res, "Not Found", http.StatusNotFound)
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrBanNotFound):
+		http.Error(res, err.Error(), http.StatusNotFound)
+	case errors.Is(err, service.ErrForbidden), errors.Is(err, service.ErrUserBanned):
+		http.Error(res, err.Error(), http.StatusForbidden)
+	case errors.Is(err, service.ErrInvalidRules), errors.Is(err, auth.ErrInvalidScope):
+		http.Error(res, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrDestinationTaken), errors.Is(err, service.ErrAccountExists),
+		errors.Is(err, service.ErrLastOwner):
+		http.Error(res, err.Error(), http.StatusConflict)
+	case errors.Is(err, service.ErrInvalidAccount), errors.Is(err, service.ErrInvalidOrganization),
+		errors.Is(err, service.ErrInvalidAdminRequest):
+		http.Error(res, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidCredentials):
+		http.Error(res, err.Error(), http.StatusUnauthorized)
+	case errors.Is(err, service.ErrStreamUnavailable):
+		http.Error(res, err.Error(), http.StatusServiceUnavailable)
+	default:
+		h.Logger.Error(msg, zap.Error(err))
+		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+	}
+}
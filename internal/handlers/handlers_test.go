@@ -1,22 +1,44 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/Totarae/URLShortener/internal/service"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Totarae/URLShortener/internal/accounts"
+	"github.com/Totarae/URLShortener/internal/analytics"
 	"github.com/Totarae/URLShortener/internal/auth"
+	"github.com/Totarae/URLShortener/internal/blocklist"
+	"github.com/Totarae/URLShortener/internal/botdetect"
+	"github.com/Totarae/URLShortener/internal/cache"
+	"github.com/Totarae/URLShortener/internal/canonical"
+	"github.com/Totarae/URLShortener/internal/clicks"
+	"github.com/Totarae/URLShortener/internal/healthcheck"
+	"github.com/Totarae/URLShortener/internal/middleware"
 	"github.com/Totarae/URLShortener/internal/mocks"
 	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/netguard"
+	"github.com/Totarae/URLShortener/internal/pubsub"
+	"github.com/Totarae/URLShortener/internal/repositories"
+	"github.com/Totarae/URLShortener/internal/sso"
+	"github.com/Totarae/URLShortener/internal/sso/ssotest"
+	"github.com/Totarae/URLShortener/internal/util"
 	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -39,6 +61,7 @@ func TestReceiveURL(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	mockStore := mocks.NewMockStorage(ctrl)
 
 	// Ожидаем вызов `SaveURL`, если используется БД
@@ -257,6 +280,7 @@ func TestReceiveShorten(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	mockStore := mocks.NewMockStorage(ctrl)
 
 	// Ожидаем вызов `SaveURL`, если используется БД
@@ -459,6 +483,7 @@ func TestBatchShortenHandler_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	mockStore := mocks.NewMockStorage(ctrl)
 	handler := setupMockHandler(t, mockRepo, mockStore, "database")
 
@@ -483,3 +508,1341 @@ func TestBatchShortenHandler_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, string(body), "short_url")
 }
+
+func TestResponseURL_RedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	shortID := "rules123"
+	mockRepo.EXPECT().GetURL(gomock.Any(), shortID).Return(&model.URLObject{
+		Shorten: shortID,
+		Origin:  "https://example.com",
+		Rules: []model.RedirectRule{
+			{Devices: []string{"android"}, Target: "https://play.example.com"},
+		},
+	}, nil).Times(2)
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+
+	for ua, location := range map[string]string{
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8)":  "https://play.example.com",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64)": "https://example.com",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/"+shortID, nil)
+		req.Header.Set("User-Agent", ua)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		resp := w.Result()
+		resp.Body.Close()
+		assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+		assert.Equal(t, location, resp.Header.Get("Location"))
+	}
+}
+
+func TestSetRedirectRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	userID := "rules-owner"
+	mockRepo.EXPECT().UpdateRules(gomock.Any(), "abc123", userID, gomock.Len(1)).Return(true, nil).Times(1)
+	mockRepo.EXPECT().UpdateRules(gomock.Any(), "foreign", userID, gomock.Any()).Return(false, nil).Times(1)
+
+	r := chi.NewRouter()
+	r.Put("/api/user/urls/{id}/rules", h.SetRedirectRules)
+
+	tests := []struct {
+		id     string
+		body   string
+		status int
+	}{
+		{"abc123", `[{"devices":["ios"],"target":"https://apps.example.com"}]`, http.StatusNoContent},
+		{"foreign", `[]`, http.StatusNotFound},
+		{"abc123", `[{"devices":["tv"],"target":"https://example.com"}]`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPut, "/api/user/urls/"+tt.id+"/rules", strings.NewReader(tt.body))
+		req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue(userID)})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		resp := w.Result()
+		resp.Body.Close()
+		assert.Equal(t, tt.status, resp.StatusCode, tt.body)
+	}
+}
+
+func TestResponseURL_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	created := time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetURL(gomock.Any(), "plain1").Return(&model.URLObject{
+		Shorten: "plain1", Origin: "https://example.com/page", Created: created,
+	}, nil).Times(3)
+	mockRepo.EXPECT().GetURL(gomock.Any(), "forced1").Return(&model.URLObject{
+		Shorten: "forced1", Origin: "https://external.example.org", ForcePreview: true,
+	}, nil).Times(1)
+	mockRepo.EXPECT().GetURL(gomock.Any(), "titled1").Return(&model.URLObject{
+		Shorten: "titled1", Origin: "https://example.com/article", ForcePreview: true,
+		Metadata: &model.LinkMetadata{Title: "Статья <о> ссылках"},
+	}, nil).Times(1)
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+
+	tests := []struct {
+		path    string
+		preview bool
+		want    string
+	}{
+		{"/plain1", false, "https://example.com/page"},
+		{"/plain1+", true, "2025-03-15"},
+		{"/plain1?preview=1", true, "https://example.com/page"},
+		{"/forced1", true, "external.example.org"},
+		{"/titled1", true, "<h1>Статья &lt;о&gt; ссылках</h1>"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		resp := w.Result()
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		if !tt.preview {
+			assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode, tt.path)
+			assert.Equal(t, tt.want, resp.Header.Get("Location"))
+			continue
+		}
+		assert.Equal(t, http.StatusOK, resp.StatusCode, tt.path)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+		assert.Empty(t, resp.Header.Get("Location"))
+		assert.Contains(t, string(body), tt.want)
+	}
+}
+
+func TestReceiveShorten_SelfReference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten", strings.NewReader(`{"url":"http://localhost:8080/abc123"}`))
+	w := httptest.NewRecorder()
+	h.ReceiveShorten(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"code":"self_reference"`)
+}
+
+func TestReceiveShorten_CanonicalURLsShareShortCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+	h.Service.Canonicalizer = canonical.New(canonical.Options{SortQuery: true})
+
+	var saved []*model.URLObject
+	mockRepo.EXPECT().SaveURL(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, obj *model.URLObject) error {
+			saved = append(saved, obj)
+			return nil
+		}).Times(2)
+
+	for _, raw := range []string{"https://Example.com:443/a?b=1&a=2", "https://example.com/a?a=2&b=1"} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(raw))
+		w := httptest.NewRecorder()
+		h.ReceiveURL(w, req)
+		w.Result().Body.Close()
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	if assert.Len(t, saved, 2) {
+		assert.Equal(t, "https://example.com/a?a=2&b=1", saved[0].Origin)
+		assert.Equal(t, saved[0].Shorten, saved[1].Shorten)
+	}
+}
+
+func TestBatchShorten_BlockedDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	path := filepath.Join(t.TempDir(), "blocklist.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("suffix:.evil\n"), 0644))
+	list, err := blocklist.Load(path, zap.NewNop())
+	assert.NoError(t, err)
+	h.Service.Blocklist = list
+
+	input := `[{"correlation_id":"ok","original_url":"https://example.com"},{"correlation_id":"bad","original_url":"https://phish.evil/login"}]`
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", strings.NewReader(input))
+	w := httptest.NewRecorder()
+	h.BatchShortenHandler(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"code":"blocked"`)
+	assert.Contains(t, string(body), `"rule":"suffix:.evil"`)
+	assert.Contains(t, string(body), `"correlation_id":"bad"`)
+}
+
+func TestResponseURL_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	mockRepo.EXPECT().GetURL(gomock.Any(), "banned1").Return(&model.URLObject{
+		Shorten: "banned1", Origin: "https://phish.evil", IsDisabled: true, DisabledReason: "blocklist: suffix:.evil",
+	}, nil).Times(1)
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/banned1", nil))
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestGetBrokenURLs(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+	dead := httptest.NewServer(http.NotFoundHandler())
+	defer dead.Close()
+
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	svc.Checker = healthcheck.New()
+	svc.Checker.Client = netguard.NewClient(2*time.Second, true)
+	svc.Checker.HostInterval = 0
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	userID := "health-user"
+	_, err := svc.ShortenURL(context.Background(), userID, ok.URL+"/alive")
+	assert.NoError(t, err)
+	deadShort, err := svc.ShortenURL(context.Background(), userID, dead.URL+"/missing")
+	assert.NoError(t, err)
+
+	checked, err := svc.CheckDestinations(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, checked)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/urls/broken", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue(userID)})
+	w := httptest.NewRecorder()
+	h.GetBrokenURLs(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var broken []BrokenURLResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&broken))
+	if assert.Len(t, broken, 1) {
+		assert.Equal(t, "http://localhost:8080/"+deadShort, broken[0].ShortURL)
+		assert.Equal(t, http.StatusNotFound, broken[0].Status)
+	}
+}
+
+func TestGetUserURLs_WithMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	userID := "meta-user"
+	mockRepo.EXPECT().GetURLsByUserID(gomock.Any(), userID).Return([]*model.URLObject{
+		{Shorten: "abc123", Origin: "https://example.com", Metadata: &model.LinkMetadata{
+			Title:     "Example Domain",
+			OpenGraph: map[string]string{"image": "https://example.com/cover.png"},
+		}},
+		{Shorten: "xyz789", Origin: "https://golang.org"},
+	}, nil).Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue(userID)})
+	w := httptest.NewRecorder()
+	h.GetUserURLs(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var urls []UserURLResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&urls))
+	if assert.Len(t, urls, 2) {
+		if assert.NotNil(t, urls[0].Metadata) {
+			assert.Equal(t, "Example Domain", urls[0].Metadata.Title)
+			assert.Equal(t, "https://example.com/cover.png", urls[0].Metadata.OpenGraph["image"])
+		}
+		assert.Nil(t, urls[1].Metadata)
+	}
+}
+
+func TestUpdateURL_ChangesDestinationAndInvalidatesCache(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Cache = cache.New[*model.URLObject](100, time.Minute)
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+	r.Patch("/api/user/urls/{id}", h.UpdateURL)
+	r.Get("/api/user/urls/{id}/revisions", h.GetRevisions)
+
+	owner := &http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")}
+	stranger := &http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("stranger")}
+	short, err := svc.ShortenURL(context.Background(), "owner", "https://old.example.com/page")
+	assert.NoError(t, err)
+
+	do := func(method, target, body string, cookie *http.Cookie) *http.Response {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	resp := do(http.MethodGet, "/"+short, "", owner)
+	resp.Body.Close()
+	assert.Equal(t, "https://old.example.com/page", resp.Header.Get("Location"))
+
+	resp = do(http.MethodPatch, "/api/user/urls/"+short, `{"url":"https://new.example.com/page"}`, stranger)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = do(http.MethodPatch, "/api/user/urls/"+short, `{"url":"https://new.example.com/page"}`, owner)
+	var updated UserURLResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "http://localhost:8080/"+short, updated.ShortURL)
+	assert.Equal(t, "https://new.example.com/page", updated.OriginalURL)
+
+	resp = do(http.MethodGet, "/"+short, "", owner)
+	resp.Body.Close()
+	assert.Equal(t, "https://new.example.com/page", resp.Header.Get("Location"))
+
+	resp = do(http.MethodGet, "/api/user/urls/"+short+"/revisions", "", owner)
+	var revisions []model.Revision
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&revisions))
+	resp.Body.Close()
+	if assert.Len(t, revisions, 1) {
+		assert.Equal(t, "owner", revisions[0].ChangedBy)
+		assert.Equal(t, "https://old.example.com/page", revisions[0].OldURL)
+		assert.Equal(t, "https://new.example.com/page", revisions[0].NewURL)
+	}
+
+	// Новый адрес уже занят отредактированной ссылкой: второй ссылки на него не появляется
+	same, err := svc.ShortenURL(context.Background(), "someone", "https://new.example.com/page")
+	assert.NoError(t, err)
+	assert.Equal(t, short, same)
+	batch, err := svc.CreateBatchShortURLs(context.Background(), "someone", []model.BatchItem{
+		{CorrelationID: "1", OriginalURL: "https://new.example.com/page"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/"+short, batch[0].ShortURL)
+
+	// Старый адрес больше не занимает код отредактированной ссылки
+	again, err := svc.ShortenURL(context.Background(), "someone", "https://old.example.com/page")
+	assert.NoError(t, err)
+	assert.NotEqual(t, short, again)
+	batch, err = svc.CreateBatchShortURLs(context.Background(), "someone", []model.BatchItem{
+		{CorrelationID: "1", OriginalURL: "https://old.example.com/page"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080/"+again, batch[0].ShortURL)
+
+	// Адрес назначения уникален и в файловом хранилище
+	resp = do(http.MethodPatch, "/api/user/urls/"+short, `{"url":"https://old.example.com/page"}`, owner)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestSaveMetadata_DropsStaleFetchAndInvalidatesCache(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Cache = cache.New[*model.URLObject](100, time.Minute)
+	ctx := context.Background()
+
+	short, err := svc.ShortenURL(ctx, "owner", "https://old.example.com/page")
+	require.NoError(t, err)
+	obj, err := svc.ResolveURL(ctx, short)
+	require.NoError(t, err)
+	assert.Nil(t, obj.Metadata)
+
+	// Сохранённые метаданные сразу видны, а не после истечения кеша
+	require.NoError(t, svc.SaveMetadata(ctx, short, "https://old.example.com/page", model.LinkMetadata{Title: "Old"}))
+	obj, err = svc.ResolveURL(ctx, short)
+	require.NoError(t, err)
+	if assert.NotNil(t, obj.Metadata) {
+		assert.Equal(t, "Old", obj.Metadata.Title)
+	}
+
+	// Загрузка прежнего адреса, завершившаяся после правки, не перезаписывает сброс
+	_, err = svc.UpdateDestination(ctx, "owner", short, "https://new.example.com/page")
+	require.NoError(t, err)
+	require.NoError(t, svc.SaveMetadata(ctx, short, "https://old.example.com/page", model.LinkMetadata{Title: "Old"}))
+	obj, err = svc.ResolveURL(ctx, short)
+	require.NoError(t, err)
+	assert.Nil(t, obj.Metadata)
+
+	require.NoError(t, svc.SaveMetadata(ctx, short, "https://new.example.com/page", model.LinkMetadata{Title: "New"}))
+	obj, err = svc.ResolveURL(ctx, short)
+	require.NoError(t, err)
+	if assert.NotNil(t, obj.Metadata) {
+		assert.Equal(t, "New", obj.Metadata.Title)
+	}
+}
+
+func TestBatchShorten_RetriesTakenShortCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	h := setupMockHandler(t, mockRepo, mocks.NewMockStorage(ctrl), "database")
+
+	// Код второй ссылки занят ссылкой, адрес которой изменили
+	var saved [][]string
+	mockRepo.EXPECT().SaveBatchURLs(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, objs []*model.URLObject) error {
+			saved = append(saved, []string{objs[0].Shorten, objs[1].Shorten})
+			objs[0].ID = 1
+			if len(saved) == 1 {
+				return repositories.ErrShortenTaken
+			}
+			objs[1].ID = 2
+			return nil
+		}).Times(2)
+
+	results, err := h.Service.CreateBatchShortURLs(context.Background(), "user", []model.BatchItem{
+		{CorrelationID: "1", OriginalURL: "https://example.com/a"},
+		{CorrelationID: "2", OriginalURL: "https://example.com/b"},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, saved[0][0], saved[1][0])
+	assert.NotEqual(t, saved[0][1], saved[1][1])
+	assert.Equal(t, "http://localhost:8080/"+saved[1][1], results[1].ShortURL)
+}
+
+func TestUpdateURL_DestinationTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockURLRepositoryInterface(ctrl)
+	mockRepo.EXPECT().GetBan(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockStore := mocks.NewMockStorage(ctrl)
+	h := setupMockHandler(t, mockRepo, mockStore, "database")
+
+	mockRepo.EXPECT().UpdateOrigin(gomock.Any(), "abc123", "owner", "https://taken.example.com/").
+		Return(model.Revision{}, false, repositories.ErrOriginExists).Times(1)
+
+	r := chi.NewRouter()
+	r.Patch("/api/user/urls/{id}", h.UpdateURL)
+	req := httptest.NewRequest(http.MethodPatch, "/api/user/urls/abc123", strings.NewReader(`{"url":"https://taken.example.com"}`))
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestTrashAndRestore(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Cache = cache.New[*model.URLObject](100, time.Minute)
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+	r.Get("/api/user/urls/trash", h.GetTrash)
+	r.Post("/api/user/urls/restore", h.RestoreURLs)
+	r.Post("/api/user/urls/{id}/restore", h.RestoreURL)
+
+	owner := &http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")}
+	stranger := &http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("stranger")}
+	do := func(method, target, body string, cookie *http.Cookie) *http.Response {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.AddCookie(cookie)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	first, _ := svc.ShortenURL(context.Background(), "owner", "https://one.example.com/")
+	second, _ := svc.ShortenURL(context.Background(), "owner", "https://two.example.com/")
+	resp := do(http.MethodGet, "/"+first, "", owner)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+
+	svc.DeleteURLs(context.Background(), "owner", []string{first, second})
+
+	resp = do(http.MethodGet, "/"+first, "", owner)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusGone, resp.StatusCode)
+
+	resp = do(http.MethodGet, "/api/user/urls/trash", "", stranger)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = do(http.MethodGet, "/api/user/urls/trash", "", owner)
+	var trash []TrashItemResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&trash))
+	resp.Body.Close()
+	if assert.Len(t, trash, 2) {
+		assert.WithinDuration(t, time.Now(), trash[0].DeletedAt, time.Minute)
+		assert.Equal(t, service.DefaultTrashRetention, trash[0].ExpiresAt.Sub(trash[0].DeletedAt))
+	}
+
+	resp = do(http.MethodPost, "/api/user/urls/"+first+"/restore", "", stranger)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = do(http.MethodPost, "/api/user/urls/"+first+"/restore", "", owner)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = do(http.MethodGet, "/"+first, "", owner)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+
+	// За пределами срока хранения ссылку восстановить нельзя
+	svc.TrashRetention = 0
+	resp = do(http.MethodPost, "/api/user/urls/restore", `["`+second+`"]`, owner)
+	var restored []string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&restored))
+	resp.Body.Close()
+	assert.Empty(t, restored)
+}
+
+func TestResponseURL_RecordsClick(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	sinkPath := filepath.Join(t.TempDir(), "clicks.ndjson")
+	svc.Clicks = clicks.NewTracker(&clicks.FileSink{Path: sinkPath}, 10, 10, time.Hour, zap.NewNop())
+	svc.Clicks.Salt = "pepper"
+	svc.Clicks.Start()
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	short, err := svc.ShortenURL(context.Background(), "owner", "https://example.com/landing")
+	assert.NoError(t, err)
+	assert.NoError(t, svc.SetRedirectRules(context.Background(), "owner", short, []model.RedirectRule{
+		{Name: "ios", Devices: []string{"ios"}, Target: "https://apps.apple.com/app"},
+	}))
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+	req := httptest.NewRequest(http.MethodGet, "/"+short, nil)
+	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)")
+	req.Header.Set("Referer", "https://news.example.org/")
+	req.Header.Set("X-Real-IP", "203.0.113.7")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	w.Result().Body.Close()
+
+	// Предпросмотр не считается переходом
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+short+"+", nil))
+	w.Result().Body.Close()
+
+	assert.NoError(t, svc.Clicks.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(sinkPath)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if assert.Len(t, lines, 1) {
+		var click model.Click
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &click))
+		assert.Equal(t, short, click.Short)
+		assert.Equal(t, "ios", click.Variant)
+		assert.Equal(t, "https://news.example.org/", click.Referrer)
+		assert.Equal(t, clicks.HashIP("pepper", "203.0.113.7"), click.IPHash)
+		assert.NotContains(t, lines[0], "203.0.113.7")
+	}
+}
+
+func TestGetLinkStats(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.ClicksPath = filepath.Join(t.TempDir(), "clicks.ndjson")
+	svc.Sketches = &analytics.SketchFile{Dir: filepath.Join(t.TempDir(), "sketches")}
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	r := chi.NewRouter()
+	r.Get("/api/user/urls/{id}/stats", h.GetLinkStats)
+
+	short, err := svc.ShortenURL(context.Background(), "owner", "https://example.com/")
+	assert.NoError(t, err)
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	chrome := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+	sink := clicks.MultiSink{&clicks.FileSink{Path: svc.ClicksPath}, svc.Sketches}
+	assert.NoError(t, sink.WriteClicks(context.Background(), []model.Click{
+		{Time: day.Add(time.Hour), Short: short, Referrer: "https://www.google.com/search?q=x", UserAgent: chrome, Country: "DE"},
+		{Time: day.Add(2 * time.Hour), Short: short, Referrer: "https://google.com/", UserAgent: chrome, Country: "DE"},
+		{Time: day.Add(26 * time.Hour), Short: short, UserAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Version/17.0 Mobile Safari/604.1"},
+		{Time: day.Add(26 * time.Hour), Short: "other", Referrer: "https://bing.com/"},
+		{Time: day.AddDate(0, 0, -5), Short: short},
+	}))
+
+	do := func(target, userID string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue(userID)})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	resp := do("/api/user/urls/"+short+"/stats?from=2026-03-10&to=2026-03-12&interval=day", "owner")
+	var stats model.LinkStats
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(3), stats.Total)
+	// Два перехода первого дня — один посетитель (одинаковые IP и User-Agent)
+	assert.Equal(t, int64(2), stats.Visitors)
+	assert.Equal(t, []model.StatsBucket{
+		{Start: day, Clicks: 2, Visitors: 1},
+		{Start: day.AddDate(0, 0, 1), Clicks: 1, Visitors: 1},
+		{Start: day.AddDate(0, 0, 2), Clicks: 0},
+	}, stats.Buckets)
+	assert.Equal(t, []model.StatsEntry{{Name: "google.com", Clicks: 2}, {Name: "direct", Clicks: 1}}, stats.Referrers)
+	assert.Equal(t, []model.StatsEntry{{Name: "Chrome", Clicks: 2}, {Name: "Safari", Clicks: 1}}, stats.Browsers)
+	assert.Equal(t, []model.StatsEntry{{Name: "Windows", Clicks: 2}, {Name: "iOS", Clicks: 1}}, stats.OS)
+	assert.Equal(t, []model.StatsEntry{{Name: "DE", Clicks: 2}, {Name: "unknown", Clicks: 1}}, stats.Countries)
+
+	resp = do("/api/user/urls/"+short+"/stats?from=2026-03-10T00:00:00Z&to=2026-03-10T03:00:00Z&interval=hour", "owner")
+	stats = model.LinkStats{}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	resp.Body.Close()
+	assert.Len(t, stats.Buckets, 3)
+	assert.Equal(t, int64(1), stats.Buckets[1].Clicks)
+
+	resp = do("/api/user/urls/"+short+"/stats", "stranger")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = do("/api/user/urls/"+short+"/stats?interval=week", "owner")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetStatsHandler_FileMode(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.ClicksPath = filepath.Join(t.TempDir(), "clicks.ndjson")
+	_, trusted, _ := net.ParseCIDR("10.0.0.0/8")
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), trusted)
+
+	ctx := context.Background()
+	first, _ := svc.ShortenURL(ctx, "alice", "https://one.example.com/")
+	second, _ := svc.ShortenURL(ctx, "alice", "https://two.example.com/")
+	third, _ := svc.ShortenURL(ctx, "bob", "https://three.example.com/")
+	svc.DeleteURLs(ctx, "bob", []string{third})
+
+	sink := &clicks.FileSink{Path: svc.ClicksPath}
+	now := time.Now()
+	assert.NoError(t, sink.WriteClicks(ctx, []model.Click{
+		{Time: now, Short: second}, {Time: now, Short: second}, {Time: now, Short: first},
+		{Time: now.AddDate(0, 0, -60), Short: first}, {Time: now.AddDate(0, 0, -60), Short: first},
+	}))
+
+	do := func(target, ip string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set("X-Real-IP", ip)
+		w := httptest.NewRecorder()
+		h.GetStatsHandler(w, req)
+		return w.Result()
+	}
+
+	resp := do("/api/internal/stats?days=7", "192.168.1.1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = do("/api/internal/stats?days=0", "10.1.2.3")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = do("/api/internal/stats?days=7", "10.1.2.3")
+	var stats model.ServiceStats
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Equal(t, int64(2), stats.URLs)
+	assert.Equal(t, model.LinkCounts{Total: 3, Active: 2, Deleted: 1, Users: 2}, stats.LinkCounts)
+	assert.Len(t, stats.CreatedPerDay, 7)
+	assert.Equal(t, int64(3), stats.CreatedPerDay[6].Count)
+	assert.Equal(t, []model.TopLink{
+		{Short: second, Origin: "https://two.example.com/", Clicks: 2},
+		{Short: first, Origin: "https://one.example.com/", Clicks: 1},
+	}, stats.TopLinks)
+}
+
+func TestStreamClicks(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.ClickStream = pubsub.New[model.Click](8)
+	svc.StreamHeartbeat = 50 * time.Millisecond
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	short, err := svc.ShortenURL(context.Background(), "owner", "https://example.com/landing")
+	assert.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(middleware.LoggingMiddleware(zap.NewNop()), middleware.GzipMiddleware)
+	r.Get("/{id}", h.ResponseURL)
+	r.Get("/api/user/urls/stream", h.StreamClicks)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/user/urls/stream", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")})
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get("Content-Encoding"))
+	assert.Eventually(t, func() bool { return svc.ClickStream.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	// Переход по чужой ссылке в поток владельца не попадает
+	other, err := svc.ShortenURL(context.Background(), "stranger", "https://example.com/other")
+	assert.NoError(t, err)
+	for _, id := range []string{other, short} {
+		redirect, _ := http.NewRequest(http.MethodGet, srv.URL+"/"+id, nil)
+		redirect.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile/15E148 Safari/604.1")
+		redirect.Header.Set("Referer", "https://news.example.org/")
+		res, err := (&http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}}).Do(redirect)
+		if assert.NoError(t, err) {
+			res.Body.Close()
+		}
+	}
+
+	events := make(map[string]string)
+	scanner := bufio.NewScanner(resp.Body)
+	var event string
+	for len(events) < 2 && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if _, seen := events[event]; !seen {
+				events[event] = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}
+
+	var click ClickEvent
+	if assert.Contains(t, events, "click") {
+		assert.NoError(t, json.Unmarshal([]byte(events["click"]), &click))
+	}
+	assert.Equal(t, "http://localhost:8080/"+short, click.ShortURL)
+	assert.Equal(t, "https://news.example.org/", click.Referrer)
+	assert.Equal(t, "iOS", click.OS)
+	assert.Contains(t, events, "heartbeat")
+}
+
+func TestStreamClicks_Disabled(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	w := httptest.NewRecorder()
+	h.StreamClicks(w, httptest.NewRequest(http.MethodGet, "/api/user/urls/stream", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestGetLinkStats_ExcludesBots(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.ClicksPath = filepath.Join(t.TempDir(), "clicks.ndjson")
+	svc.Clicks = clicks.NewTracker(&clicks.FileSink{Path: svc.ClicksPath}, 10, 10, time.Hour, zap.NewNop())
+	svc.Clicks.Start()
+	svc.Bots = botdetect.New()
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	short, err := svc.ShortenURL(context.Background(), "owner", "https://example.com/")
+	assert.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Get("/{id}", h.ResponseURL)
+	r.Head("/{id}", h.ResponseURL)
+	r.Get("/api/user/urls/{id}/stats", h.GetLinkStats)
+
+	chrome := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0 Safari/537.36"
+	for _, c := range []struct{ method, ua string }{
+		{http.MethodGet, chrome},
+		{http.MethodGet, "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)"},
+		{http.MethodHead, chrome},
+	} {
+		req := httptest.NewRequest(c.method, "/"+short, nil)
+		req.Header.Set("User-Agent", c.ua)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+	}
+	assert.NoError(t, svc.Clicks.Shutdown(context.Background()))
+
+	stats := func(query string) (*http.Response, model.LinkStats) {
+		req := httptest.NewRequest(http.MethodGet, "/api/user/urls/"+short+"/stats"+query, nil)
+		req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var s model.LinkStats
+		json.NewDecoder(w.Body).Decode(&s)
+		return w.Result(), s
+	}
+
+	_, humans := stats("")
+	assert.Equal(t, int64(1), humans.Total)
+	assert.Equal(t, int64(2), humans.Bots)
+	assert.False(t, humans.IncludeBots)
+	assert.Equal(t, []model.StatsEntry{{Name: "Chrome", Clicks: 1}}, humans.Browsers)
+
+	_, all := stats("?include_bots=true")
+	assert.Equal(t, int64(3), all.Total)
+	assert.True(t, all.IncludeBots)
+
+	resp, _ := stats("?include_bots=maybe")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestIssueToken(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	issue := func(body string, header func(*http.Request)) (*http.Response, TokenResponse) {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(body))
+		header(req)
+		w := httptest.NewRecorder()
+		h.IssueToken(w, req)
+		resp := w.Result()
+		var tok TokenResponse
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&tok))
+		}
+		resp.Body.Close()
+		return resp, tok
+	}
+	withCookie := func(r *http.Request) {
+		r.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")})
+	}
+
+	resp, tok := issue("", withCookie)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Bearer", tok.TokenType)
+	assert.Equal(t, strings.Join(auth.AllScopes(), " "), tok.Scope)
+	assert.InDelta(t, auth.DefaultTokenTTL.Seconds(), tok.ExpiresIn, 2)
+
+	// Токеном нельзя выпустить новый токен, даже с теми же правами
+	resp, readOnly := issue(`{"scopes":["links:read"],"expires_in":60}`, withCookie)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "links:read", readOnly.Scope)
+	bearer := func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+readOnly.AccessToken) }
+	resp, _ = issue(`{"scopes":["links:write"]}`, bearer)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	resp, _ = issue(`{"scopes":["links:read"]}`, bearer)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// Токен принимается вместо cookie
+	_, err := svc.ShortenURL(context.Background(), "owner", "https://example.com/token")
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/api/user/urls", nil)
+	bearer(req)
+	w := httptest.NewRecorder()
+	h.GetUserURLs(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "https://example.com/token")
+	assert.Empty(t, w.Result().Cookies())
+
+	resp, _ = issue(`{"scopes":["admin"]}`, withCookie)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAPIKeys(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+	h.Auth.APIKeys = svc
+
+	r := chi.NewRouter()
+	r.Use(h.Auth.Authenticate)
+	r.Post("/api/user/keys", h.CreateAPIKey)
+	r.Get("/api/user/keys", h.ListAPIKeys)
+	r.Delete("/api/user/keys/{id}", h.RevokeAPIKey)
+	r.With(h.Auth.RequireScope(auth.ScopeLinksWrite)).Post("/api/shorten", h.ReceiveShorten)
+	r.With(h.Auth.RequireScope(auth.ScopeLinksDelete)).Delete("/api/user/urls", h.DeleteUserURLs)
+
+	do := func(method, target, body string, header func(*http.Request)) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		header(req)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	owner := func(r *http.Request) {
+		r.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("owner")})
+	}
+
+	w := do(http.MethodPost, "/api/user/keys", `{"name":"ci","scopes":["links:write"]}`, owner)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created APIKeyResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	assert.True(t, strings.HasPrefix(created.Key, "sk_"))
+	withKey := func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+created.Key) }
+
+	w = do(http.MethodPost, "/api/user/keys", `{"scopes":["admin"]}`, owner)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Ключ позволяет сокращать, но не удалять
+	w = do(http.MethodPost, "/api/shorten", `{"url":"https://example.com/ci"}`, withKey)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, store.GetByUser("owner"), 1)
+	w = do(http.MethodDelete, "/api/user/urls", `["abc"]`, withKey)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), "insufficient_scope")
+	w = do(http.MethodPost, "/api/user/keys", `{"scopes":["links:write"]}`, withKey)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = do(http.MethodGet, "/api/user/keys", "", withKey)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = do(http.MethodGet, "/api/user/keys", "", owner)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), created.Key)
+	var keys []APIKeyResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&keys))
+	if assert.Len(t, keys, 1) {
+		assert.Equal(t, created.ID, keys[0].ID)
+		assert.Equal(t, "ci", keys[0].Name)
+	}
+
+	w = do(http.MethodDelete, "/api/user/keys/"+created.ID, "", owner)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = do(http.MethodDelete, "/api/user/keys/"+created.ID, "", owner)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodPost, "/api/shorten", `{"url":"https://example.com/revoked"}`, withKey)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAccounts_ClaimAnonymousLinks(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	svc.PasswordParams = accounts.Params{Memory: 1024, Time: 1, Threads: 1, SaltLen: 16, KeyLen: 32}
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+	h.Auth.Sessions = svc
+
+	r := chi.NewRouter()
+	r.Use(h.Auth.Authenticate)
+	r.Post("/api/auth/register", h.Register)
+	r.Post("/api/auth/login", h.Login)
+	r.Post("/api/auth/logout", h.Logout)
+	r.Get("/api/user/urls", h.GetUserURLs)
+
+	do := func(method, target, body string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	cookie := func(w *httptest.ResponseRecorder, name string) *http.Cookie {
+		for _, c := range w.Result().Cookies() {
+			if c.Name == name {
+				return c
+			}
+		}
+		return nil
+	}
+
+	_, err := svc.ShortenURL(context.Background(), "anon-1", "https://example.com/anon")
+	assert.NoError(t, err)
+	anon := &http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("anon-1")}
+
+	w := do(http.MethodPost, "/api/auth/register", `{"email":"User@Example.com","password":"short"}`, anon)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do(http.MethodPost, "/api/auth/register", `{"email":"User@Example.com","password":"correct horse"}`, anon)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var account AccountResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&account))
+	assert.Equal(t, "user@example.com", account.Email)
+	assert.EqualValues(t, 1, account.Claimed)
+	session := cookie(w, "session")
+	if assert.NotNil(t, session) {
+		assert.True(t, session.HttpOnly)
+	}
+	if rotated := cookie(w, "auth_token"); assert.NotNil(t, rotated) {
+		assert.NotEqual(t, anon.Value, rotated.Value, "анонимная cookie заменяется")
+	}
+
+	// Ссылки доступны по сессии, но не по прежней анонимной cookie
+	w = do(http.MethodGet, "/api/user/urls", "", session)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "https://example.com/anon")
+	w = do(http.MethodGet, "/api/user/urls", "", anon)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = do(http.MethodPost, "/api/auth/register", `{"email":"user@example.com","password":"another pass"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	w = do(http.MethodPost, "/api/auth/login", `{"email":"user@example.com","password":"wrong password"}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// После выхода сессия недействительна и не подменяется анонимной cookie
+	w = do(http.MethodPost, "/api/auth/logout", "", session)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = do(http.MethodGet, "/api/user/urls", "", session)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, cookie(w, "auth_token"))
+
+	_, err = svc.ShortenURL(context.Background(), "anon-2", "https://example.com/second")
+	assert.NoError(t, err)
+	w = do(http.MethodPost, "/api/auth/login", `{"email":"user@example.com","password":"correct horse"}`,
+		&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("anon-2")})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&account))
+	assert.EqualValues(t, 1, account.Claimed)
+	w = do(http.MethodGet, "/api/user/urls", "", cookie(w, "session"))
+	assert.Contains(t, w.Body.String(), "https://example.com/second")
+	assert.Contains(t, w.Body.String(), "https://example.com/anon")
+}
+
+func TestSSO_SignIn(t *testing.T) {
+	idp := ssotest.NewProvider(t)
+	provider, err := sso.New(context.Background(), sso.Config{
+		IssuerURL:    idp.URL,
+		ClientID:     ssotest.ClientID,
+		ClientSecret: ssotest.ClientSecret,
+		RedirectURL:  "http://localhost:8080/api/auth/oidc/callback",
+	})
+	require.NoError(t, err)
+
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+	h.Auth.Sessions = svc
+	h.SSO = provider
+
+	r := chi.NewRouter()
+	r.Use(h.Auth.Authenticate)
+	r.Get("/api/auth/oidc/login", h.SSOLogin)
+	r.Get("/api/auth/oidc/callback", h.SSOCallback)
+	r.Get("/api/user/urls", h.GetUserURLs)
+
+	do := func(target string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		for _, c := range cookies {
+			if c != nil {
+				req.AddCookie(c)
+			}
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	cookie := func(w *httptest.ResponseRecorder, name string) *http.Cookie {
+		for _, c := range w.Result().Cookies() {
+			if c.Name == name {
+				return c
+			}
+		}
+		return nil
+	}
+	noRedirect := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	// signIn проходит вход у провайдера и возвращает ответ на адрес возврата
+	signIn := func(returnTo string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		w := do("/api/auth/oidc/login?return_to="+url.QueryEscape(returnTo), cookies...)
+		require.Equal(t, http.StatusFound, w.Code)
+		resp, err := noRedirect.Get(w.Header().Get("Location"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		callback, err := url.Parse(resp.Header.Get("Location"))
+		require.NoError(t, err)
+		return do(callback.RequestURI(), append(cookies, cookie(w, "sso_state"))...)
+	}
+
+	_, err = svc.ShortenURL(context.Background(), "anon-1", "https://example.com/anon")
+	require.NoError(t, err)
+	anon := &http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue("anon-1")}
+
+	idp.SignIn("subject-1", "ann@example.com")
+	w := signIn("/dashboard", anon)
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/dashboard", w.Header().Get("Location"))
+	session := cookie(w, "session")
+	require.NotNil(t, session)
+	w = do("/api/user/urls", session)
+	assert.Contains(t, w.Body.String(), "https://example.com/anon", "анонимные ссылки переносятся")
+
+	// Повторный вход того же субъекта попадает к тому же пользователю
+	w = signIn("https://evil.example/")
+	assert.Equal(t, "/", w.Header().Get("Location"), "return_to только локальный")
+	w = do("/api/user/urls", cookie(w, "session"))
+	assert.Contains(t, w.Body.String(), "https://example.com/anon")
+
+	// Другой субъект — другой пользователь
+	idp.SignIn("subject-2", "bob@example.com")
+	w = signIn("/")
+	w = do("/api/user/urls", cookie(w, "session"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	// Возврат без cookie state, начатой этим браузером, отклоняется
+	w = do("/api/auth/oidc/login")
+	resp, err := noRedirect.Get(w.Header().Get("Location"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	w = do(callback.RequestURI())
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Nil(t, cookie(w, "session"))
+
+	w = do("/api/auth/oidc/callback?state=x&error=access_denied", &http.Cookie{Name: "sso_state", Value: "x"})
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestOrganizations_Roles(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	h := NewHandler(svc, zap.NewNop(), auth.New("test-secret"), nil)
+
+	r := chi.NewRouter()
+	r.Post("/api/orgs", h.CreateOrganization)
+	r.Get("/api/orgs", h.ListOrganizations)
+	r.Get("/api/orgs/{org}/members", h.ListMembers)
+	r.Put("/api/orgs/{org}/members/{user}", h.SetMember)
+	r.Delete("/api/orgs/{org}/members/{user}", h.RemoveMember)
+	r.Post("/api/orgs/{org}/urls", h.ShortenForOrganization)
+	r.Get("/api/orgs/{org}/urls", h.GetOrganizationURLs)
+	r.Delete("/api/orgs/{org}/urls", h.DeleteOrganizationURLs)
+
+	do := func(user, method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.AddCookie(&http.Cookie{Name: "auth_token", Value: h.Auth.SignCookieValue(user)})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("alice", http.MethodPost, "/api/orgs", `{"name":"  "}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("alice", http.MethodPost, "/api/orgs", `{"name":"Marketing"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var org OrganizationResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&org))
+	assert.Equal(t, "owner", org.Role)
+	base := "/api/orgs/" + org.ID
+
+	assert.Equal(t, http.StatusOK, do("alice", http.MethodPut, base+"/members/bob", `{"role":"editor"}`).Code)
+	assert.Equal(t, http.StatusOK, do("alice", http.MethodPut, base+"/members/carol", `{"role":"viewer"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do("alice", http.MethodPut, base+"/members/dave", `{"role":"admin"}`).Code)
+
+	// Редактор создаёт ссылку, она видна всем участникам и не видна посторонним
+	w = do("bob", http.MethodPost, base+"/urls", `{"url":"https://example.com/campaign"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created model.ShortenResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&created))
+	id := strings.TrimPrefix(created.Result, "http://localhost:8080/")
+	w = do("carol", http.MethodGet, base+"/urls", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "https://example.com/campaign")
+	assert.Equal(t, http.StatusNotFound, do("mallory", http.MethodGet, base+"/urls", "").Code)
+	w = do("mallory", http.MethodPut, base+"/members/mallory", `{"role":"owner"}`)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Наблюдатель не создаёт, не удаляет ссылки и не управляет участниками
+	assert.Equal(t, http.StatusForbidden, do("carol", http.MethodPost, base+"/urls", `{"url":"https://example.com/x"}`).Code)
+	assert.Equal(t, http.StatusForbidden, do("carol", http.MethodDelete, base+"/urls", `["`+id+`"]`).Code)
+	assert.Equal(t, http.StatusForbidden, do("bob", http.MethodPut, base+"/members/carol", `{"role":"owner"}`).Code)
+	assert.Equal(t, http.StatusForbidden, do("carol", http.MethodDelete, base+"/members/bob", "").Code)
+
+	// Ссылку организации удаляет редактор, но не через личный список
+	svc.DeleteURLs(context.Background(), "bob", []string{id})
+	w = do("alice", http.MethodGet, base+"/urls", "")
+	assert.Contains(t, w.Body.String(), "https://example.com/campaign")
+	assert.Equal(t, http.StatusAccepted, do("bob", http.MethodDelete, base+"/urls", `["`+id+`"]`).Code)
+	assert.Eventually(t, func() bool {
+		return do("alice", http.MethodGet, base+"/urls", "").Code == http.StatusNoContent
+	}, time.Second, 10*time.Millisecond)
+
+	// Последний владелец не может уйти или понизить себя
+	assert.Equal(t, http.StatusConflict, do("alice", http.MethodDelete, base+"/members/alice", "").Code)
+	assert.Equal(t, http.StatusConflict, do("alice", http.MethodPut, base+"/members/alice", `{"role":"editor"}`).Code)
+	assert.Equal(t, http.StatusNoContent, do("carol", http.MethodDelete, base+"/members/carol", "").Code)
+
+	w = do("alice", http.MethodGet, base+"/members", "")
+	var members []MemberResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&members))
+	assert.Len(t, members, 2)
+	w = do("bob", http.MethodGet, "/api/orgs", "")
+	assert.Contains(t, w.Body.String(), `"role":"editor"`)
+}
+
+func TestAdmin_Moderation(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	svc.Guard = nil
+	a := auth.New("test-secret")
+	a.Admins = []string{"root"}
+	a.APIKeys = svc
+	_, trusted, _ := net.ParseCIDR("10.0.0.0/8")
+	h := NewHandler(svc, zap.NewNop(), a, trusted)
+
+	r := chi.NewRouter()
+	r.Use(a.Authenticate)
+	r.Post("/api/shorten", h.ReceiveShorten)
+	r.Post("/api/auth/token", h.IssueToken)
+	r.Get("/api/admin/urls", h.SearchLinks)
+	r.Post("/api/admin/urls/{id}/disable", h.DisableLink)
+	r.Delete("/api/admin/urls/{id}", h.DeleteLink)
+	r.Put("/api/admin/users/{user}/ban", h.BanUser)
+	r.Delete("/api/admin/users/{user}/ban", h.UnbanUser)
+	r.Get("/api/admin/users/{user}/activity", h.GetUserActivity)
+
+	token, _, err := a.IssueSessionToken("root", nil, 0)
+	require.NoError(t, err)
+	_, rootKey, err := svc.CreateAPIKey(context.Background(), "root", "ci", auth.AllScopes())
+	require.NoError(t, err)
+	do := func(as, method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		switch {
+		case as == "admin":
+			req.Header.Set("Authorization", "Bearer "+token)
+		case strings.HasPrefix(as, "bearer:"):
+			req.Header.Set("Authorization", "Bearer "+strings.TrimPrefix(as, "bearer:"))
+		case strings.HasPrefix(as, "ip:"):
+			req.Header.Set("X-Real-IP", strings.TrimPrefix(as, "ip:"))
+		default:
+			req.AddCookie(&http.Cookie{Name: "auth_token", Value: a.SignCookieValue(as)})
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+	shorten := func(user, url string) string {
+		w := do(user, http.MethodPost, "/api/shorten", `{"url":"`+url+`"}`)
+		require.Equal(t, http.StatusCreated, w.Code)
+		var resp model.ShortenResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return strings.TrimPrefix(resp.Result, "http://localhost:8080/")
+	}
+	spam := shorten("spammer", "https://win.spam.example/prize")
+	other := shorten("spammer", "https://example.org/ok")
+	shorten("alice", "https://spam.example/")
+
+	// Доступ только администраторам: ни доверенная подсеть, ни ключ API
+	// администратора и выпущенные по cookie токены роли не дают
+	assert.Equal(t, http.StatusForbidden, do("alice", http.MethodGet, "/api/admin/urls", "").Code)
+	assert.Equal(t, http.StatusForbidden, do("root", http.MethodGet, "/api/admin/urls", "").Code)
+	assert.Equal(t, http.StatusForbidden, do("ip:10.1.2.3", http.MethodGet, "/api/admin/urls", "").Code)
+	assert.Equal(t, http.StatusForbidden, do("bearer:"+rootKey, http.MethodGet, "/api/admin/urls", "").Code)
+	assert.Equal(t, http.StatusForbidden, do("bearer:"+rootKey, http.MethodPost, "/api/auth/token", "").Code)
+	w := do("root", http.MethodPost, "/api/auth/token", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var cookieToken TokenResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&cookieToken))
+	assert.Equal(t, http.StatusForbidden, do("bearer:"+cookieToken.AccessToken, http.MethodGet, "/api/admin/urls", "").Code)
+	assert.Equal(t, http.StatusOK, do("admin", http.MethodGet, "/api/admin/urls", "").Code)
+
+	w = do("admin", http.MethodGet, "/api/admin/urls?domain=spam.example&user=spammer&from=2000-01-01", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var links []AdminLinkResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&links))
+	require.Len(t, links, 1)
+	assert.Equal(t, "http://localhost:8080/"+spam, links[0].ShortURL)
+	assert.Equal(t, http.StatusBadRequest, do("admin", http.MethodGet, "/api/admin/urls?domain=a/b", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do("admin", http.MethodGet, "/api/admin/urls?limit=5000", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do("admin", http.MethodGet, "/api/admin/urls?from=yesterday", "").Code)
+
+	// Отключение и удаление любой ссылки
+	assert.Equal(t, http.StatusNoContent, do("admin", http.MethodPost, "/api/admin/urls/"+spam+"/disable", `{"reason":"phishing"}`).Code)
+	entry, ok := store.GetEntry(spam)
+	require.True(t, ok)
+	assert.True(t, entry.IsDisabled)
+	assert.Equal(t, "admin: phishing", entry.DisabledReason)
+	assert.Equal(t, http.StatusNoContent, do("admin", http.MethodDelete, "/api/admin/urls/"+other, "").Code)
+	entry, _ = store.GetEntry(other)
+	assert.True(t, entry.IsDeleted)
+	assert.Equal(t, http.StatusNotFound, do("admin", http.MethodDelete, "/api/admin/urls/missing", "").Code)
+
+	// Запрет блокирует создание ссылок
+	w = do("admin", http.MethodPut, "/api/admin/users/alice/ban", `{"reason":"spam","disable_links":true}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	var ban BanResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ban))
+	assert.Equal(t, 1, ban.DisabledLinks)
+	assert.Equal(t, "root", ban.By)
+	assert.Equal(t, http.StatusForbidden, do("alice", http.MethodPost, "/api/shorten", `{"url":"https://example.com/"}`).Code)
+
+	w = do("admin", http.MethodGet, "/api/admin/users/spammer/activity", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var activity UserActivityResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&activity))
+	assert.Equal(t, int64(2), activity.Links)
+	assert.Equal(t, int64(1), activity.Disabled)
+	assert.Equal(t, int64(1), activity.Deleted)
+	assert.Nil(t, activity.Ban)
+	assert.Len(t, activity.Recent, 2)
+
+	assert.Equal(t, http.StatusNoContent, do("admin", http.MethodDelete, "/api/admin/users/alice/ban", "").Code)
+	assert.Equal(t, http.StatusNotFound, do("admin", http.MethodDelete, "/api/admin/users/alice/ban", "").Code)
+	assert.Equal(t, http.StatusCreated, do("alice", http.MethodPost, "/api/shorten", `{"url":"https://example.com/"}`).Code)
+}
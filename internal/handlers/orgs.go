@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/go-chi/chi/v5"
+)
+
+// OrganizationRequest — запрос на создание организации.
+type OrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// OrganizationResponse — организация и роль в ней текущего пользователя.
+type OrganizationResponse struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Role    string    `json:"role"`
+	Created time.Time `json:"created"`
+}
+
+// MemberRequest — роль участника: owner, editor или viewer.
+type MemberRequest struct {
+	Role string `json:"role"`
+}
+
+// MemberResponse — участник организации.
+type MemberResponse struct {
+	UserID  string    `json:"user_id"`
+	Role    string    `json:"role"`
+	Created time.Time `json:"created"`
+}
+
+func organizationResponse(w model.Workspace) OrganizationResponse {
+	return OrganizationResponse{ID: w.ID, Name: w.Name, Role: w.Role, Created: w.Created}
+}
+
+func memberResponse(m model.Membership) MemberResponse {
+	return MemberResponse{UserID: m.UserID, Role: m.Role, Created: m.Created}
+}
+
+// CreateOrganization создаёт организацию; пользователь становится её владельцем.
+func (h *Handler) CreateOrganization(res http.ResponseWriter, req *http.Request) {
+	var body OrganizationRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	userID := h.Auth.GetOrSetUserID(res, req)
+	org, err := h.Service.CreateOrganization(req.Context(), userID, body.Name)
+	if err != nil {
+		h.writeServiceError(res, "CreateOrganization error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	json.NewEncoder(res).Encode(organizationResponse(org))
+}
+
+// ListOrganizations возвращает организации пользователя с его ролями.
+func (h *Handler) ListOrganizations(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	list, err := h.Service.ListOrganizations(req.Context(), userID)
+	if err != nil {
+		h.writeServiceError(res, "ListOrganizations error", err)
+		return
+	}
+	resp := make([]OrganizationResponse, 0, len(list))
+	for _, w := range list {
+		resp = append(resp, organizationResponse(w))
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(resp)
+}
+
+// ListMembers возвращает участников организации.
+func (h *Handler) ListMembers(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	members, err := h.Service.ListMembers(req.Context(), userID, chi.URLParam(req, "org"))
+	if err != nil {
+		h.writeServiceError(res, "ListMembers error", err)
+		return
+	}
+	resp := make([]MemberResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, memberResponse(m))
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(resp)
+}
+
+// SetMember добавляет участника организации или меняет его роль.
+func (h *Handler) SetMember(res http.ResponseWriter, req *http.Request) {
+	var body MemberRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	userID := h.Auth.GetOrSetUserID(res, req)
+	m, err := h.Service.SetMember(req.Context(), userID, chi.URLParam(req, "org"), chi.URLParam(req, "user"), body.Role)
+	if err != nil {
+		h.writeServiceError(res, "SetMember error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(memberResponse(m))
+}
+
+// RemoveMember исключает участника из организации или выводит из неё самого пользователя.
+func (h *Handler) RemoveMember(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	if err := h.Service.RemoveMember(req.Context(), userID, chi.URLParam(req, "org"), chi.URLParam(req, "user")); err != nil {
+		h.writeServiceError(res, "RemoveMember error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
+
+// ShortenForOrganization создаёт ссылку, принадлежащую организации.
+func (h *Handler) ShortenForOrganization(res http.ResponseWriter, req *http.Request) {
+	var request model.ShortenRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil || request.URL == "" {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	parsedURL, err := url.ParseRequestURI(request.URL)
+	if err != nil || parsedURL.Scheme == "" || parsedURL.Host == "" {
+		http.Error(res, "Invalid URL", http.StatusBadRequest)
+		return
+	}
+
+	userID := h.Auth.GetOrSetUserID(res, req)
+	short, err := h.Service.ShortenForOrganization(req.Context(), userID, chi.URLParam(req, "org"), request.URL)
+	if err != nil {
+		h.writeServiceError(res, "ShortenForOrganization error", err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusCreated)
+	json.NewEncoder(res).Encode(model.ShortenResponse{Result: fmt.Sprintf("%s/%s", h.Service.BaseURL, short)})
+}
+
+// GetOrganizationURLs возвращает ссылки организации.
+func (h *Handler) GetOrganizationURLs(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	results, err := h.Service.GetOrganizationURLs(req.Context(), userID, chi.URLParam(req, "org"))
+	if err != nil {
+		h.writeServiceError(res, "GetOrganizationURLs error", err)
+		return
+	}
+	if len(results) == 0 {
+		res.WriteHeader(http.StatusNoContent)
+		return
+	}
+	resp := make([]UserURLResponse, 0, len(results))
+	for _, r := range results {
+		resp = append(resp, UserURLResponse{
+			ShortURL:    fmt.Sprintf("%s/%s", h.Service.BaseURL, r.ShortURL),
+			OriginalURL: r.OriginalURL,
+			Metadata:    r.Metadata,
+		})
+	}
+	res.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(res).Encode(resp)
+}
+
+// DeleteOrganizationURLs удаляет ссылки организации в фоне.
+func (h *Handler) DeleteOrganizationURLs(res http.ResponseWriter, req *http.Request) {
+	var ids []string
+	if err := json.NewDecoder(req.Body).Decode(&ids); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	userID := h.Auth.GetOrSetUserID(res, req)
+	if err := h.Service.DeleteOrganizationURLsAsync(req.Context(), userID, chi.URLParam(req, "org"), ids); err != nil {
+		h.writeServiceError(res, "DeleteOrganizationURLs error", err)
+		return
+	}
+	res.WriteHeader(http.StatusAccepted)
+}
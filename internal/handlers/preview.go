@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// previewTemplate — страница предпросмотра, показываемая вместо перенаправления.
+var previewTemplate = template.Must(template.New("preview").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Переход по ссылке: {{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Короткая ссылка <code>{{.ShortURL}}</code> ведёт на:</p>
+<p><a href="{{.Target}}" rel="noopener noreferrer nofollow">{{.Target}}</a></p>
+{{if .Created}}<p>Создана: {{.Created}}</p>{{end}}
+<p><a href="{{.Target}}" rel="noopener noreferrer nofollow">Перейти</a></p>
+</body>
+</html>
+`))
+
+// previewPage содержит данные для шаблона предпросмотра.
+type previewPage struct {
+	Title    string
+	ShortURL string
+	Target   string
+	Created  string
+}
+
+// PreviewRequest задаёт обязательный показ страницы предпросмотра для ссылки.
+type PreviewRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// renderPreview отдаёт HTML-страницу с адресом назначения вместо перенаправления.
+func (h *Handler) renderPreview(res http.ResponseWriter, urlObj *model.URLObject, target string) {
+	page := previewPage{
+		Title:    target,
+		ShortURL: h.Service.BaseURL + "/" + urlObj.Shorten,
+		Target:   target,
+	}
+	if parsed, err := url.Parse(target); err == nil && parsed.Host != "" {
+		page.Title = parsed.Hostname()
+	}
+	// Метаданные описывают основной адрес назначения, а не цели правил перенаправления
+	if meta := urlObj.Metadata; meta != nil && target == urlObj.Origin {
+		if title := strings.TrimSpace(meta.Title); title != "" {
+			page.Title = title
+		} else if title := strings.TrimSpace(meta.OpenGraph["title"]); title != "" {
+			page.Title = title
+		}
+	}
+	if !urlObj.Created.IsZero() {
+		page.Created = urlObj.Created.UTC().Format(time.DateOnly)
+	}
+
+	res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(http.StatusOK)
+	if err := previewTemplate.Execute(res, page); err != nil {
+		h.Logger.Error("Preview render error", zap.Error(err))
+	}
+}
+
+// SetForcePreview включает или выключает обязательный предпросмотр ссылки пользователя.
+// Принимает JSON вида {"enabled": true}.
+func (h *Handler) SetForcePreview(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	var body PreviewRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if err := h.Service.SetForcePreview(req.Context(), userID, chi.URLParam(req, "id"), body.Enabled); err != nil {
+		h.writeServiceError(res, "SetForcePreview error", err)
+		return
+	}
+	res.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/Totarae/URLShortener/internal/sso"
+	"go.uber.org/zap"
+)
+
+// SSOLogin начинает вход через провайдер OpenID Connect: запоминает state в cookie
+// и перенаправляет на страницу входа провайдера. Параметр return_to — локальный
+// путь, на который пользователь вернётся после входа.
+func (h *Handler) SSOLogin(res http.ResponseWriter, req *http.Request) {
+	if h.SSO == nil {
+		http.NotFound(res, req)
+		return
+	}
+	returnTo := req.URL.Query().Get("return_to")
+	if !isLocalPath(returnTo) {
+		returnTo = "/"
+	}
+	state, authURL, err := h.SSO.Start(returnTo)
+	if err != nil {
+		h.Logger.Error("SSO start error", zap.Error(err))
+		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	h.Auth.SetSSOState(res, state, h.SSO.FlowTTL)
+	res.Header().Set("Cache-Control", "no-store")
+	http.Redirect(res, req, authURL, http.StatusFound)
+}
+
+// SSOCallback завершает вход через провайдер: проверяет state, обменивает код,
+// проверяет ID-токен и открывает сессию пользователя, сопоставленного субъекту.
+// Ссылки из анонимной cookie переносятся ему, как при обычном входе.
+func (h *Handler) SSOCallback(res http.ResponseWriter, req *http.Request) {
+	if h.SSO == nil {
+		http.NotFound(res, req)
+		return
+	}
+	query := req.URL.Query()
+	expected, ok := h.Auth.SSOState(res, req)
+	if !ok || query.Get("state") != expected {
+		http.Error(res, "invalid sign-in state", http.StatusBadRequest)
+		return
+	}
+	if reason := query.Get("error"); reason != "" {
+		h.Logger.Info("SSO sign-in declined", zap.String("error", reason))
+		http.Error(res, "sign-in was not completed", http.StatusUnauthorized)
+		return
+	}
+
+	claims, returnTo, err := h.SSO.Finish(req.Context(), expected, query.Get("code"))
+	switch {
+	case errors.Is(err, sso.ErrUnknownState):
+		http.Error(res, "invalid sign-in state", http.StatusBadRequest)
+		return
+	case err != nil:
+		h.Logger.Warn("SSO sign-in failed", zap.Error(err))
+		http.Error(res, "sign-in failed", http.StatusUnauthorized)
+		return
+	}
+
+	anonUserID, _ := h.Auth.AnonymousUserID(req)
+	login, err := h.Service.LoginIdentity(req.Context(), claims.Issuer, claims.Subject, claims.Email, anonUserID)
+	if err != nil {
+		h.writeServiceError(res, "SSO login error", err)
+		return
+	}
+	h.Auth.SetSession(res, login.Token, login.ExpiresAt)
+	if anonUserID != "" {
+		h.Auth.ResetUserID(res)
+	}
+	res.Header().Set("Cache-Control", "no-store")
+	http.Redirect(res, req, returnTo, http.StatusSeeOther)
+}
+
+// isLocalPath не даёт использовать return_to для перенаправления на чужой сайт.
+func isLocalPath(path string) bool {
+	return strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//") && !strings.Contains(path, "\\")
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/useragent"
+	"go.uber.org/zap"
+)
+
+// ClickEvent — событие перехода в потоке /api/user/urls/stream.
+type ClickEvent struct {
+	ShortURL string    `json:"short_url"`
+	Time     time.Time `json:"time"`
+	Referrer string    `json:"referrer,omitempty"`
+	Browser  string    `json:"browser"`
+	OS       string    `json:"os"`
+	Country  string    `json:"country,omitempty"`
+	Variant  string    `json:"variant,omitempty"`
+	Bot      bool      `json:"bot,omitempty"`
+}
+
+// StreamClicks отправляет переходы по ссылкам пользователя в формате
+// Server-Sent Events: "click" на каждый переход, "heartbeat" при отсутствии
+// событий и "evicted" перед закрытием, если клиент не успевает читать поток.
+func (h *Handler) StreamClicks(res http.ResponseWriter, req *http.Request) {
+	userID := h.Auth.GetOrSetUserID(res, req)
+	sub, err := h.Service.SubscribeClicks(userID)
+	if err != nil {
+		h.writeServiceError(res, "SubscribeClicks error", err)
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(res)
+	// Поток живёт дольше, чем WriteTimeout сервера
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		h.Logger.Error("Stream flush error", zap.Error(err))
+		return
+	}
+
+	heartbeat := time.NewTicker(h.Service.Heartbeat())
+	defer heartbeat.Stop()
+
+	for {
+		var err error
+		select {
+		case <-req.Context().Done():
+			return
+		case <-sub.Done():
+			if sub.Evicted() {
+				writeEvent(res, "evicted", "{}")
+				rc.Flush()
+			}
+			return
+		case click := <-sub.Events():
+			data, _ := json.Marshal(ClickEvent{
+				ShortURL: h.Service.BaseURL + "/" + click.Short,
+				Time:     click.Time.UTC(),
+				Referrer: click.Referrer,
+				Browser:  useragent.Browser(click.UserAgent),
+				OS:       useragent.OS(click.UserAgent),
+				Country:  click.Country,
+				Variant:  click.Variant,
+				Bot:      click.Bot,
+			})
+			err = writeEvent(res, "click", string(data))
+		case t := <-heartbeat.C:
+			err = writeEvent(res, "heartbeat", fmt.Sprintf(`{"time":%q}`, t.UTC().Format(time.RFC3339)))
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent записывает одно событие SSE.
+func writeEvent(res http.ResponseWriter, event, data string) error {
+	_, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/auth"
+	"go.uber.org/zap"
+)
+
+// TokenRequest — необязательное тело запроса токена.
+// Пустой список областей означает все области, доступные вызывающему.
+type TokenRequest struct {
+	Scopes []string `json:"scopes,omitempty"`
+	// ExpiresIn — желаемый срок жизни в секундах, не больше настроенного максимума.
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+}
+
+// TokenResponse — выпущенный токен в формате ответа OAuth 2.0.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// IssueToken выпускает токен для передачи в заголовке Authorization: Bearer.
+// Пользователь определяется по cookie или сессии; без них создаётся новый.
+// Токеном или ключом API новый токен не выпускается: иначе токен можно было бы
+// продлевать бесконечно, а отзыв ключа API не прекращал бы доступ.
+func (h *Handler) IssueToken(res http.ResponseWriter, req *http.Request) {
+	var body TokenRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(res, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if body.ExpiresIn < 0 {
+		http.Error(res, "expires_in must be positive", http.StatusBadRequest)
+		return
+	}
+
+	identity, ok := h.Auth.Identify(req)
+	if ok && identity.Bearer {
+		http.Error(res, "tokens are issued only by cookie or session", http.StatusForbidden)
+		return
+	}
+	if !ok {
+		identity = auth.Identity{UserID: h.Auth.GetOrSetUserID(res, req), Scopes: auth.AllScopes()}
+	}
+
+	scopes := body.Scopes
+	if len(scopes) == 0 {
+		scopes = identity.Scopes
+	}
+	for _, s := range scopes {
+		if !identity.HasScope(s) {
+			http.Error(res, "scope not allowed: "+s, http.StatusBadRequest)
+			return
+		}
+	}
+
+	issue := h.Auth.IssueToken
+	if identity.Session {
+		issue = h.Auth.IssueSessionToken
+	}
+	token, expires, err := issue(identity.UserID, scopes, time.Duration(body.ExpiresIn)*time.Second)
+	if err != nil {
+		h.Logger.Error("IssueToken error", zap.Error(err))
+		http.Error(res, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(res).Encode(TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(expires).Round(time.Second).Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	})
+}
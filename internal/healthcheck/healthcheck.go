@@ -0,0 +1,180 @@
+// Package healthcheck периодически проверяет доступность адресов назначения
+// коротких ссылок.
+package healthcheck
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/netguard"
+)
+
+// maxBodyRead ограничивает объём тела, вычитываемого при GET-проверке.
+const maxBodyRead = 64 << 10
+
+// Target — проверяемая ссылка.
+type Target struct {
+	Short string
+	URL   string
+}
+
+// Result — результат проверки одной ссылки.
+type Result struct {
+	Short  string
+	Health model.LinkHealth
+}
+
+// Checker проверяет адреса назначения запросом HEAD, а если сервер его
+// не поддерживает или отвечает ошибкой — запросом GET.
+type Checker struct {
+	Client *http.Client
+	// Concurrency ограничивает количество одновременных проверок.
+	Concurrency int
+	// HostInterval — минимальный интервал между запросами к одному хосту.
+	HostInterval time.Duration
+	UserAgent    string
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+// New создаёт Checker с защитой от SSRF и настройками по умолчанию.
+func New() *Checker {
+	return &Checker{
+		Client:       netguard.NewClient(10*time.Second, false),
+		Concurrency:  8,
+		HostInterval: time.Second,
+		UserAgent:    "URLShortener-HealthCheck/1.0",
+	}
+}
+
+// CheckAll проверяет все ссылки и возвращает результаты в порядке targets.
+func (c *Checker) CheckAll(ctx context.Context, targets []Target) []Result {
+	c.mu.Lock()
+	c.nextSlot = make(map[string]time.Time)
+	c.mu.Unlock()
+
+	concurrency := c.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	results := make([]Result, len(targets))
+
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return results[:i]
+		}
+		wg.Add(1)
+		go func(i int, t Target) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = Result{Short: t.Short, Health: c.Probe(ctx, t.URL)}
+		}(i, t)
+	}
+	wg.Wait()
+	return results
+}
+
+// Probe проверяет один адрес. Status равен 0, если ответ не получен.
+func (c *Checker) Probe(ctx context.Context, raw string) model.LinkHealth {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return model.LinkHealth{CheckedAt: time.Now(), Error: err.Error()}
+	}
+	if err := c.wait(ctx, parsed.Host); err != nil {
+		return model.LinkHealth{CheckedAt: time.Now(), Error: err.Error()}
+	}
+
+	health := c.do(ctx, http.MethodHead, raw)
+	if health.Broken() {
+		// Часть серверов не поддерживает HEAD или отвечает на него иначе, чем на GET
+		if err := c.wait(ctx, parsed.Host); err != nil {
+			return health
+		}
+		health = c.do(ctx, http.MethodGet, raw)
+	}
+	return health
+}
+
+func (c *Checker) do(ctx context.Context, method, raw string) model.LinkHealth {
+	start := time.Now()
+	health := model.LinkHealth{CheckedAt: start}
+
+	req, err := http.NewRequestWithContext(ctx, method, raw, nil)
+	if err != nil {
+		health.Error = err.Error()
+		return health
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		health.LatencyMs = time.Since(start).Milliseconds()
+		health.Error = err.Error()
+		return health
+	}
+	if method == http.MethodGet {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyRead))
+	}
+	resp.Body.Close()
+
+	health.Status = resp.StatusCode
+	health.LatencyMs = time.Since(start).Milliseconds()
+	return health
+}
+
+// wait резервирует для хоста ближайшее свободное окно и ждёт его наступления.
+func (c *Checker) wait(ctx context.Context, host string) error {
+	if c.HostInterval <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	if c.nextSlot == nil {
+		c.nextSlot = make(map[string]time.Time)
+	}
+	now := time.Now()
+	slot := c.nextSlot[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot[host] = slot.Add(c.HostInterval)
+	c.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Schedule вызывает fn сразу и затем с заданным интервалом, пока не отменён ctx.
+func Schedule(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package healthcheck_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/healthcheck"
+	"github.com/Totarae/URLShortener/internal/netguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestChecker создаёт Checker, которому разрешены loopback-адреса httptest.
+func newTestChecker() *healthcheck.Checker {
+	c := healthcheck.New()
+	c.Client = netguard.NewClient(2*time.Second, true)
+	c.HostInterval = 0
+	return c
+}
+
+func TestProbe_HeadFallbackToGet(t *testing.T) {
+	var methods []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	h := newTestChecker().Probe(context.Background(), srv.URL)
+	assert.Equal(t, http.StatusOK, h.Status)
+	assert.False(t, h.Broken())
+	assert.Equal(t, []string{http.MethodHead, http.MethodGet}, methods)
+}
+
+func TestProbe_BrokenLink(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	h := newTestChecker().Probe(context.Background(), srv.URL+"/gone")
+	assert.Equal(t, http.StatusNotFound, h.Status)
+	assert.True(t, h.Broken())
+	assert.False(t, h.CheckedAt.IsZero())
+}
+
+func TestProbe_RefusesPrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request must not reach a loopback server")
+	}))
+	defer srv.Close()
+
+	c := healthcheck.New()
+	c.HostInterval = 0
+	h := c.Probe(context.Background(), srv.URL)
+	assert.Equal(t, 0, h.Status)
+	assert.Contains(t, h.Error, netguard.ErrPrivateAddress.Error())
+}
+
+func TestCheckAll_BoundedConcurrency(t *testing.T) {
+	var current, peak int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+	}))
+	defer srv.Close()
+
+	c := newTestChecker()
+	c.Concurrency = 3
+	targets := make([]healthcheck.Target, 12)
+	for i := range targets {
+		targets[i] = healthcheck.Target{Short: string(rune('a' + i)), URL: srv.URL}
+	}
+
+	results := c.CheckAll(context.Background(), targets)
+	require.Len(t, results, len(targets))
+	for i, r := range results {
+		assert.Equal(t, targets[i].Short, r.Short)
+		assert.Equal(t, http.StatusOK, r.Health.Status)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+}
+
+func TestCheckAll_PerHostRateLimit(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	c := newTestChecker()
+	c.Concurrency = 4
+	c.HostInterval = 50 * time.Millisecond
+	targets := []healthcheck.Target{{Short: "a", URL: srv.URL}, {Short: "b", URL: srv.URL}, {Short: "c", URL: srv.URL}}
+
+	c.CheckAll(context.Background(), targets)
+
+	require.Len(t, times, 3)
+	first, last := times[0], times[0]
+	for _, ts := range times {
+		if ts.Before(first) {
+			first = ts
+		}
+		if ts.After(last) {
+			last = ts
+		}
+	}
+	assert.GreaterOrEqual(t, last.Sub(first), 90*time.Millisecond)
+}
@@ -0,0 +1,154 @@
+// Package hll реализует HyperLogLog — вероятностную оценку количества
+// уникальных значений в фиксированном объёме памяти. Скетчи можно объединять,
+// поэтому дневные скетчи складываются в оценку за произвольный период.
+package hll
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/bits"
+)
+
+// Precision — число бит хеша, выбирающих регистр. 2^12 регистров дают
+// стандартную ошибку около 1,6% при 4 КиБ на скетч.
+const Precision = 12
+
+const registers = 1 << Precision
+
+// Форматы сериализации.
+const (
+	formatDense  = 1
+	formatSparse = 2
+)
+
+// ErrInvalidSketch — сериализованный скетч повреждён или имеет другую точность.
+var ErrInvalidSketch = errors.New("invalid hyperloglog sketch")
+
+// Sketch — скетч HyperLogLog. Нулевое значение непригодно, используйте New.
+type Sketch struct {
+	reg []uint8
+}
+
+// New создаёт пустой скетч.
+func New() *Sketch {
+	return &Sketch{reg: make([]uint8, registers)}
+}
+
+// Add учитывает значение по его 64-битному хешу.
+func (s *Sketch) Add(hash uint64) {
+	idx := hash >> (64 - Precision)
+	// Сторожевой бит ограничивает ранг, если оставшиеся биты нулевые
+	rank := uint8(bits.LeadingZeros64(hash<<Precision|1<<(Precision-1)) + 1)
+	if rank > s.reg[idx] {
+		s.reg[idx] = rank
+	}
+}
+
+// AddString учитывает строку.
+func (s *Sketch) AddString(v string) {
+	s.Add(Hash(v))
+}
+
+// Merge добавляет в скетч значения другого скетча.
+func (s *Sketch) Merge(other *Sketch) {
+	for i, r := range other.reg {
+		if r > s.reg[i] {
+			s.reg[i] = r
+		}
+	}
+}
+
+// Estimate возвращает оценку количества уникальных значений.
+func (s *Sketch) Estimate() uint64 {
+	const m = float64(registers)
+	sum, zeros := 0.0, 0
+	for _, r := range s.reg {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	// Для малых количеств точнее линейный подсчёт пустых регистров
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// MarshalBinary сериализует скетч. Скетч с небольшим числом заполненных
+// регистров хранится как список пар (регистр, значение).
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	filled := 0
+	for _, r := range s.reg {
+		if r != 0 {
+			filled++
+		}
+	}
+	if 3*filled >= registers {
+		return append([]byte{formatDense, Precision}, s.reg...), nil
+	}
+	data := make([]byte, 2, 2+3*filled)
+	data[0], data[1] = formatSparse, Precision
+	for i, r := range s.reg {
+		if r != 0 {
+			data = append(data, byte(i>>8), byte(i), r)
+		}
+	}
+	return data, nil
+}
+
+// UnmarshalBinary восстанавливает скетч, сериализованный MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[1] != Precision {
+		return ErrInvalidSketch
+	}
+	reg := make([]uint8, registers)
+	switch body := data[2:]; data[0] {
+	case formatDense:
+		if len(body) != registers {
+			return ErrInvalidSketch
+		}
+		copy(reg, body)
+	case formatSparse:
+		if len(body)%3 != 0 {
+			return ErrInvalidSketch
+		}
+		for i := 0; i < len(body); i += 3 {
+			idx := int(body[i])<<8 | int(body[i+1])
+			if idx >= registers {
+				return ErrInvalidSketch
+			}
+			reg[idx] = body[i+2]
+		}
+	default:
+		return ErrInvalidSketch
+	}
+	s.reg = reg
+	return nil
+}
+
+// Decode создаёт скетч из данных MarshalBinary.
+func Decode(data []byte) (*Sketch, error) {
+	s := &Sketch{}
+	if err := s.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Hash возвращает 64-битный хеш строки с хорошим перемешиванием бит:
+// FNV-1a с финализатором MurmurHash3.
+func Hash(v string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(v))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb93fe5366d53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,63 @@
+package hll_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/hll"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertClose(t *testing.T, want int, got uint64) {
+	t.Helper()
+	// Шесть стандартных ошибок при точности 12 — около 10%
+	assert.LessOrEqual(t, math.Abs(float64(got)-float64(want))/float64(want), 0.1,
+		"estimate %d for %d values", got, want)
+}
+
+func TestEstimate(t *testing.T) {
+	for _, n := range []int{10, 1000, 100000} {
+		s := hll.New()
+		for i := 0; i < n; i++ {
+			v := fmt.Sprintf("visitor-%d", i)
+			s.AddString(v)
+			s.AddString(v) // повторы не увеличивают оценку
+		}
+		assertClose(t, n, s.Estimate())
+	}
+	assert.Zero(t, hll.New().Estimate())
+}
+
+func TestMerge(t *testing.T) {
+	a, b := hll.New(), hll.New()
+	for i := 0; i < 6000; i++ {
+		a.AddString(fmt.Sprint(i))
+	}
+	for i := 4000; i < 10000; i++ {
+		b.AddString(fmt.Sprint(i))
+	}
+	a.Merge(b)
+	assertClose(t, 10000, a.Estimate())
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	for _, n := range []int{0, 5, 50000} {
+		s := hll.New()
+		for i := 0; i < n; i++ {
+			s.AddString(fmt.Sprint(i))
+		}
+		data, err := s.MarshalBinary()
+		assert.NoError(t, err)
+		if n == 5 {
+			assert.Less(t, len(data), 32, "small sketch should use sparse encoding")
+		}
+
+		restored := hll.New()
+		assert.NoError(t, restored.UnmarshalBinary(data))
+		assert.Equal(t, s.Estimate(), restored.Estimate())
+	}
+
+	assert.ErrorIs(t, hll.New().UnmarshalBinary([]byte{1, 14}), hll.ErrInvalidSketch)
+	assert.ErrorIs(t, hll.New().UnmarshalBinary([]byte{2, 12, 0xff, 0xff, 1}), hll.ErrInvalidSketch)
+}
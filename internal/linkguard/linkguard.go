@@ -0,0 +1,148 @@
+// Package linkguard отклоняет адреса назначения, которые ведут обратно
+// на сам сервис или образуют цепочки через другие сокращатели ссылок.
+package linkguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultShorteners — известные публичные сокращатели ссылок.
+var DefaultShorteners = []string{
+	"bit.ly", "bitly.com", "tinyurl.com", "t.co", "goo.gl", "ow.ly", "is.gd",
+	"buff.ly", "cutt.ly", "rebrand.ly", "shorturl.at", "tiny.cc", "clck.ru", "vk.cc",
+}
+
+var (
+	// ErrSelfReference — адрес ведёт на домен самого сервиса.
+	ErrSelfReference = errors.New("destination points to this shortener")
+	// ErrShortenerChain — адрес ведёт на другой сокращатель, и развернуть его не удалось.
+	ErrShortenerChain = errors.New("destination is another URL shortener")
+	// ErrRedirectLoop — при разворачивании цепочки обнаружен цикл или превышен лимит переходов.
+	ErrRedirectLoop = errors.New("redirect loop while unwrapping shortener chain")
+)
+
+// Guard проверяет адреса назначения перед сокращением.
+type Guard struct {
+	ownHosts   []string
+	shorteners []string
+	// Unwrap включает разворачивание ссылок известных сокращателей до конечного адреса.
+	Unwrap bool
+	// MaxHops ограничивает количество переходов при разворачивании.
+	MaxHops int
+	Client  *http.Client
+}
+
+// New создаёт Guard для собственных доменов и списка известных сокращателей.
+// Домены сравниваются без учёта порта, поддомены считаются совпадением.
+func New(ownHosts, shorteners []string, unwrap bool) *Guard {
+	return &Guard{
+		ownHosts:   normalizeHosts(ownHosts),
+		shorteners: normalizeHosts(shorteners),
+		Unwrap:     unwrap,
+		MaxHops:    5,
+		Client: &http.Client{
+			Timeout: 5 * time.Second,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// HostOf возвращает имя хоста из URL или пустую строку.
+func HostOf(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// Check проверяет адрес назначения и возвращает адрес, который следует сохранить.
+// При включённом Unwrap ссылки известных сокращателей заменяются конечным адресом.
+func (g *Guard) Check(ctx context.Context, raw string) (string, error) {
+	current := raw
+	visited := map[string]struct{}{}
+	for hop := 0; ; hop++ {
+		host := HostOf(current)
+		if matchHost(g.ownHosts, host) {
+			return "", ErrSelfReference
+		}
+		if !matchHost(g.shorteners, host) {
+			return current, nil
+		}
+		if !g.Unwrap {
+			return "", ErrShortenerChain
+		}
+		if _, seen := visited[current]; seen || hop >= g.MaxHops {
+			return "", ErrRedirectLoop
+		}
+		visited[current] = struct{}{}
+
+		next, err := g.follow(ctx, current)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrShortenerChain, err)
+		}
+		current = next
+	}
+}
+
+// follow выполняет один переход по ссылке сокращателя и возвращает адрес из Location.
+func (g *Guard) follow(ctx context.Context, raw string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, raw, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := g.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	loc, err := resp.Location()
+	if err != nil {
+		return "", err
+	}
+	return loc.String(), nil
+}
+
+func matchHost(list []string, host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return false
+	}
+	for _, h := range list {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeHosts(hosts []string) []string {
+	result := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.TrimSpace(h)
+		if strings.Contains(h, "://") {
+			h = HostOf(h)
+		}
+		h = strings.TrimSuffix(strings.ToLower(h), ".")
+		if host, _, err := net.SplitHostPort(h); err == nil {
+			h = host
+		}
+		if h != "" {
+			result = append(result, h)
+		}
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package linkguard_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/linkguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck_SelfReference(t *testing.T) {
+	g := linkguard.New([]string{"http://localhost:8080", "sho.rt"}, nil, false)
+
+	for _, raw := range []string{
+		"http://localhost:8080/abc",
+		"https://LOCALHOST/abc",
+		"https://sho.rt/x",
+		"https://www.sho.rt/x",
+	} {
+		_, err := g.Check(context.Background(), raw)
+		assert.ErrorIs(t, err, linkguard.ErrSelfReference, raw)
+	}
+
+	got, err := g.Check(context.Background(), "https://example.com/page")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/page", got)
+}
+
+func TestCheck_ShortenerWithoutUnwrap(t *testing.T) {
+	g := linkguard.New([]string{"sho.rt"}, linkguard.DefaultShorteners, false)
+
+	_, err := g.Check(context.Background(), "https://bit.ly/abc")
+	assert.ErrorIs(t, err, linkguard.ErrShortenerChain)
+}
+
+func TestCheck_UnwrapChain(t *testing.T) {
+	final := "https://example.com/final"
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hop1":
+			http.Redirect(w, r, srv.URL+"/hop2", http.StatusMovedPermanently)
+		case "/hop2":
+			http.Redirect(w, r, final, http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, srv.URL+"/loop", http.StatusFound)
+		case "/home":
+			http.Redirect(w, r, "https://sho.rt/abc", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer srv.Close()
+
+	// Тестовый сервер выступает в роли стороннего сокращателя.
+	g := linkguard.New([]string{"sho.rt"}, []string{srv.URL}, true)
+
+	got, err := g.Check(context.Background(), srv.URL+"/hop1")
+	require.NoError(t, err)
+	assert.Equal(t, final, got)
+
+	_, err = g.Check(context.Background(), srv.URL+"/loop")
+	assert.ErrorIs(t, err, linkguard.ErrRedirectLoop)
+
+	_, err = g.Check(context.Background(), srv.URL+"/home")
+	assert.ErrorIs(t, err, linkguard.ErrSelfReference)
+
+	_, err = g.Check(context.Background(), srv.URL+"/landing")
+	assert.ErrorIs(t, err, linkguard.ErrShortenerChain)
+}
@@ -0,0 +1,285 @@
+// Package metadata получает заголовок, описание и теги Open Graph страницы
+// назначения. Загрузка выполняется фоновыми воркерами с повторными попытками.
+package metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/netguard"
+	"go.uber.org/zap"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	// maxFieldLen ограничивает длину сохраняемых значений в символах.
+	maxFieldLen = 512
+	// maxOpenGraph ограничивает количество сохраняемых свойств og:*.
+	maxOpenGraph = 32
+)
+
+// ErrNotHTML — страница назначения не является HTML-документом.
+var ErrNotHTML = errors.New("destination is not an HTML page")
+
+// StatusError — страница назначения ответила неуспешным статусом.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.Code)
+}
+
+// Retryable сообщает, имеет ли смысл повторить загрузку после ошибки.
+// Повторяются сетевые ошибки, 429 и ответы 5xx; запрет подключения
+// к внутренним адресам и не-HTML ответы считаются окончательными.
+func Retryable(err error) bool {
+	if err == nil || errors.Is(err, ErrNotHTML) || errors.Is(err, netguard.ErrPrivateAddress) {
+		return false
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code == http.StatusTooManyRequests || statusErr.Code >= 500
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Fetcher загружает и разбирает страницу назначения.
+type Fetcher struct {
+	Client *http.Client
+	// MaxBytes ограничивает объём читаемого документа.
+	MaxBytes  int64
+	UserAgent string
+}
+
+// NewFetcher создаёт Fetcher с защитой от SSRF и ограничениями по умолчанию.
+func NewFetcher() *Fetcher {
+	return &Fetcher{
+		Client:    netguard.NewClient(5*time.Second, false),
+		MaxBytes:  512 << 10,
+		UserAgent: "URLShortener-Metadata/1.0",
+	}
+}
+
+// Fetch загружает страницу и извлекает из неё метаданные.
+func (f *Fetcher) Fetch(ctx context.Context, raw string) (model.LinkMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, raw, nil)
+	if err != nil {
+		return model.LinkMetadata{}, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	if f.UserAgent != "" {
+		req.Header.Set("User-Agent", f.UserAgent)
+	}
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return model.LinkMetadata{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.LinkMetadata{}, &StatusError{Code: resp.StatusCode}
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); contentType != "" &&
+		mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return model.LinkMetadata{}, ErrNotHTML
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, f.MaxBytes), contentType)
+	if err != nil {
+		return model.LinkMetadata{}, err
+	}
+	meta := Parse(body)
+	meta.FetchedAt = time.Now()
+	return meta, nil
+}
+
+// Parse извлекает метаданные из заголовка HTML-документа.
+// Разбор прекращается на <body> или по окончании данных.
+func Parse(r io.Reader) model.LinkMetadata {
+	var meta model.LinkMetadata
+	z := html.NewTokenizer(r)
+	inTitle := false
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return meta
+		case html.TextToken:
+			if inTitle && meta.Title == "" {
+				meta.Title = clean(string(z.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				return meta
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return meta
+			case "title":
+				inTitle = true
+			case "meta":
+				if hasAttr {
+					applyMeta(&meta, attrs(z))
+				}
+			}
+		}
+	}
+}
+
+func applyMeta(meta *model.LinkMetadata, a map[string]string) {
+	content := clean(a["content"])
+	if content == "" {
+		return
+	}
+	if strings.EqualFold(a["name"], "description") {
+		meta.Description = content
+		return
+	}
+	// Часть сайтов указывает og-теги в name вместо property
+	prop := a["property"]
+	if prop == "" {
+		prop = a["name"]
+	}
+	key, ok := strings.CutPrefix(strings.ToLower(prop), "og:")
+	if !ok || key == "" {
+		return
+	}
+	if meta.OpenGraph == nil {
+		meta.OpenGraph = make(map[string]string)
+	}
+	if _, exists := meta.OpenGraph[key]; !exists && len(meta.OpenGraph) < maxOpenGraph {
+		meta.OpenGraph[key] = content
+	}
+}
+
+func attrs(z *html.Tokenizer) map[string]string {
+	result := make(map[string]string)
+	for {
+		key, val, more := z.TagAttr()
+		result[strings.ToLower(string(key))] = string(val)
+		if !more {
+			return result
+		}
+	}
+}
+
+// clean схлопывает пробельные символы и обрезает значение до maxFieldLen символов.
+func clean(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= maxFieldLen {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:maxFieldLen])
+}
+
+// SaveFunc сохраняет метаданные ссылки, загруженные по адресу url. Если адрес
+// назначения ссылки с тех пор изменился, метаданные не сохраняются.
+type SaveFunc func(ctx context.Context, short, url string, meta model.LinkMetadata) error
+
+type job struct {
+	short string
+	url   string
+}
+
+// Worker загружает метаданные в фоне. Задания, не поместившиеся в очередь,
+// отбрасываются: метаданные необязательны и не должны замедлять сокращение.
+type Worker struct {
+	Fetcher *Fetcher
+	// Attempts — максимальное количество попыток загрузки.
+	Attempts int
+	// Backoff — задержка перед второй попыткой, далее удваивается.
+	Backoff time.Duration
+
+	save   SaveFunc
+	logger *zap.Logger
+	jobs   chan job
+}
+
+// NewWorker создаёт Worker с очередью заданного размера.
+func NewWorker(fetcher *Fetcher, queueSize int, save SaveFunc, logger *zap.Logger) *Worker {
+	return &Worker{
+		Fetcher:  fetcher,
+		Attempts: 3,
+		Backoff:  2 * time.Second,
+		save:     save,
+		logger:   logger,
+		jobs:     make(chan job, queueSize),
+	}
+}
+
+// Enqueue ставит ссылку в очередь. Возвращает false, если очередь заполнена.
+func (w *Worker) Enqueue(short, url string) bool {
+	select {
+	case w.jobs <- job{short: short, url: url}:
+		return true
+	default:
+		w.logger.Warn("Metadata queue is full, job dropped", zap.String("short", short))
+		return false
+	}
+}
+
+// Run запускает n обработчиков очереди и блокируется до отмены ctx.
+func (w *Worker) Run(ctx context.Context, n int) {
+	done := make(chan struct{})
+	for i := 0; i < n; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-w.jobs:
+					w.process(ctx, j)
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		<-done
+	}
+}
+
+func (w *Worker) process(ctx context.Context, j job) {
+	delay := w.Backoff
+	for attempt := 1; ; attempt++ {
+		meta, err := w.Fetcher.Fetch(ctx, j.url)
+		if err == nil {
+			if err := w.save(ctx, j.short, j.url, meta); err != nil {
+				w.logger.Error("Failed to save metadata", zap.String("short", j.short), zap.Error(err))
+			}
+			return
+		}
+		if !Retryable(err) || attempt >= w.Attempts {
+			w.logger.Info("Metadata fetch failed", zap.String("short", j.short), zap.Int("attempt", attempt), zap.Error(err))
+			return
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
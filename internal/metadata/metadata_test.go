@@ -0,0 +1,142 @@
+package metadata_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/metadata"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/netguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const page = `<!doctype html>
+<html><head>
+  <title>
+    Example   Domain
+  </title>
+  <meta name="description" content="Illustrative example">
+  <meta property="og:title" content="Example OG">
+  <meta property="og:image" content="https://example.com/cover.png">
+  <meta name="og:site_name" content="Example Site">
+</head>
+<body><meta property="og:title" content="ignored"></body></html>`
+
+func newTestFetcher() *metadata.Fetcher {
+	f := metadata.NewFetcher()
+	f.Client = netguard.NewClient(2*time.Second, true)
+	return f
+}
+
+func TestParse(t *testing.T) {
+	meta := metadata.Parse(strings.NewReader(page))
+	assert.Equal(t, "Example Domain", meta.Title)
+	assert.Equal(t, "Illustrative example", meta.Description)
+	assert.Equal(t, map[string]string{
+		"title":     "Example OG",
+		"image":     "https://example.com/cover.png",
+		"site_name": "Example Site",
+	}, meta.OpenGraph)
+}
+
+func TestFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=windows-1251")
+		// "Привет" в windows-1251
+		w.Write([]byte("<html><head><title>\xcf\xf0\xe8\xe2\xe5\xf2</title></head></html>"))
+	}))
+	defer srv.Close()
+
+	meta, err := newTestFetcher().Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Привет", meta.Title)
+	assert.False(t, meta.FetchedAt.IsZero())
+}
+
+func TestFetch_SizeLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><head><title>Short</title>" + strings.Repeat("<!-- padding -->", 100) +
+			`<meta name="description" content="too far"></head></html>`))
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher()
+	f.MaxBytes = 256
+	meta, err := f.Fetch(context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "Short", meta.Title)
+	assert.Empty(t, meta.Description)
+}
+
+func TestFetch_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+		case "/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	f := newTestFetcher()
+
+	_, err := f.Fetch(context.Background(), srv.URL+"/image")
+	assert.ErrorIs(t, err, metadata.ErrNotHTML)
+	assert.False(t, metadata.Retryable(err))
+
+	_, err = f.Fetch(context.Background(), srv.URL+"/down")
+	assert.True(t, metadata.Retryable(err))
+
+	_, err = f.Fetch(context.Background(), srv.URL+"/missing")
+	assert.False(t, metadata.Retryable(err))
+
+	_, err = metadata.NewFetcher().Fetch(context.Background(), srv.URL)
+	assert.ErrorIs(t, err, netguard.ErrPrivateAddress)
+	assert.False(t, metadata.Retryable(err))
+}
+
+func TestWorker_RetriesTransientFailures(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(page))
+	}))
+	defer srv.Close()
+
+	saved := make(chan model.LinkMetadata, 1)
+	worker := metadata.NewWorker(newTestFetcher(), 10, func(ctx context.Context, short, url string, meta model.LinkMetadata) error {
+		assert.Equal(t, "abc123", short)
+		assert.Equal(t, srv.URL, url)
+		saved <- meta
+		return nil
+	}, zap.NewNop())
+	worker.Backoff = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go worker.Run(ctx, 1)
+
+	require.True(t, worker.Enqueue("abc123", srv.URL))
+	select {
+	case meta := <-saved:
+		assert.Equal(t, "Example Domain", meta.Title)
+	case <-time.After(3 * time.Second):
+		t.Fatal("metadata was not saved")
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
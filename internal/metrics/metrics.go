@@ -0,0 +1,127 @@
+// Package metrics собирает метрики сервиса в формате Prometheus:
+// HTTP- и gRPC-запросы, запросы к базе данных, состояние кеша,
+// очередей и пула соединений.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace — общий префикс имён метрик.
+const Namespace = "shortener"
+
+// Metrics хранит собственный реестр и метрики сервиса.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	grpcRequests  *prometheus.CounterVec
+	grpcDuration  *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+	queryErrors   *prometheus.CounterVec
+}
+
+// New создаёт реестр с метриками сервиса, среды выполнения Go и процесса.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "http_requests_total",
+			Help:      "Количество HTTP-запросов по маршруту и статусу ответа.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Длительность обработки HTTP-запросов.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		grpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "grpc_requests_total",
+			Help:      "Количество gRPC-вызовов по методу и коду ответа.",
+		}, []string{"method", "code"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Длительность обработки gRPC-вызовов.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Длительность запросов к базе данных.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"query"}),
+		queryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "db_query_errors_total",
+			Help:      "Количество запросов к базе данных, завершившихся ошибкой.",
+		}, []string{"query"}),
+	}
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests, m.httpDuration,
+		m.grpcRequests, m.grpcDuration,
+		m.queryDuration, m.queryErrors,
+	)
+	return m
+}
+
+// Handler возвращает HTTP-обработчик, отдающий метрики в текстовом формате Prometheus.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Register добавляет в реестр дополнительные коллекторы.
+func (m *Metrics) Register(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+// ObserveHTTP учитывает обработанный HTTP-запрос. route — шаблон маршрута,
+// а не фактический путь, чтобы число временных рядов не зависело от ссылок.
+func (m *Metrics) ObserveHTTP(method, route string, status int, d time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(method, route).Observe(d.Seconds())
+}
+
+// GaugeFunc регистрирует показатель, значение которого вычисляется при сборе метрик.
+func (m *Metrics) GaugeFunc(name, help string, fn func() float64) {
+	m.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// CounterFunc регистрирует счётчик, значение которого вычисляется при сборе метрик.
+func (m *Metrics) CounterFunc(name, help string, fn func() float64) {
+	m.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      name,
+		Help:      help,
+	}, fn))
+}
+
+// RegisterCache регистрирует метрики кеша по функциям статистики.
+func (m *Metrics) RegisterCache(name string, stats func() (hits, misses uint64), size func() int) {
+	m.CounterFunc(name+"_cache_hits_total", "Количество попаданий в кеш.", func() float64 {
+		hits, _ := stats()
+		return float64(hits)
+	})
+	m.CounterFunc(name+"_cache_misses_total", "Количество промахов кеша.", func() float64 {
+		_, misses := stats()
+		return float64(misses)
+	})
+	m.GaugeFunc(name+"_cache_entries", "Количество записей в кеше.", func() float64 {
+		return float64(size())
+	})
+}
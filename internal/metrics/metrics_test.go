@@ -0,0 +1,79 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/metrics"
+	"github.com/Totarae/URLShortener/internal/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := io.ReadAll(w.Result().Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHTTPMetricsUseRoutePattern(t *testing.T) {
+	m := metrics.New()
+	r := chi.NewRouter()
+	r.Use(middleware.LoggingMiddleware(zap.NewNop(), m))
+	r.Get("/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+
+	for _, path := range []string{"/abc", "/def", "/a/b"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `shortener_http_requests_total{method="GET",route="/{id}",status="307"} 2`)
+	assert.Contains(t, out, `shortener_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, out, `shortener_http_request_duration_seconds_count{method="GET",route="/{id}"} 2`)
+	assert.NotContains(t, out, "abc")
+}
+
+func TestGRPCInterceptor(t *testing.T) {
+	m := metrics.New()
+	interceptor := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/shortener.v2.ShortenerService/Resolve"}
+
+	_, err := interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	assert.Error(t, err)
+	_, err = interceptor(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+
+	out := scrape(t, m)
+	assert.Contains(t, out, `shortener_grpc_requests_total{code="NotFound",method="/shortener.v2.ShortenerService/Resolve"} 1`)
+	assert.Contains(t, out, `shortener_grpc_requests_total{code="OK",method="/shortener.v2.ShortenerService/Resolve"} 1`)
+}
+
+func TestFuncMetrics(t *testing.T) {
+	m := metrics.New()
+	m.RegisterCache("resolve", func() (uint64, uint64) { return 7, 3 }, func() int { return 5 })
+	m.GaugeFunc("pending_deletions", "test", func() float64 { return 2 })
+
+	out := scrape(t, m)
+	assert.Contains(t, out, "shortener_resolve_cache_hits_total 7")
+	assert.Contains(t, out, "shortener_resolve_cache_misses_total 3")
+	assert.Contains(t, out, "shortener_resolve_cache_entries 5")
+	assert.Contains(t, out, "shortener_pending_deletions 2")
+	assert.Contains(t, out, "go_goroutines")
+}
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	name  string
+	start time.Time
+}
+
+// QueryTracer измеряет длительность запросов pgx. Подключается через
+// pgx.ConnConfig.Tracer и не требует изменений в репозитории.
+type QueryTracer struct {
+	m *Metrics
+}
+
+// QueryTracer возвращает трассировщик запросов, пишущий в эти метрики.
+func (m *Metrics) QueryTracer() *QueryTracer {
+	return &QueryTracer{m: m}
+}
+
+// TraceQueryStart запоминает имя и время начала запроса.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: database.QueryName(data.SQL), start: time.Now()})
+}
+
+// TraceQueryEnd учитывает завершённый запрос.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.observe(ctx, data.Err)
+}
+
+// TraceCopyFromStart запоминает время начала COPY.
+func (t *QueryTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: database.CopyName(data.TableName), start: time.Now()})
+}
+
+// TraceCopyFromEnd учитывает завершённый COPY.
+func (t *QueryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	t.observe(ctx, data.Err)
+}
+
+func (t *QueryTracer) observe(ctx context.Context, err error) {
+	qs, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	t.m.queryDuration.WithLabelValues(qs.name).Observe(time.Since(qs.start).Seconds())
+	if err != nil {
+		t.m.queryErrors.WithLabelValues(qs.name).Inc()
+	}
+}
+
+// poolCollector публикует статистику пула соединений pgxpool.
+type poolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquired, idle, constructing, total, max *prometheus.Desc
+	acquireCount, acquireDuration            *prometheus.Desc
+	emptyAcquire, canceledAcquire            *prometheus.Desc
+}
+
+// NewPoolCollector создаёт коллектор статистики пула соединений.
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(Namespace, "db_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		stat:            pool.Stat,
+		acquired:        desc("acquired_conns", "Соединения, занятые запросами."),
+		idle:            desc("idle_conns", "Свободные соединения."),
+		constructing:    desc("constructing_conns", "Соединения в процессе установки."),
+		total:           desc("total_conns", "Все соединения пула."),
+		max:             desc("max_conns", "Максимальный размер пула."),
+		acquireCount:    desc("acquires_total", "Количество успешных получений соединения."),
+		acquireDuration: desc("acquire_duration_seconds_total", "Суммарное время ожидания соединения."),
+		emptyAcquire:    desc("empty_acquires_total", "Получения соединения, которым пришлось ждать."),
+		canceledAcquire: desc("canceled_acquires_total", "Получения соединения, отменённые контекстом."),
+	}
+}
+
+// Describe реализует prometheus.Collector.
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.acquired, c.idle, c.constructing, c.total, c.max,
+		c.acquireCount, c.acquireDuration, c.emptyAcquire, c.canceledAcquire,
+	} {
+		ch <- d
+	}
+}
+
+// Collect реализует prometheus.Collector.
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructing, prometheus.GaugeValue, float64(s.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquire, prometheus.CounterValue, float64(s.CanceledAcquireCount()))
+}
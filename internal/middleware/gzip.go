@@ -32,8 +32,10 @@ func GzipMiddleware(next http.Handler) http.Handler {
 			r.Body = reader
 		}
 
-		// Проверяем, поддерживает ли клиент gzip-ответ
-		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		// Проверяем, поддерживает ли клиент gzip-ответ. Поток событий не сжимаем:
+		// gzip буферизует данные и задерживает доставку событий.
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") ||
+			strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
 			next.ServeHTTP(w, r)
 			return
 		}
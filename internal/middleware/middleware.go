@@ -4,9 +4,15 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
+// RequestObserver получает сведения о каждом обработанном запросе, например для метрик.
+type RequestObserver interface {
+	ObserveHTTP(method, route string, status int, d time.Duration)
+}
+
 // loggingResponseWriter оборачивает http.ResponseWriter для отслеживания
 // статуса ответа и количества переданных байт.
 type loggingResponseWriter struct {
@@ -16,8 +22,9 @@ type loggingResponseWriter struct {
 }
 
 // LoggingMiddleware создаёт middleware для логирования HTTP-запросов.
-// Логирует метод, URI, статус ответа, размер и длительность обработки.
-func LoggingMiddleware(loggrt *zap.Logger) func(handler http.Handler) http.Handler {
+// Логирует метод, URI, статус ответа, размер и длительность обработки
+// и передаёт их наблюдателям вместе с шаблоном маршрута chi.
+func LoggingMiddleware(loggrt *zap.Logger, observers ...RequestObserver) func(handler http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(resp http.ResponseWriter, r *http.Request) {
 			start := time.Now()
@@ -34,10 +41,27 @@ func LoggingMiddleware(loggrt *zap.Logger) func(handler http.Handler) http.Handl
 				zap.Int("size", lw.size),
 				zap.Duration("duration", duration),
 			)
+			if len(observers) > 0 {
+				route := RoutePattern(r)
+				for _, o := range observers {
+					o.ObserveHTTP(r.Method, route, lw.statusCode, duration)
+				}
+			}
 		})
 	}
 }
 
+// RoutePattern возвращает шаблон сработавшего маршрута chi ("/api/user/urls/{id}")
+// или "unmatched", если маршрут не найден.
+func RoutePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return "unmatched"
+}
+
 // WriteHeader сохраняет статус ответа и вызывает оригинальный WriteHeader.
 func (lw *loggingResponseWriter) WriteHeader(code int) {
 	lw.statusCode = code
@@ -50,3 +74,9 @@ func (lw *loggingResponseWriter) Write(b []byte) (int, error) {
 	lw.size += size
 	return size, err
 }
+
+// Unwrap возвращает исходный ResponseWriter, чтобы http.ResponseController
+// мог сбрасывать потоковые ответы (SSE) через обёртку.
+func (lw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lw.ResponseWriter
+}
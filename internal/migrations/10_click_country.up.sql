@@ -0,0 +1 @@
+ALTER TABLE clicks ADD COLUMN country TEXT;
@@ -0,0 +1,6 @@
+CREATE TABLE click_sketches (
+    shorten TEXT NOT NULL,
+    day DATE NOT NULL,
+    sketch BYTEA NOT NULL,
+    PRIMARY KEY (shorten, day)
+);
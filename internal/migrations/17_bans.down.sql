@@ -0,0 +1,2 @@
+DROP INDEX urls_created_idx;
+DROP TABLE bans;
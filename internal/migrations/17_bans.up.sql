@@ -0,0 +1,7 @@
+CREATE TABLE bans (
+    user_id TEXT PRIMARY KEY,
+    reason TEXT NOT NULL DEFAULT '',
+    banned_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX urls_created_idx ON urls (created);
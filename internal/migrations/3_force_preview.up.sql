@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN force_preview BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,2 @@
+ALTER TABLE urls ADD COLUMN is_disabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE urls ADD COLUMN disabled_reason TEXT;
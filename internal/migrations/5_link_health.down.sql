@@ -0,0 +1,4 @@
+ALTER TABLE urls DROP COLUMN health_checked_at;
+ALTER TABLE urls DROP COLUMN health_error;
+ALTER TABLE urls DROP COLUMN health_latency_ms;
+ALTER TABLE urls DROP COLUMN health_status;
@@ -0,0 +1,4 @@
+ALTER TABLE urls ADD COLUMN health_status INTEGER;
+ALTER TABLE urls ADD COLUMN health_latency_ms INTEGER;
+ALTER TABLE urls ADD COLUMN health_error TEXT;
+ALTER TABLE urls ADD COLUMN health_checked_at TIMESTAMP;
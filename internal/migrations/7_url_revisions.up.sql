@@ -0,0 +1,9 @@
+CREATE TABLE url_revisions (
+    id SERIAL PRIMARY KEY,
+    shorten TEXT NOT NULL,
+    changed_by TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    old_origin TEXT NOT NULL,
+    new_origin TEXT NOT NULL
+);
+CREATE INDEX url_revisions_shorten_idx ON url_revisions (shorten, changed_at);
@@ -0,0 +1 @@
+ALTER TABLE urls ADD COLUMN deleted_at TIMESTAMP;
@@ -0,0 +1,10 @@
+CREATE TABLE clicks (
+    id BIGSERIAL PRIMARY KEY,
+    shorten TEXT NOT NULL,
+    clicked_at TIMESTAMP NOT NULL,
+    referrer TEXT,
+    user_agent TEXT,
+    ip_hash TEXT,
+    variant TEXT
+);
+CREATE INDEX clicks_shorten_clicked_at_idx ON clicks (shorten, clicked_at);
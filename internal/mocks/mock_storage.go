@@ -11,6 +11,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/Totarae/URLShortener/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -40,6 +41,20 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// ActiveEntries mocks base method.
+func (m *MockStorage) ActiveEntries() []model.Entry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveEntries")
+	ret0, _ := ret[0].([]model.Entry)
+	return ret0
+}
+
+// ActiveEntries indicates an expected call of ActiveEntries.
+func (mr *MockStorageMockRecorder) ActiveEntries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveEntries", reflect.TypeOf((*MockStorage)(nil).ActiveEntries))
+}
+
 // AppendToFile mocks base method.
 func (m *MockStorage) AppendToFile(entry model.Entry) error {
 	m.ctrl.T.Helper()
@@ -54,6 +69,49 @@ func (mr *MockStorageMockRecorder) AppendToFile(entry any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendToFile", reflect.TypeOf((*MockStorage)(nil).AppendToFile), entry)
 }
 
+// DisableWhere mocks base method.
+func (m *MockStorage) DisableWhere(match func(model.Entry) bool, reason string) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableWhere", match, reason)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// DisableWhere indicates an expected call of DisableWhere.
+func (mr *MockStorageMockRecorder) DisableWhere(match, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableWhere", reflect.TypeOf((*MockStorage)(nil).DisableWhere), match, reason)
+}
+
+// Entries mocks base method.
+func (m *MockStorage) Entries() []model.Entry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Entries")
+	ret0, _ := ret[0].([]model.Entry)
+	return ret0
+}
+
+// Entries indicates an expected call of Entries.
+func (mr *MockStorageMockRecorder) Entries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockStorage)(nil).Entries))
+}
+
+// FindByOrigin mocks base method.
+func (m *MockStorage) FindByOrigin(origin string) (model.Entry, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByOrigin", origin)
+	ret0, _ := ret[0].(model.Entry)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// FindByOrigin indicates an expected call of FindByOrigin.
+func (mr *MockStorageMockRecorder) FindByOrigin(origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrigin", reflect.TypeOf((*MockStorage)(nil).FindByOrigin), origin)
+}
+
 // Get mocks base method.
 func (m *MockStorage) Get(short string) (string, bool) {
 	m.ctrl.T.Helper()
@@ -83,6 +141,35 @@ func (mr *MockStorageMockRecorder) GetByUser(userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUser", reflect.TypeOf((*MockStorage)(nil).GetByUser), userID)
 }
 
+// GetEntriesByUser mocks base method.
+func (m *MockStorage) GetEntriesByUser(userID string) []model.Entry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntriesByUser", userID)
+	ret0, _ := ret[0].([]model.Entry)
+	return ret0
+}
+
+// GetEntriesByUser indicates an expected call of GetEntriesByUser.
+func (mr *MockStorageMockRecorder) GetEntriesByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntriesByUser", reflect.TypeOf((*MockStorage)(nil).GetEntriesByUser), userID)
+}
+
+// GetEntry mocks base method.
+func (m *MockStorage) GetEntry(short string) (model.Entry, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", short)
+	ret0, _ := ret[0].(model.Entry)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStorageMockRecorder) GetEntry(short any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStorage)(nil).GetEntry), short)
+}
+
 // MarkDeleted mocks base method.
 func (m *MockStorage) MarkDeleted(shortenIDs []string, userID string) {
 	m.ctrl.T.Helper()
@@ -95,6 +182,34 @@ func (mr *MockStorageMockRecorder) MarkDeleted(shortenIDs, userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDeleted", reflect.TypeOf((*MockStorage)(nil).MarkDeleted), shortenIDs, userID)
 }
 
+// Reassign mocks base method.
+func (m *MockStorage) Reassign(from, to string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reassign", from, to)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Reassign indicates an expected call of Reassign.
+func (mr *MockStorageMockRecorder) Reassign(from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reassign", reflect.TypeOf((*MockStorage)(nil).Reassign), from, to)
+}
+
+// Restore mocks base method.
+func (m *MockStorage) Restore(shortenIDs []string, userID string, since time.Time) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", shortenIDs, userID, since)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockStorageMockRecorder) Restore(shortenIDs, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockStorage)(nil).Restore), shortenIDs, userID, since)
+}
+
 // Save mocks base method.
 func (m *MockStorage) Save(short, original, userID string) {
 	m.ctrl.T.Helper()
@@ -106,3 +221,75 @@ func (mr *MockStorageMockRecorder) Save(short, original, userID any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockStorage)(nil).Save), short, original, userID)
 }
+
+// SetForcePreview mocks base method.
+func (m *MockStorage) SetForcePreview(short, userID string, enabled bool) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetForcePreview", short, userID, enabled)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SetForcePreview indicates an expected call of SetForcePreview.
+func (mr *MockStorageMockRecorder) SetForcePreview(short, userID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForcePreview", reflect.TypeOf((*MockStorage)(nil).SetForcePreview), short, userID, enabled)
+}
+
+// SetHealth mocks base method.
+func (m *MockStorage) SetHealth(short string, health model.LinkHealth) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHealth", short, health)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SetHealth indicates an expected call of SetHealth.
+func (mr *MockStorageMockRecorder) SetHealth(short, health any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealth", reflect.TypeOf((*MockStorage)(nil).SetHealth), short, health)
+}
+
+// SetMetadata mocks base method.
+func (m *MockStorage) SetMetadata(short, url string, meta model.LinkMetadata) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMetadata", short, url, meta)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SetMetadata indicates an expected call of SetMetadata.
+func (mr *MockStorageMockRecorder) SetMetadata(short, url, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMetadata", reflect.TypeOf((*MockStorage)(nil).SetMetadata), short, url, meta)
+}
+
+// SetRules mocks base method.
+func (m *MockStorage) SetRules(short, userID string, rules []model.RedirectRule) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRules", short, userID, rules)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SetRules indicates an expected call of SetRules.
+func (mr *MockStorageMockRecorder) SetRules(short, userID, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRules", reflect.TypeOf((*MockStorage)(nil).SetRules), short, userID, rules)
+}
+
+// UpdateOrigin mocks base method.
+func (m *MockStorage) UpdateOrigin(short, userID, origin string) (model.Revision, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrigin", short, userID, origin)
+	ret0, _ := ret[0].(model.Revision)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateOrigin indicates an expected call of UpdateOrigin.
+func (mr *MockStorageMockRecorder) UpdateOrigin(short, userID, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrigin", reflect.TypeOf((*MockStorage)(nil).UpdateOrigin), short, userID, origin)
+}
@@ -12,7 +12,9 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	hll "github.com/Totarae/URLShortener/internal/hll"
 	model "github.com/Totarae/URLShortener/internal/model"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -41,6 +43,36 @@ func (m *MockURLRepositoryInterface) EXPECT() *MockURLRepositoryInterfaceMockRec
 	return m.recorder
 }
 
+// CountCreatedByDay mocks base method.
+func (m *MockURLRepositoryInterface) CountCreatedByDay(ctx context.Context, since time.Time) (map[time.Time]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCreatedByDay", ctx, since)
+	ret0, _ := ret[0].(map[time.Time]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCreatedByDay indicates an expected call of CountCreatedByDay.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CountCreatedByDay(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCreatedByDay", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CountCreatedByDay), ctx, since)
+}
+
+// CountLinks mocks base method.
+func (m *MockURLRepositoryInterface) CountLinks(ctx context.Context) (model.LinkCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLinks", ctx)
+	ret0, _ := ret[0].(model.LinkCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLinks indicates an expected call of CountLinks.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CountLinks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLinks", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CountLinks), ctx)
+}
+
 // CountURLs mocks base method.
 func (m *MockURLRepositoryInterface) CountURLs(ctx context.Context) (int, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +88,21 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) CountURLs(ctx any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountURLs", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CountURLs), ctx)
 }
 
+// CountUserLinks mocks base method.
+func (m *MockURLRepositoryInterface) CountUserLinks(ctx context.Context, userID string) (model.LinkCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserLinks", ctx, userID)
+	ret0, _ := ret[0].(model.LinkCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserLinks indicates an expected call of CountUserLinks.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CountUserLinks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserLinks", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CountUserLinks), ctx, userID)
+}
+
 // CountUsers mocks base method.
 func (m *MockURLRepositoryInterface) CountUsers(ctx context.Context) (int, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +118,270 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) CountUsers(ctx any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CountUsers), ctx)
 }
 
+// CreateAPIKey mocks base method.
+func (m *MockURLRepositoryInterface) CreateAPIKey(ctx context.Context, key model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CreateAPIKey(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CreateAPIKey), ctx, key)
+}
+
+// CreateAccount mocks base method.
+func (m *MockURLRepositoryInterface) CreateAccount(ctx context.Context, acc model.Account) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, acc)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CreateAccount(ctx, acc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CreateAccount), ctx, acc)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockURLRepositoryInterface) CreateOrganization(ctx context.Context, org model.Organization, owner model.Membership) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", ctx, org, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CreateOrganization(ctx, org, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CreateOrganization), ctx, org, owner)
+}
+
+// CreateSession mocks base method.
+func (m *MockURLRepositoryInterface) CreateSession(ctx context.Context, s model.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockURLRepositoryInterfaceMockRecorder) CreateSession(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockURLRepositoryInterface)(nil).CreateSession), ctx, s)
+}
+
+// DeleteBan mocks base method.
+func (m *MockURLRepositoryInterface) DeleteBan(ctx context.Context, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBan", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBan indicates an expected call of DeleteBan.
+func (mr *MockURLRepositoryInterfaceMockRecorder) DeleteBan(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBan", reflect.TypeOf((*MockURLRepositoryInterface)(nil).DeleteBan), ctx, userID)
+}
+
+// DeleteMembership mocks base method.
+func (m *MockURLRepositoryInterface) DeleteMembership(ctx context.Context, orgID, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMembership", ctx, orgID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMembership indicates an expected call of DeleteMembership.
+func (mr *MockURLRepositoryInterfaceMockRecorder) DeleteMembership(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMembership", reflect.TypeOf((*MockURLRepositoryInterface)(nil).DeleteMembership), ctx, orgID, userID)
+}
+
+// DeleteSession mocks base method.
+func (m *MockURLRepositoryInterface) DeleteSession(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockURLRepositoryInterfaceMockRecorder) DeleteSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockURLRepositoryInterface)(nil).DeleteSession), ctx, id)
+}
+
+// DisableURLs mocks base method.
+func (m *MockURLRepositoryInterface) DisableURLs(ctx context.Context, ids []string, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableURLs", ctx, ids, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableURLs indicates an expected call of DisableURLs.
+func (mr *MockURLRepositoryInterfaceMockRecorder) DisableURLs(ctx, ids, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableURLs", reflect.TypeOf((*MockURLRepositoryInterface)(nil).DisableURLs), ctx, ids, reason)
+}
+
+// ForEachActiveURL mocks base method.
+func (m *MockURLRepositoryInterface) ForEachActiveURL(ctx context.Context, fn func(*model.URLObject) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachActiveURL", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachActiveURL indicates an expected call of ForEachActiveURL.
+func (mr *MockURLRepositoryInterfaceMockRecorder) ForEachActiveURL(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachActiveURL", reflect.TypeOf((*MockURLRepositoryInterface)(nil).ForEachActiveURL), ctx, fn)
+}
+
+// GetAPIKey mocks base method.
+func (m *MockURLRepositoryInterface) GetAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKey", ctx, id)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKey indicates an expected call of GetAPIKey.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKey", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetAPIKey), ctx, id)
+}
+
+// GetAccountByEmail mocks base method.
+func (m *MockURLRepositoryInterface) GetAccountByEmail(ctx context.Context, email string) (*model.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountByEmail", ctx, email)
+	ret0, _ := ret[0].(*model.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountByEmail indicates an expected call of GetAccountByEmail.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetAccountByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountByEmail", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetAccountByEmail), ctx, email)
+}
+
+// GetBan mocks base method.
+func (m *MockURLRepositoryInterface) GetBan(ctx context.Context, userID string) (*model.Ban, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBan", ctx, userID)
+	ret0, _ := ret[0].(*model.Ban)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBan indicates an expected call of GetBan.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetBan(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBan", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetBan), ctx, userID)
+}
+
+// GetBrokenURLsByUserID mocks base method.
+func (m *MockURLRepositoryInterface) GetBrokenURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrokenURLsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*model.URLObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrokenURLsByUserID indicates an expected call of GetBrokenURLsByUserID.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetBrokenURLsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrokenURLsByUserID", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetBrokenURLsByUserID), ctx, userID)
+}
+
+// GetClickSummary mocks base method.
+func (m *MockURLRepositoryInterface) GetClickSummary(ctx context.Context, shorten string, from, to time.Time, interval string, includeBots bool) (model.ClickSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClickSummary", ctx, shorten, from, to, interval, includeBots)
+	ret0, _ := ret[0].(model.ClickSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClickSummary indicates an expected call of GetClickSummary.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetClickSummary(ctx, shorten, from, to, interval, includeBots any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClickSummary", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetClickSummary), ctx, shorten, from, to, interval, includeBots)
+}
+
+// GetDeletedURLsByUserID mocks base method.
+func (m *MockURLRepositoryInterface) GetDeletedURLsByUserID(ctx context.Context, userID string, since time.Time) ([]*model.URLObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedURLsByUserID", ctx, userID, since)
+	ret0, _ := ret[0].([]*model.URLObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedURLsByUserID indicates an expected call of GetDeletedURLsByUserID.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetDeletedURLsByUserID(ctx, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedURLsByUserID", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetDeletedURLsByUserID), ctx, userID, since)
+}
+
+// GetMembership mocks base method.
+func (m *MockURLRepositoryInterface) GetMembership(ctx context.Context, orgID, userID string) (*model.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembership", ctx, orgID, userID)
+	ret0, _ := ret[0].(*model.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembership indicates an expected call of GetMembership.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetMembership(ctx, orgID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembership", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetMembership), ctx, orgID, userID)
+}
+
+// GetRevisions mocks base method.
+func (m *MockURLRepositoryInterface) GetRevisions(ctx context.Context, shorten string) ([]model.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, shorten)
+	ret0, _ := ret[0].([]model.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetRevisions(ctx, shorten any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetRevisions), ctx, shorten)
+}
+
+// GetSession mocks base method.
+func (m *MockURLRepositoryInterface) GetSession(ctx context.Context, id string) (*model.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, id)
+	ret0, _ := ret[0].(*model.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetSession(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetSession), ctx, id)
+}
+
 // GetShortURLByOrigin mocks base method.
 func (m *MockURLRepositoryInterface) GetShortURLByOrigin(ctx context.Context, originalURL string) (string, error) {
 	m.ctrl.T.Helper()
@@ -86,6 +397,21 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) GetShortURLByOrigin(ctx, origi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortURLByOrigin", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetShortURLByOrigin), ctx, originalURL)
 }
 
+// GetSketches mocks base method.
+func (m *MockURLRepositoryInterface) GetSketches(ctx context.Context, shorten string, from, to time.Time) (map[time.Time]*hll.Sketch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSketches", ctx, shorten, from, to)
+	ret0, _ := ret[0].(map[time.Time]*hll.Sketch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSketches indicates an expected call of GetSketches.
+func (mr *MockURLRepositoryInterfaceMockRecorder) GetSketches(ctx, shorten, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSketches", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetSketches), ctx, shorten, from, to)
+}
+
 // GetStats mocks base method.
 func (m *MockURLRepositoryInterface) GetStats(ctx context.Context) (int, int, error) {
 	m.ctrl.T.Helper()
@@ -132,6 +458,51 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) GetURLsByUserID(ctx, userID an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURLsByUserID", reflect.TypeOf((*MockURLRepositoryInterface)(nil).GetURLsByUserID), ctx, userID)
 }
 
+// ListAPIKeys mocks base method.
+func (m *MockURLRepositoryInterface) ListAPIKeys(ctx context.Context, userID string) ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx, userID)
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockURLRepositoryInterfaceMockRecorder) ListAPIKeys(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockURLRepositoryInterface)(nil).ListAPIKeys), ctx, userID)
+}
+
+// ListMembers mocks base method.
+func (m *MockURLRepositoryInterface) ListMembers(ctx context.Context, orgID string) ([]model.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, orgID)
+	ret0, _ := ret[0].([]model.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockURLRepositoryInterfaceMockRecorder) ListMembers(ctx, orgID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*MockURLRepositoryInterface)(nil).ListMembers), ctx, orgID)
+}
+
+// ListWorkspaces mocks base method.
+func (m *MockURLRepositoryInterface) ListWorkspaces(ctx context.Context, userID string) ([]model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaces", ctx, userID)
+	ret0, _ := ret[0].([]model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaces indicates an expected call of ListWorkspaces.
+func (mr *MockURLRepositoryInterfaceMockRecorder) ListWorkspaces(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaces", reflect.TypeOf((*MockURLRepositoryInterface)(nil).ListWorkspaces), ctx, userID)
+}
+
 // MarkURLsAsDeleted mocks base method.
 func (m *MockURLRepositoryInterface) MarkURLsAsDeleted(ctx context.Context, ids []string, userID string) error {
 	m.ctrl.T.Helper()
@@ -160,6 +531,66 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) Ping(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockURLRepositoryInterface)(nil).Ping), ctx)
 }
 
+// ReassignUser mocks base method.
+func (m *MockURLRepositoryInterface) ReassignUser(ctx context.Context, from, to string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReassignUser", ctx, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReassignUser indicates an expected call of ReassignUser.
+func (mr *MockURLRepositoryInterfaceMockRecorder) ReassignUser(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReassignUser", reflect.TypeOf((*MockURLRepositoryInterface)(nil).ReassignUser), ctx, from, to)
+}
+
+// ResolveIdentity mocks base method.
+func (m *MockURLRepositoryInterface) ResolveIdentity(ctx context.Context, issuer, subject, newUserID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveIdentity", ctx, issuer, subject, newUserID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveIdentity indicates an expected call of ResolveIdentity.
+func (mr *MockURLRepositoryInterfaceMockRecorder) ResolveIdentity(ctx, issuer, subject, newUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveIdentity", reflect.TypeOf((*MockURLRepositoryInterface)(nil).ResolveIdentity), ctx, issuer, subject, newUserID)
+}
+
+// RestoreURLs mocks base method.
+func (m *MockURLRepositoryInterface) RestoreURLs(ctx context.Context, ids []string, userID string, since time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreURLs", ctx, ids, userID, since)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreURLs indicates an expected call of RestoreURLs.
+func (mr *MockURLRepositoryInterfaceMockRecorder) RestoreURLs(ctx, ids, userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreURLs", reflect.TypeOf((*MockURLRepositoryInterface)(nil).RestoreURLs), ctx, ids, userID, since)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockURLRepositoryInterface) RevokeAPIKey(ctx context.Context, userID, id string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, userID, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockURLRepositoryInterfaceMockRecorder) RevokeAPIKey(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockURLRepositoryInterface)(nil).RevokeAPIKey), ctx, userID, id)
+}
+
 // SaveBatchURLs mocks base method.
 func (m *MockURLRepositoryInterface) SaveBatchURLs(ctx context.Context, urlObjs []*model.URLObject) error {
 	m.ctrl.T.Helper()
@@ -174,6 +605,49 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) SaveBatchURLs(ctx, urlObjs any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBatchURLs", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SaveBatchURLs), ctx, urlObjs)
 }
 
+// SaveClicks mocks base method.
+func (m *MockURLRepositoryInterface) SaveClicks(ctx context.Context, clicks []model.Click) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveClicks", ctx, clicks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveClicks indicates an expected call of SaveClicks.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SaveClicks(ctx, clicks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveClicks", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SaveClicks), ctx, clicks)
+}
+
+// SaveHealth mocks base method.
+func (m *MockURLRepositoryInterface) SaveHealth(ctx context.Context, results map[string]model.LinkHealth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveHealth", ctx, results)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveHealth indicates an expected call of SaveHealth.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SaveHealth(ctx, results any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveHealth", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SaveHealth), ctx, results)
+}
+
+// SaveMetadata mocks base method.
+func (m *MockURLRepositoryInterface) SaveMetadata(ctx context.Context, shorten, origin string, meta model.LinkMetadata) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMetadata", ctx, shorten, origin, meta)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveMetadata indicates an expected call of SaveMetadata.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SaveMetadata(ctx, shorten, origin, meta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMetadata", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SaveMetadata), ctx, shorten, origin, meta)
+}
+
 // SaveURL mocks base method.
 func (m *MockURLRepositoryInterface) SaveURL(ctx context.Context, urlObj *model.URLObject) error {
 	m.ctrl.T.Helper()
@@ -187,3 +661,107 @@ func (mr *MockURLRepositoryInterfaceMockRecorder) SaveURL(ctx, urlObj any) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveURL", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SaveURL), ctx, urlObj)
 }
+
+// SearchURLs mocks base method.
+func (m *MockURLRepositoryInterface) SearchURLs(ctx context.Context, q model.LinkSearch) ([]*model.URLObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchURLs", ctx, q)
+	ret0, _ := ret[0].([]*model.URLObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchURLs indicates an expected call of SearchURLs.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SearchURLs(ctx, q any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchURLs", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SearchURLs), ctx, q)
+}
+
+// SetBan mocks base method.
+func (m *MockURLRepositoryInterface) SetBan(ctx context.Context, ban model.Ban) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBan", ctx, ban)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBan indicates an expected call of SetBan.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SetBan(ctx, ban any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBan", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SetBan), ctx, ban)
+}
+
+// SetForcePreview mocks base method.
+func (m *MockURLRepositoryInterface) SetForcePreview(ctx context.Context, shorten, userID string, enabled bool) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetForcePreview", ctx, shorten, userID, enabled)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetForcePreview indicates an expected call of SetForcePreview.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SetForcePreview(ctx, shorten, userID, enabled any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetForcePreview", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SetForcePreview), ctx, shorten, userID, enabled)
+}
+
+// SetMembership mocks base method.
+func (m_2 *MockURLRepositoryInterface) SetMembership(ctx context.Context, m model.Membership) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SetMembership", ctx, m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMembership indicates an expected call of SetMembership.
+func (mr *MockURLRepositoryInterfaceMockRecorder) SetMembership(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMembership", reflect.TypeOf((*MockURLRepositoryInterface)(nil).SetMembership), ctx, m)
+}
+
+// TopClickedURLs mocks base method.
+func (m *MockURLRepositoryInterface) TopClickedURLs(ctx context.Context, since time.Time, limit int, includeBots bool) ([]model.TopLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopClickedURLs", ctx, since, limit, includeBots)
+	ret0, _ := ret[0].([]model.TopLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopClickedURLs indicates an expected call of TopClickedURLs.
+func (mr *MockURLRepositoryInterfaceMockRecorder) TopClickedURLs(ctx, since, limit, includeBots any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopClickedURLs", reflect.TypeOf((*MockURLRepositoryInterface)(nil).TopClickedURLs), ctx, since, limit, includeBots)
+}
+
+// UpdateOrigin mocks base method.
+func (m *MockURLRepositoryInterface) UpdateOrigin(ctx context.Context, shorten, userID, origin string) (model.Revision, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrigin", ctx, shorten, userID, origin)
+	ret0, _ := ret[0].(model.Revision)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateOrigin indicates an expected call of UpdateOrigin.
+func (mr *MockURLRepositoryInterfaceMockRecorder) UpdateOrigin(ctx, shorten, userID, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrigin", reflect.TypeOf((*MockURLRepositoryInterface)(nil).UpdateOrigin), ctx, shorten, userID, origin)
+}
+
+// UpdateRules mocks base method.
+func (m *MockURLRepositoryInterface) UpdateRules(ctx context.Context, shorten, userID string, rules []model.RedirectRule) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRules", ctx, shorten, userID, rules)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRules indicates an expected call of UpdateRules.
+func (mr *MockURLRepositoryInterfaceMockRecorder) UpdateRules(ctx, shorten, userID, rules any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRules", reflect.TypeOf((*MockURLRepositoryInterface)(nil).UpdateRules), ctx, shorten, userID, rules)
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Account — учётная запись пользователя. ID используется как идентификатор
+// владельца ссылок, так же как идентификатор из cookie.
+type Account struct {
+	ID           string    `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"password_hash"`
+	Created      time.Time `json:"created"`
+}
+
+// Session — серверная сессия. Хранится хеш токена, сам токен есть только в cookie.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Created   time.Time `json:"created"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
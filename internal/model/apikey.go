@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// APIKey — ключ API пользователя. Секрет ключа не хранится, только его хеш.
+type APIKey struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	Name      string     `json:"name"`
+	Hash      string     `json:"hash"`
+	Scopes    []string   `json:"scopes"`
+	Created   time.Time  `json:"created"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
@@ -23,4 +23,5 @@ type BatchResult struct {
 	CorrelationID string
 	ShortURL      string
 	OriginalURL   string
+	Metadata      *LinkMetadata
 }
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Click — событие перехода по короткой ссылке.
+type Click struct {
+	Time      time.Time `json:"time"`
+	Short     string    `json:"short"`
+	Referrer  string    `json:"referrer,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IPHash    string    `json:"ip_hash,omitempty"`
+	Variant   string    `json:"variant,omitempty"`
+	// Country — код страны ISO 3166-1 alpha-2 из заголовка прокси, если он есть.
+	Country string `json:"country,omitempty"`
+	// Bot — переход совершён ботом или сборщиком превью.
+	Bot bool `json:"bot,omitempty"`
+}
@@ -1,9 +1,20 @@
 package model
 
+import "time"
+
 // Entry представляет структуру записи URL в файле
 type Entry struct {
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
-	UserID      string `json:"user_id"`
-	IsDeleted   bool   `json:"is_deleted"`
+	ShortURL       string         `json:"short_url"`
+	OriginalURL    string         `json:"original_url"`
+	UserID         string         `json:"user_id"`
+	IsDeleted      bool           `json:"is_deleted"`
+	DeletedAt      *time.Time     `json:"deleted_at,omitempty"`
+	Created        time.Time      `json:"created,omitempty"`
+	Rules          []RedirectRule `json:"rules,omitempty"`
+	ForcePreview   bool           `json:"force_preview,omitempty"`
+	IsDisabled     bool           `json:"is_disabled,omitempty"`
+	DisabledReason string         `json:"disabled_reason,omitempty"`
+	Health         *LinkHealth    `json:"health,omitempty"`
+	Metadata       *LinkMetadata  `json:"metadata,omitempty"`
+	Revisions      []Revision     `json:"revisions,omitempty"`
 }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// LinkHealth — результат последней проверки доступности адреса назначения.
+type LinkHealth struct {
+	Status    int       `json:"status"` // 0, если ответ не получен
+	LatencyMs int64     `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// Broken сообщает, что адрес не ответил или ответил ошибкой.
+func (h LinkHealth) Broken() bool {
+	return h.Status == 0 || h.Status >= 400
+}
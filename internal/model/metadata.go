@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// LinkMetadata — сведения о странице назначения, собранные после создания ссылки.
+type LinkMetadata struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// OpenGraph содержит свойства og:* без префикса: title, description, image, site_name и т.д.
+	OpenGraph map[string]string `json:"open_graph,omitempty"`
+	FetchedAt time.Time         `json:"fetched_at"`
+}
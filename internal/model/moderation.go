@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Ban — запрет пользователю создавать ссылки.
+type Ban struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+	// By — администратор, выставивший запрет.
+	By      string    `json:"by"`
+	Created time.Time `json:"created"`
+}
+
+// LinkSearch — условия поиска ссылок всех пользователей.
+type LinkSearch struct {
+	// Domain — хост адреса назначения или его родительский домен.
+	Domain string
+	UserID string
+	// From и To ограничивают время создания: [From, To). Нулевое значение не ограничивает.
+	From time.Time
+	To   time.Time
+	// IncludeDeleted включает в результат удалённые ссылки.
+	IncludeDeleted bool
+	Limit          int
+	Offset         int
+}
+
+// UserActivity — сводка по пользователю для модерации.
+type UserActivity struct {
+	UserID   string `json:"user_id"`
+	Ban      *Ban   `json:"ban,omitempty"`
+	Links    int64  `json:"links"`
+	Active   int64  `json:"active"`
+	Deleted  int64  `json:"deleted"`
+	Disabled int64  `json:"disabled"`
+	// LastCreated — время создания последней ссылки.
+	LastCreated   *time.Time   `json:"last_created,omitempty"`
+	Recent        []*URLObject `json:"-"`
+	APIKeys       int          `json:"api_keys"`
+	Organizations []Workspace  `json:"organizations"`
+}
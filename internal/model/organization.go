@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// Organization — рабочее пространство, которому принадлежат общие ссылки.
+type Organization struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+// Membership — участие пользователя в организации с ролью owner, editor или viewer.
+type Membership struct {
+	OrgID   string    `json:"org_id"`
+	UserID  string    `json:"user_id"`
+	Role    string    `json:"role"`
+	Created time.Time `json:"created"`
+}
+
+// Workspace — организация с ролью в ней текущего пользователя.
+type Workspace struct {
+	Organization
+	Role string `json:"role"`
+}
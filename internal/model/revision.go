@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Revision — запись об изменении адреса назначения короткой ссылки.
+type Revision struct {
+	ChangedBy string    `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+	OldURL    string    `json:"old_url"`
+	NewURL    string    `json:"new_url"`
+}
@@ -0,0 +1,17 @@
+package model
+
+// RedirectRule описывает правило условного перенаправления короткой ссылки.
+// Правила проверяются по порядку, срабатывает первое подходящее.
+// Незаполненное условие считается выполненным.
+type RedirectRule struct {
+	Name      string   `json:"name,omitempty"`
+	Devices   []string `json:"devices,omitempty"`   // ios, android, desktop
+	Languages []string `json:"languages,omitempty"` // языковые теги: "ru", "en-US"
+	Weekdays  []string `json:"weekdays,omitempty"`  // mon, tue, ... sun
+	TimeFrom  string   `json:"time_from,omitempty"` // HH:MM включительно
+	TimeTo    string   `json:"time_to,omitempty"`   // HH:MM не включительно
+	DateFrom  string   `json:"date_from,omitempty"` // YYYY-MM-DD включительно
+	DateTo    string   `json:"date_to,omitempty"`   // YYYY-MM-DD включительно
+	Timezone  string   `json:"timezone,omitempty"`  // IANA-зона, по умолчанию UTC
+	Target    string   `json:"target"`
+}
@@ -0,0 +1,96 @@
+package model
+
+import "time"
+
+// ClickSummary — сырые агрегаты переходов по ссылке за период,
+// из которых собирается LinkStats.
+type ClickSummary struct {
+	// Buckets — количество переходов по началу интервала (UTC).
+	Buckets    map[time.Time]int64
+	Referrers  map[string]int64
+	UserAgents map[string]int64
+	Countries  map[string]int64
+	// Bots — переходы ботов за период, в том числе не вошедшие в сводку.
+	Bots int64
+}
+
+// NewClickSummary создаёт пустую сводку.
+func NewClickSummary() ClickSummary {
+	return ClickSummary{
+		Buckets:    map[time.Time]int64{},
+		Referrers:  map[string]int64{},
+		UserAgents: map[string]int64{},
+		Countries:  map[string]int64{},
+	}
+}
+
+// StatsBucket — количество переходов за один интервал.
+type StatsBucket struct {
+	Start  time.Time `json:"start"`
+	Clicks int64     `json:"clicks"`
+	// Visitors — оценка уникальных посетителей; заполняется только для интервала day.
+	Visitors int64 `json:"visitors,omitempty"`
+}
+
+// StatsEntry — значение измерения и количество переходов с ним.
+type StatsEntry struct {
+	Name   string `json:"name"`
+	Clicks int64  `json:"clicks"`
+}
+
+// LinkStats — статистика переходов по ссылке за период.
+type LinkStats struct {
+	Short    string    `json:"short_url"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Interval string    `json:"interval"`
+	// IncludeBots — учтены ли в статистике переходы ботов.
+	IncludeBots bool `json:"include_bots"`
+	// Bots — переходы ботов за период.
+	Bots  int64 `json:"bots"`
+	Total int64 `json:"total"`
+	// Visitors — оценка уникальных посетителей-людей за дни, пересекающиеся с периодом.
+	Visitors  int64         `json:"visitors"`
+	Buckets   []StatsBucket `json:"buckets"`
+	Referrers []StatsEntry  `json:"referrers"`
+	Browsers  []StatsEntry  `json:"browsers"`
+	OS        []StatsEntry  `json:"os"`
+	Countries []StatsEntry  `json:"countries"`
+}
+
+// LinkCounts — количество ссылок по состояниям и число их владельцев.
+type LinkCounts struct {
+	Total    int64 `json:"total"`
+	Active   int64 `json:"active"`
+	Deleted  int64 `json:"deleted"`
+	Disabled int64 `json:"disabled"`
+	Users    int64 `json:"users"`
+}
+
+// TopLink — ссылка и количество переходов по ней за период.
+type TopLink struct {
+	Short  string `json:"short_url"`
+	Origin string `json:"original_url"`
+	Clicks int64  `json:"clicks"`
+}
+
+// DayCount — количество созданных ссылок за день.
+type DayCount struct {
+	Day   time.Time `json:"day"`
+	Count int64     `json:"count"`
+}
+
+// ServiceStats — сводная статистика сервиса для внутреннего API.
+type ServiceStats struct {
+	// URLs — количество активных ссылок; поле сохранено для совместимости.
+	URLs int64 `json:"urls"`
+	LinkCounts
+	// Since — начало окна для CreatedPerDay и TopLinks.
+	Since time.Time `json:"since"`
+	// IncludeBots — учтены ли в TopLinks переходы ботов.
+	IncludeBots bool `json:"include_bots"`
+	// Visitors — оценка уникальных посетителей-людей всех ссылок с начала окна.
+	Visitors      int64      `json:"visitors"`
+	CreatedPerDay []DayCount `json:"created_per_day"`
+	TopLinks      []TopLink  `json:"top_links"`
+}
@@ -4,13 +4,23 @@ import "time"
 
 // URLObject представляет зпись по короткой ссылке.
 // Используется для хранения оригинального URL, сокращённого идентификатора,
-// времени создания, принадлежности пользователю и флага удаления.
+// времени создания, принадлежности пользователю, флага удаления,
+// правил условного перенаправления, признака обязательного предпросмотра,
+// отключения ссылки политикой сервиса, последней проверки доступности
+// и метаданных страницы назначения.
 type URLObject struct {
-	tableName struct{}  `pg:"urls"`
-	ID        uint      `pg:"id,notnull,pk"`
-	Origin    string    `pg:"origin,notnull"`
-	Shorten   string    `pg:"shorten,notnull,unique"`
-	Created   time.Time `pg:"created,default:now()"`
-	UserID    string    `pg:"user_id"`
-	IsDeleted bool      `pg:"is_deleted,default:false"`
+	tableName      struct{}       `pg:"urls"`
+	ID             uint           `pg:"id,notnull,pk"`
+	Origin         string         `pg:"origin,notnull"`
+	Shorten        string         `pg:"shorten,notnull,unique"`
+	Created        time.Time      `pg:"created,default:now()"`
+	UserID         string         `pg:"user_id"`
+	IsDeleted      bool           `pg:"is_deleted,default:false"`
+	DeletedAt      *time.Time     `pg:"deleted_at"`
+	Rules          []RedirectRule `pg:"rules"`
+	ForcePreview   bool           `pg:"force_preview,default:false"`
+	IsDisabled     bool           `pg:"is_disabled,default:false"`
+	DisabledReason string         `pg:"disabled_reason"`
+	Health         *LinkHealth    `pg:"-"` // nil, если ссылка ещё не проверялась
+	Metadata       *LinkMetadata  `pg:"metadata"`
 }
@@ -0,0 +1,130 @@
+// Package moderation хранит запреты пользователей и сопоставляет ссылки с доменами
+// для административного поиска.
+package moderation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Totarae/URLShortener/internal/model"
+)
+
+// ErrInvalidDomain — домен поиска содержит недопустимые символы.
+var ErrInvalidDomain = errors.New("invalid domain")
+
+// NormalizeDomain приводит домен поиска к нижнему регистру без точки в конце.
+// Допустимы только буквы, цифры, точки и дефисы, поэтому домен безопасно
+// подставлять в шаблон LIKE.
+func NormalizeDomain(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	if domain == "" {
+		return "", ErrInvalidDomain
+	}
+	for _, r := range domain {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-') {
+			return "", ErrInvalidDomain
+		}
+	}
+	return domain, nil
+}
+
+// MatchDomain сообщает, ведёт ли адрес на домен domain или его поддомен.
+// domain должен быть нормализован NormalizeDomain.
+func MatchDomain(rawURL, domain string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// FileStore хранит запреты в JSON-файле в режимах file и in-memory.
+// Пустой Path хранит их только в памяти.
+type FileStore struct {
+	Path string
+
+	mu     sync.Mutex
+	loaded bool
+	bans   map[string]model.Ban
+}
+
+// SetBan сохраняет запрет, заменяя прежний.
+func (f *FileStore) SetBan(ban model.Ban) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	f.bans[ban.UserID] = ban
+	return f.save()
+}
+
+// GetBan возвращает запрет пользователя.
+func (f *FileStore) GetBan(userID string) (model.Ban, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return model.Ban{}, false, err
+	}
+	ban, ok := f.bans[userID]
+	return ban, ok, nil
+}
+
+// DeleteBan снимает запрет. Возвращает false, если его не было.
+func (f *FileStore) DeleteBan(userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return false, err
+	}
+	if _, ok := f.bans[userID]; !ok {
+		return false, nil
+	}
+	delete(f.bans, userID)
+	return true, f.save()
+}
+
+// load читает файл при первом обращении; отсутствующий файл означает пустое хранилище.
+func (f *FileStore) load() error {
+	if f.loaded {
+		return nil
+	}
+	f.bans = make(map[string]model.Ban)
+	if f.Path != "" {
+		raw, err := os.ReadFile(f.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &f.bans); err != nil {
+				return err
+			}
+		}
+	}
+	f.loaded = true
+	return nil
+}
+
+// save атомарно переписывает файл.
+func (f *FileStore) save() error {
+	if f.Path == "" {
+		return nil
+	}
+	raw, err := json.Marshal(f.bans)
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
@@ -0,0 +1,49 @@
+package moderation_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchDomain(t *testing.T) {
+	domain, err := moderation.NormalizeDomain(" Example.COM. ")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", domain)
+
+	assert.True(t, moderation.MatchDomain("https://example.com/a", domain))
+	assert.True(t, moderation.MatchDomain("http://user@Cdn.Example.com:8080/x", domain))
+	assert.False(t, moderation.MatchDomain("https://notexample.com/", domain))
+	assert.False(t, moderation.MatchDomain("https://example.com.evil.net/", domain))
+
+	for _, bad := range []string{"", "exa%mple.com", "example_com", "a b"} {
+		_, err := moderation.NormalizeDomain(bad)
+		assert.ErrorIs(t, err, moderation.ErrInvalidDomain, bad)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	store := &moderation.FileStore{Path: path}
+	require.NoError(t, store.SetBan(model.Ban{UserID: "u1", Reason: "spam", By: "admin"}))
+
+	reopened := &moderation.FileStore{Path: path}
+	ban, found, err := reopened.GetBan("u1")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "spam", ban.Reason)
+
+	deleted, err := reopened.DeleteBan("u1")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	_, found, err = reopened.GetBan("u1")
+	require.NoError(t, err)
+	assert.False(t, found)
+	deleted, err = reopened.DeleteBan("u1")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
@@ -0,0 +1,59 @@
+// Package netguard защищает исходящие запросы сервиса от SSRF:
+// соединения с внутренними адресами запрещаются на этапе установки TCP-соединения,
+// уже после разрешения DNS-имени.
+package netguard
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress — адрес назначения находится во внутренней сети.
+var ErrPrivateAddress = errors.New("destination resolves to a private address")
+
+// sharedAddressSpace — диапазон CGNAT (RFC 6598), не покрытый net.IP.IsPrivate.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPrivate сообщает, относится ли адрес к внутренним: loopback, частные сети,
+// link-local, CGNAT, multicast и неуказанный адрес.
+func IsPrivate(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// NewDialer создаёт Dialer, отказывающийся подключаться к внутренним адресам,
+// если allowPrivate не установлен.
+func NewDialer(timeout time.Duration, allowPrivate bool) *net.Dialer {
+	d := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		d.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || IsPrivate(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+			return nil
+		}
+	}
+	return d
+}
+
+// NewClient создаёт HTTP-клиент с защитой от SSRF и общим таймаутом запроса.
+// Прокси из окружения не используется, чтобы проверка адреса не обходилась.
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	transport := &http.Transport{
+		DialContext:           NewDialer(timeout, allowPrivate).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,20 @@
+package netguard_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/netguard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPrivate(t *testing.T) {
+	private := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1"}
+	for _, addr := range private {
+		assert.True(t, netguard.IsPrivate(net.ParseIP(addr)), addr)
+	}
+	public := []string{"93.184.216.34", "8.8.8.8", "2606:4700:4700::1111"}
+	for _, addr := range public {
+		assert.False(t, netguard.IsPrivate(net.ParseIP(addr)), addr)
+	}
+}
@@ -0,0 +1,244 @@
+// Package orgs хранит организации и участие в них пользователей.
+//
+// Ссылки организации хранятся как ссылки пользователя с идентификатором Owner(orgID),
+// поэтому создание, список, удаление и корзина работают так же, как для личных ссылок.
+// Роли проверяет сервис: viewer видит ссылки, editor создаёт и удаляет их,
+// owner дополнительно управляет участниками.
+package orgs
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Totarae/URLShortener/internal/model"
+)
+
+// Роли участников в порядке возрастания прав.
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleOwner  = "owner"
+)
+
+// ownerPrefix отличает владельца-организацию от пользователей, чьи идентификаторы — UUID.
+const ownerPrefix = "org:"
+
+var rank = map[string]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// ValidRole сообщает, известна ли роль.
+func ValidRole(role string) bool {
+	return rank[role] > 0
+}
+
+// Allows сообщает, даёт ли роль role права роли required.
+func Allows(role, required string) bool {
+	return ValidRole(role) && rank[role] >= rank[required]
+}
+
+// Owner возвращает идентификатор владельца ссылок организации.
+func Owner(orgID string) string {
+	return ownerPrefix + orgID
+}
+
+// IsOwner сообщает, что идентификатор владельца ссылок — организация.
+func IsOwner(userID string) bool {
+	return strings.HasPrefix(userID, ownerPrefix)
+}
+
+// FileStore хранит организации в JSON-файле в режимах file и in-memory.
+// Пустой Path хранит их только в памяти.
+type FileStore struct {
+	Path string
+
+	mu     sync.Mutex
+	loaded bool
+	data   fileData
+}
+
+type fileData struct {
+	Organizations map[string]model.Organization `json:"organizations"`
+	Members       []model.Membership            `json:"members"`
+}
+
+// CreateOrganization сохраняет организацию вместе с её владельцем.
+func (f *FileStore) CreateOrganization(org model.Organization, owner model.Membership) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	f.data.Organizations[org.ID] = org
+	f.data.Members = append(f.data.Members, owner)
+	return f.save()
+}
+
+// GetMembership возвращает участие пользователя в организации.
+func (f *FileStore) GetMembership(orgID, userID string) (model.Membership, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return model.Membership{}, false, err
+	}
+	if i := f.find(orgID, userID); i >= 0 {
+		return f.data.Members[i], true, nil
+	}
+	return model.Membership{}, false, nil
+}
+
+// ListWorkspaces возвращает организации пользователя по имени.
+func (f *FileStore) ListWorkspaces(userID string) ([]model.Workspace, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	var result []model.Workspace
+	for _, m := range f.data.Members {
+		if m.UserID == userID {
+			result = append(result, model.Workspace{Organization: f.data.Organizations[m.OrgID], Role: m.Role})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// ListMembers возвращает участников организации в порядке вступления.
+func (f *FileStore) ListMembers(orgID string) ([]model.Membership, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	var result []model.Membership
+	for _, m := range f.data.Members {
+		if m.OrgID == orgID {
+			result = append(result, m)
+		}
+	}
+	return result, nil
+}
+
+// SetMembership добавляет участника или меняет его роль.
+func (f *FileStore) SetMembership(m model.Membership) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	if i := f.find(m.OrgID, m.UserID); i >= 0 {
+		f.data.Members[i].Role = m.Role
+	} else {
+		f.data.Members = append(f.data.Members, m)
+	}
+	return f.save()
+}
+
+// DeleteMembership удаляет участника. Возвращает false, если его не было.
+func (f *FileStore) DeleteMembership(orgID, userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return false, err
+	}
+	i := f.find(orgID, userID)
+	if i < 0 {
+		return false, nil
+	}
+	f.data.Members = append(f.data.Members[:i], f.data.Members[i+1:]...)
+	return true, f.save()
+}
+
+// Reassign передаёт участие пользователя from пользователю to. Если to уже
+// состоит в организации, ему достаётся старшая из двух ролей.
+func (f *FileStore) Reassign(from, to string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.load(); err != nil {
+		return err
+	}
+	changed := false
+	for _, m := range f.data.Members {
+		if m.UserID != from {
+			continue
+		}
+		changed = true
+		if i := f.find(m.OrgID, to); i >= 0 && rank[m.Role] > rank[f.data.Members[i].Role] {
+			f.data.Members[i].Role = m.Role
+		}
+	}
+	if !changed {
+		return nil
+	}
+	members := make([]model.Membership, 0, len(f.data.Members))
+	for _, m := range f.data.Members {
+		if m.UserID == from {
+			if f.find(m.OrgID, to) >= 0 {
+				continue
+			}
+			m.UserID = to
+		}
+		members = append(members, m)
+	}
+	f.data.Members = members
+	return f.save()
+}
+
+func (f *FileStore) find(orgID, userID string) int {
+	for i, m := range f.data.Members {
+		if m.OrgID == orgID && m.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+// load читает файл при первом обращении; отсутствующий файл означает пустое хранилище.
+func (f *FileStore) load() error {
+	if f.loaded {
+		return nil
+	}
+	f.data = fileData{}
+	if f.Path != "" {
+		raw, err := os.ReadFile(f.Path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &f.data); err != nil {
+				return err
+			}
+		}
+	}
+	if f.data.Organizations == nil {
+		f.data.Organizations = make(map[string]model.Organization)
+	}
+	f.loaded = true
+	return nil
+}
+
+// save атомарно переписывает файл.
+func (f *FileStore) save() error {
+	if f.Path == "" {
+		return nil
+	}
+	raw, err := json.Marshal(f.data)
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
@@ -0,0 +1,92 @@
+package orgs_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/orgs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllows(t *testing.T) {
+	assert.True(t, orgs.Allows(orgs.RoleOwner, orgs.RoleEditor))
+	assert.True(t, orgs.Allows(orgs.RoleEditor, orgs.RoleEditor))
+	assert.False(t, orgs.Allows(orgs.RoleViewer, orgs.RoleEditor))
+	assert.False(t, orgs.Allows("admin", orgs.RoleViewer))
+	assert.True(t, orgs.IsOwner(orgs.Owner("o1")))
+	assert.False(t, orgs.IsOwner("5f0c0e9a-user"))
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orgs.json")
+	store := &orgs.FileStore{Path: path}
+
+	require.NoError(t, store.CreateOrganization(model.Organization{ID: "o1", Name: "Zeta"},
+		model.Membership{OrgID: "o1", UserID: "u1", Role: orgs.RoleOwner}))
+	require.NoError(t, store.CreateOrganization(model.Organization{ID: "o2", Name: "Alpha"},
+		model.Membership{OrgID: "o2", UserID: "u1", Role: orgs.RoleOwner}))
+	require.NoError(t, store.SetMembership(model.Membership{OrgID: "o1", UserID: "u2", Role: orgs.RoleViewer}))
+	require.NoError(t, store.SetMembership(model.Membership{OrgID: "o1", UserID: "u2", Role: orgs.RoleEditor}))
+
+	reopened := &orgs.FileStore{Path: path}
+	workspaces, err := reopened.ListWorkspaces("u1")
+	require.NoError(t, err)
+	require.Len(t, workspaces, 2)
+	assert.Equal(t, "Alpha", workspaces[0].Name)
+	assert.Equal(t, orgs.RoleOwner, workspaces[0].Role)
+
+	m, found, err := reopened.GetMembership("o1", "u2")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, orgs.RoleEditor, m.Role, "роль обновляется, участник не дублируется")
+	members, err := reopened.ListMembers("o1")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	// При переносе участие не дублируется, получатель берёт старшую роль
+	require.NoError(t, reopened.Reassign("u1", "u2"))
+	m, _, err = reopened.GetMembership("o1", "u2")
+	require.NoError(t, err)
+	assert.Equal(t, orgs.RoleOwner, m.Role)
+	m, _, err = reopened.GetMembership("o2", "u2")
+	require.NoError(t, err)
+	assert.Equal(t, orgs.RoleOwner, m.Role)
+	_, found, err = reopened.GetMembership("o1", "u1")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	deleted, err := reopened.DeleteMembership("o1", "u2")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = reopened.DeleteMembership("o1", "u2")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
+
+func TestFileStore_ReassignKeepsSoleOwner(t *testing.T) {
+	store := &orgs.FileStore{}
+	require.NoError(t, store.CreateOrganization(model.Organization{ID: "o1", Name: "Zeta"},
+		model.Membership{OrgID: "o1", UserID: "anon", Role: orgs.RoleOwner}))
+	require.NoError(t, store.SetMembership(model.Membership{OrgID: "o1", UserID: "account", Role: orgs.RoleViewer}))
+	require.NoError(t, store.SetMembership(model.Membership{OrgID: "o1", UserID: "other", Role: orgs.RoleEditor}))
+
+	require.NoError(t, store.Reassign("anon", "account"))
+
+	members, err := store.ListMembers("o1")
+	require.NoError(t, err)
+	roles := map[string]string{}
+	for _, m := range members {
+		roles[m.UserID] = m.Role
+	}
+	assert.Equal(t, map[string]string{"account": orgs.RoleOwner, "other": orgs.RoleEditor}, roles,
+		"единственный владелец не теряется, роль остальных не меняется")
+
+	// Младшая роль переносимого не понижает получателя
+	require.NoError(t, store.SetMembership(model.Membership{OrgID: "o1", UserID: "anon2", Role: orgs.RoleViewer}))
+	require.NoError(t, store.Reassign("anon2", "other"))
+	m, _, err := store.GetMembership("o1", "other")
+	require.NoError(t, err)
+	assert.Equal(t, orgs.RoleEditor, m.Role)
+}
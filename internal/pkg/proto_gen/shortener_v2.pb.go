@@ -222,9 +222,11 @@ func (x *BatchShortenResult) GetShortUrl() string {
 }
 
 type ShortenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Url    string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// Организация, которой будет принадлежать ссылка.
+	OrgId         string `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -273,6 +275,13 @@ func (x *ShortenRequest) GetUrl() string {
 	return ""
 }
 
+func (x *ShortenRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
 type ShortenResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
@@ -318,10 +327,13 @@ func (x *ShortenResponse) GetShortUrl() string {
 }
 
 type ResolveRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// Атрибуты клиента для вычисления правил перенаправления.
+	UserAgent      string `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	AcceptLanguage string `protobuf:"bytes,3,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ResolveRequest) Reset() {
@@ -361,9 +373,25 @@ func (x *ResolveRequest) GetShortUrl() string {
 	return ""
 }
 
+func (x *ResolveRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *ResolveRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.AcceptLanguage
+	}
+	return ""
+}
+
 type ResolveResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// Владелец требует показывать предпросмотр перед переходом.
+	ForcePreview  bool `protobuf:"varint,2,opt,name=force_preview,json=forcePreview,proto3" json:"force_preview,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -405,9 +433,18 @@ func (x *ResolveResponse) GetOriginalUrl() string {
 	return ""
 }
 
+func (x *ResolveResponse) GetForcePreview() bool {
+	if x != nil {
+		return x.ForcePreview
+	}
+	return false
+}
+
 type GetUserURLsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Вернуть ссылки организации вместо личных.
+	OrgId         string `protobuf:"bytes,2,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -449,10 +486,19 @@ func (x *GetUserURLsRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetUserURLsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
 type GetUserURLsResponseItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OriginalUrl   string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
-	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ShortUrl    string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// Метаданные страницы назначения, если уже загружены.
+	Metadata      *LinkMetadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -501,6 +547,83 @@ func (x *GetUserURLsResponseItem) GetShortUrl() string {
 	return ""
 }
 
+func (x *GetUserURLsResponseItem) GetMetadata() *LinkMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type LinkMetadata struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Свойства og:* без префикса.
+	OpenGraph map[string]string `protobuf:"bytes,3,rep,name=open_graph,json=openGraph,proto3" json:"open_graph,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Время загрузки, Unix-секунды.
+	FetchedAt     int64 `protobuf:"varint,4,opt,name=fetched_at,json=fetchedAt,proto3" json:"fetched_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkMetadata) Reset() {
+	*x = LinkMetadata{}
+	mi := &file_shortener_v2_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkMetadata) ProtoMessage() {}
+
+func (x *LinkMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkMetadata.ProtoReflect.Descriptor instead.
+func (*LinkMetadata) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LinkMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetOpenGraph() map[string]string {
+	if x != nil {
+		return x.OpenGraph
+	}
+	return nil
+}
+
+func (x *LinkMetadata) GetFetchedAt() int64 {
+	if x != nil {
+		return x.FetchedAt
+	}
+	return 0
+}
+
 type GetUserURLsResponse struct {
 	state         protoimpl.MessageState     `protogen:"open.v1"`
 	Urls          []*GetUserURLsResponseItem `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
@@ -510,7 +633,7 @@ type GetUserURLsResponse struct {
 
 func (x *GetUserURLsResponse) Reset() {
 	*x = GetUserURLsResponse{}
-	mi := &file_shortener_v2_proto_msgTypes[10]
+	mi := &file_shortener_v2_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -522,7 +645,7 @@ func (x *GetUserURLsResponse) String() string {
 func (*GetUserURLsResponse) ProtoMessage() {}
 
 func (x *GetUserURLsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_v2_proto_msgTypes[10]
+	mi := &file_shortener_v2_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -535,7 +658,7 @@ func (x *GetUserURLsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserURLsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserURLsResponse) Descriptor() ([]byte, []int) {
-	return file_shortener_v2_proto_rawDescGZIP(), []int{10}
+	return file_shortener_v2_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetUserURLsResponse) GetUrls() []*GetUserURLsResponseItem {
@@ -546,16 +669,18 @@ func (x *GetUserURLsResponse) GetUrls() []*GetUserURLsResponseItem {
 }
 
 type DeleteUserURLsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ShortUrls     []string               `protobuf:"bytes,2,rep,name=short_urls,json=shortUrls,proto3" json:"short_urls,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrls []string               `protobuf:"bytes,2,rep,name=short_urls,json=shortUrls,proto3" json:"short_urls,omitempty"`
+	// Удалить ссылки организации вместо личных.
+	OrgId         string `protobuf:"bytes,3,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteUserURLsRequest) Reset() {
 	*x = DeleteUserURLsRequest{}
-	mi := &file_shortener_v2_proto_msgTypes[11]
+	mi := &file_shortener_v2_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -567,7 +692,7 @@ func (x *DeleteUserURLsRequest) String() string {
 func (*DeleteUserURLsRequest) ProtoMessage() {}
 
 func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_v2_proto_msgTypes[11]
+	mi := &file_shortener_v2_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,7 +705,7 @@ func (x *DeleteUserURLsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserURLsRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserURLsRequest) Descriptor() ([]byte, []int) {
-	return file_shortener_v2_proto_rawDescGZIP(), []int{11}
+	return file_shortener_v2_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteUserURLsRequest) GetUserId() string {
@@ -597,6 +722,13 @@ func (x *DeleteUserURLsRequest) GetShortUrls() []string {
 	return nil
 }
 
+func (x *DeleteUserURLsRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
 type DeleteUserURLsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
@@ -606,7 +738,7 @@ type DeleteUserURLsResponse struct {
 
 func (x *DeleteUserURLsResponse) Reset() {
 	*x = DeleteUserURLsResponse{}
-	mi := &file_shortener_v2_proto_msgTypes[12]
+	mi := &file_shortener_v2_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -618,7 +750,7 @@ func (x *DeleteUserURLsResponse) String() string {
 func (*DeleteUserURLsResponse) ProtoMessage() {}
 
 func (x *DeleteUserURLsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shortener_v2_proto_msgTypes[12]
+	mi := &file_shortener_v2_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -631,7 +763,7 @@ func (x *DeleteUserURLsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserURLsResponse.ProtoReflect.Descriptor instead.
 func (*DeleteUserURLsResponse) Descriptor() ([]byte, []int) {
-	return file_shortener_v2_proto_rawDescGZIP(), []int{12}
+	return file_shortener_v2_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DeleteUserURLsResponse) GetStatus() string {
@@ -641,104 +773,3803 @@ func (x *DeleteUserURLsResponse) GetStatus() string {
 	return ""
 }
 
-var File_shortener_v2_proto protoreflect.FileDescriptor
+type RedirectRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Devices       []string               `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	Languages     []string               `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
+	Weekdays      []string               `protobuf:"bytes,4,rep,name=weekdays,proto3" json:"weekdays,omitempty"`
+	TimeFrom      string                 `protobuf:"bytes,5,opt,name=time_from,json=timeFrom,proto3" json:"time_from,omitempty"`
+	TimeTo        string                 `protobuf:"bytes,6,opt,name=time_to,json=timeTo,proto3" json:"time_to,omitempty"`
+	DateFrom      string                 `protobuf:"bytes,7,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo        string                 `protobuf:"bytes,8,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	Timezone      string                 `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Target        string                 `protobuf:"bytes,10,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_shortener_v2_proto_rawDesc = "" +
-	"\n" +
-	"\x12shortener_v2.proto\x12\fshortener.v2\"^\n" +
-	"\x13BatchShortenRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
-	"\x04urls\x18\x02 \x03(\v2\x1a.shortener.v2.BatchURLItemR\x04urls\"X\n" +
-	"\fBatchURLItem\x12%\n" +
-	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12!\n" +
-	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\"N\n" +
-	"\x14BatchShortenResponse\x126\n" +
-	"\x05items\x18\x01 \x03(\v2 .shortener.v2.BatchShortenResultR\x05items\"X\n" +
-	"\x12BatchShortenResult\x12%\n" +
-	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
-	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\";\n" +
-	"\x0eShortenRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
-	"\x03url\x18\x02 \x01(\tR\x03url\".\n" +
-	"\x0fShortenResponse\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"-\n" +
-	"\x0eResolveRequest\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"4\n" +
-	"\x0fResolveResponse\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\"-\n" +
-	"\x12GetUserURLsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"Y\n" +
-	"\x17GetUserURLsResponseItem\x12!\n" +
-	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
-	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"P\n" +
-	"\x13GetUserURLsResponse\x129\n" +
-	"\x04urls\x18\x01 \x03(\v2%.shortener.v2.GetUserURLsResponseItemR\x04urls\"O\n" +
-	"\x15DeleteUserURLsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
-	"\n" +
-	"short_urls\x18\x02 \x03(\tR\tshortUrls\"0\n" +
-	"\x16DeleteUserURLsResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status2\xaa\x03\n" +
-	"\x10ShortenerService\x12F\n" +
-	"\aShorten\x12\x1c.shortener.v2.ShortenRequest\x1a\x1d.shortener.v2.ShortenResponse\x12F\n" +
-	"\aResolve\x12\x1c.shortener.v2.ResolveRequest\x1a\x1d.shortener.v2.ResolveResponse\x12U\n" +
-	"\fBatchShorten\x12!.shortener.v2.BatchShortenRequest\x1a\".shortener.v2.BatchShortenResponse\x12R\n" +
-	"\vGetUserURLs\x12 .shortener.v2.GetUserURLsRequest\x1a!.shortener.v2.GetUserURLsResponse\x12[\n" +
-	"\x0eDeleteUserURLs\x12#.shortener.v2.DeleteUserURLsRequest\x1a$.shortener.v2.DeleteUserURLsResponseB\x03Z\x01.b\x06proto3"
+func (x *RedirectRule) Reset() {
+	*x = RedirectRule{}
+	mi := &file_shortener_v2_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_shortener_v2_proto_rawDescOnce sync.Once
-	file_shortener_v2_proto_rawDescData []byte
-)
+func (x *RedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_shortener_v2_proto_rawDescGZIP() []byte {
-	file_shortener_v2_proto_rawDescOnce.Do(func() {
-		file_shortener_v2_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shortener_v2_proto_rawDesc), len(file_shortener_v2_proto_rawDesc)))
-	})
-	return file_shortener_v2_proto_rawDescData
+func (*RedirectRule) ProtoMessage() {}
+
+func (x *RedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_shortener_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
-var file_shortener_v2_proto_goTypes = []any{
-	(*BatchShortenRequest)(nil),     // 0: shortener.v2.BatchShortenRequest
-	(*BatchURLItem)(nil),            // 1: shortener.v2.BatchURLItem
-	(*BatchShortenResponse)(nil),    // 2: shortener.v2.BatchShortenResponse
-	(*BatchShortenResult)(nil),      // 3: shortener.v2.BatchShortenResult
-	(*ShortenRequest)(nil),          // 4: shortener.v2.ShortenRequest
-	(*ShortenResponse)(nil),         // 5: shortener.v2.ShortenResponse
-	(*ResolveRequest)(nil),          // 6: shortener.v2.ResolveRequest
-	(*ResolveResponse)(nil),         // 7: shortener.v2.ResolveResponse
-	(*GetUserURLsRequest)(nil),      // 8: shortener.v2.GetUserURLsRequest
-	(*GetUserURLsResponseItem)(nil), // 9: shortener.v2.GetUserURLsResponseItem
-	(*GetUserURLsResponse)(nil),     // 10: shortener.v2.GetUserURLsResponse
-	(*DeleteUserURLsRequest)(nil),   // 11: shortener.v2.DeleteUserURLsRequest
-	(*DeleteUserURLsResponse)(nil),  // 12: shortener.v2.DeleteUserURLsResponse
+// Deprecated: Use RedirectRule.ProtoReflect.Descriptor instead.
+func (*RedirectRule) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{14}
 }
-var file_shortener_v2_proto_depIdxs = []int32{
-	1,  // 0: shortener.v2.BatchShortenRequest.urls:type_name -> shortener.v2.BatchURLItem
-	3,  // 1: shortener.v2.BatchShortenResponse.items:type_name -> shortener.v2.BatchShortenResult
-	9,  // 2: shortener.v2.GetUserURLsResponse.urls:type_name -> shortener.v2.GetUserURLsResponseItem
-	4,  // 3: shortener.v2.ShortenerService.Shorten:input_type -> shortener.v2.ShortenRequest
-	6,  // 4: shortener.v2.ShortenerService.Resolve:input_type -> shortener.v2.ResolveRequest
-	0,  // 5: shortener.v2.ShortenerService.BatchShorten:input_type -> shortener.v2.BatchShortenRequest
-	8,  // 6: shortener.v2.ShortenerService.GetUserURLs:input_type -> shortener.v2.GetUserURLsRequest
-	11, // 7: shortener.v2.ShortenerService.DeleteUserURLs:input_type -> shortener.v2.DeleteUserURLsRequest
-	5,  // 8: shortener.v2.ShortenerService.Shorten:output_type -> shortener.v2.ShortenResponse
-	7,  // 9: shortener.v2.ShortenerService.Resolve:output_type -> shortener.v2.ResolveResponse
-	2,  // 10: shortener.v2.ShortenerService.BatchShorten:output_type -> shortener.v2.BatchShortenResponse
-	10, // 11: shortener.v2.ShortenerService.GetUserURLs:output_type -> shortener.v2.GetUserURLsResponse
-	12, // 12: shortener.v2.ShortenerService.DeleteUserURLs:output_type -> shortener.v2.DeleteUserURLsResponse
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+
+func (x *RedirectRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func init() { file_shortener_v2_proto_init() }
-func file_shortener_v2_proto_init() {
-	if File_shortener_v2_proto != nil {
-		return
+func (x *RedirectRule) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetWeekdays() []string {
+	if x != nil {
+		return x.Weekdays
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetTimeFrom() string {
+	if x != nil {
+		return x.TimeFrom
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetTimeTo() string {
+	if x != nil {
+		return x.TimeTo
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetDateFrom() string {
+	if x != nil {
+		return x.DateFrom
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetDateTo() string {
+	if x != nil {
+		return x.DateTo
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type GetRedirectRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRedirectRulesRequest) Reset() {
+	*x = GetRedirectRulesRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRedirectRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRedirectRulesRequest) ProtoMessage() {}
+
+func (x *GetRedirectRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRedirectRulesRequest.ProtoReflect.Descriptor instead.
+func (*GetRedirectRulesRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRedirectRulesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRedirectRulesRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+type GetRedirectRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*RedirectRule        `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRedirectRulesResponse) Reset() {
+	*x = GetRedirectRulesResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRedirectRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRedirectRulesResponse) ProtoMessage() {}
+
+func (x *GetRedirectRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRedirectRulesResponse.ProtoReflect.Descriptor instead.
+func (*GetRedirectRulesResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRedirectRulesResponse) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type SetRedirectRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Rules         []*RedirectRule        `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRedirectRulesRequest) Reset() {
+	*x = SetRedirectRulesRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRedirectRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRedirectRulesRequest) ProtoMessage() {}
+
+func (x *SetRedirectRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRedirectRulesRequest.ProtoReflect.Descriptor instead.
+func (*SetRedirectRulesRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetRedirectRulesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetRedirectRulesRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *SetRedirectRulesRequest) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type SetRedirectRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRedirectRulesResponse) Reset() {
+	*x = SetRedirectRulesResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRedirectRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRedirectRulesResponse) ProtoMessage() {}
+
+func (x *SetRedirectRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRedirectRulesResponse.ProtoReflect.Descriptor instead.
+func (*SetRedirectRulesResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SetRedirectRulesResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SetPreviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPreviewRequest) Reset() {
+	*x = SetPreviewRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPreviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPreviewRequest) ProtoMessage() {}
+
+func (x *SetPreviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPreviewRequest.ProtoReflect.Descriptor instead.
+func (*SetPreviewRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SetPreviewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetPreviewRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *SetPreviewRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetPreviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPreviewResponse) Reset() {
+	*x = SetPreviewResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPreviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPreviewResponse) ProtoMessage() {}
+
+func (x *SetPreviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPreviewResponse.ProtoReflect.Descriptor instead.
+func (*SetPreviewResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SetPreviewResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListBrokenURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrokenURLsRequest) Reset() {
+	*x = ListBrokenURLsRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrokenURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrokenURLsRequest) ProtoMessage() {}
+
+func (x *ListBrokenURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrokenURLsRequest.ProtoReflect.Descriptor instead.
+func (*ListBrokenURLsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListBrokenURLsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BrokenURL struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// HTTP-статус последней проверки, 0 — ответ не получен.
+	Status    int32 `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
+	LatencyMs int64 `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// Время проверки, Unix-секунды.
+	CheckedAt     int64  `protobuf:"varint,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	Error         string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BrokenURL) Reset() {
+	*x = BrokenURL{}
+	mi := &file_shortener_v2_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BrokenURL) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BrokenURL) ProtoMessage() {}
+
+func (x *BrokenURL) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BrokenURL.ProtoReflect.Descriptor instead.
+func (*BrokenURL) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BrokenURL) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *BrokenURL) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *BrokenURL) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *BrokenURL) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *BrokenURL) GetCheckedAt() int64 {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return 0
+}
+
+func (x *BrokenURL) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ListBrokenURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Urls          []*BrokenURL           `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBrokenURLsResponse) Reset() {
+	*x = ListBrokenURLsResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBrokenURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBrokenURLsResponse) ProtoMessage() {}
+
+func (x *ListBrokenURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBrokenURLsResponse.ProtoReflect.Descriptor instead.
+func (*ListBrokenURLsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListBrokenURLsResponse) GetUrls() []*BrokenURL {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+type UpdateURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateURLRequest) Reset() {
+	*x = UpdateURLRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateURLRequest) ProtoMessage() {}
+
+func (x *UpdateURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateURLRequest.ProtoReflect.Descriptor instead.
+func (*UpdateURLRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateURLRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateURLRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type UpdateURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	PreviousUrl   string                 `protobuf:"bytes,3,opt,name=previous_url,json=previousUrl,proto3" json:"previous_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateURLResponse) Reset() {
+	*x = UpdateURLResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateURLResponse) ProtoMessage() {}
+
+func (x *UpdateURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateURLResponse.ProtoReflect.Descriptor instead.
+func (*UpdateURLResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateURLResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateURLResponse) GetPreviousUrl() string {
+	if x != nil {
+		return x.PreviousUrl
+	}
+	return ""
+}
+
+type ListRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrl      string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsRequest) Reset() {
+	*x = ListRevisionsRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsRequest) ProtoMessage() {}
+
+func (x *ListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListRevisionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRevisionsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+type Revision struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChangedBy string                 `protobuf:"bytes,1,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	// Время изменения, Unix-секунды.
+	ChangedAt     int64  `protobuf:"varint,2,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	OldUrl        string `protobuf:"bytes,3,opt,name=old_url,json=oldUrl,proto3" json:"old_url,omitempty"`
+	NewUrl        string `protobuf:"bytes,4,opt,name=new_url,json=newUrl,proto3" json:"new_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_shortener_v2_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Revision) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+func (x *Revision) GetChangedAt() int64 {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return 0
+}
+
+func (x *Revision) GetOldUrl() string {
+	if x != nil {
+		return x.OldUrl
+	}
+	return ""
+}
+
+func (x *Revision) GetNewUrl() string {
+	if x != nil {
+		return x.NewUrl
+	}
+	return ""
+}
+
+type ListRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*Revision            `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsResponse) Reset() {
+	*x = ListRevisionsResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsResponse) ProtoMessage() {}
+
+func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListRevisionsResponse) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type GetLinkStatsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrl string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// Границы периода, Unix-секунды; 0 — значение по умолчанию.
+	From int64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	// "hour" или "day", по умолчанию "day".
+	Interval string `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Учитывать переходы ботов; по умолчанию только люди.
+	IncludeBots   bool `protobuf:"varint,6,opt,name=include_bots,json=includeBots,proto3" json:"include_bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkStatsRequest) Reset() {
+	*x = GetLinkStatsRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkStatsRequest) ProtoMessage() {}
+
+func (x *GetLinkStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkStatsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetLinkStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetLinkStatsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetLinkStatsRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetLinkStatsRequest) GetIncludeBots() bool {
+	if x != nil {
+		return x.IncludeBots
+	}
+	return false
+}
+
+type StatsBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Начало интервала, Unix-секунды.
+	Start  int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Clicks int64 `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	// Оценка уникальных посетителей, только для интервала "day".
+	Visitors      int64 `protobuf:"varint,3,opt,name=visitors,proto3" json:"visitors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsBucket) Reset() {
+	*x = StatsBucket{}
+	mi := &file_shortener_v2_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsBucket) ProtoMessage() {}
+
+func (x *StatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsBucket.ProtoReflect.Descriptor instead.
+func (*StatsBucket) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StatsBucket) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *StatsBucket) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *StatsBucket) GetVisitors() int64 {
+	if x != nil {
+		return x.Visitors
+	}
+	return 0
+}
+
+type StatsEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatsEntry) Reset() {
+	*x = StatsEntry{}
+	mi := &file_shortener_v2_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatsEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsEntry) ProtoMessage() {}
+
+func (x *StatsEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsEntry.ProtoReflect.Descriptor instead.
+func (*StatsEntry) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StatsEntry) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatsEntry) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type GetLinkStatsResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl  string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	From      int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To        int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Interval  string                 `protobuf:"bytes,4,opt,name=interval,proto3" json:"interval,omitempty"`
+	Total     int64                  `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Buckets   []*StatsBucket         `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Referrers []*StatsEntry          `protobuf:"bytes,7,rep,name=referrers,proto3" json:"referrers,omitempty"`
+	Browsers  []*StatsEntry          `protobuf:"bytes,8,rep,name=browsers,proto3" json:"browsers,omitempty"`
+	Os        []*StatsEntry          `protobuf:"bytes,9,rep,name=os,proto3" json:"os,omitempty"`
+	Countries []*StatsEntry          `protobuf:"bytes,10,rep,name=countries,proto3" json:"countries,omitempty"`
+	// Оценка уникальных посетителей-людей за дни, пересекающиеся с периодом.
+	Visitors    int64 `protobuf:"varint,11,opt,name=visitors,proto3" json:"visitors,omitempty"`
+	IncludeBots bool  `protobuf:"varint,12,opt,name=include_bots,json=includeBots,proto3" json:"include_bots,omitempty"`
+	// Переходы ботов за период.
+	Bots          int64 `protobuf:"varint,13,opt,name=bots,proto3" json:"bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkStatsResponse) Reset() {
+	*x = GetLinkStatsResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkStatsResponse) ProtoMessage() {}
+
+func (x *GetLinkStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkStatsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetLinkStatsResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *GetLinkStatsResponse) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetLinkStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetBuckets() []*StatsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetReferrers() []*StatsEntry {
+	if x != nil {
+		return x.Referrers
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetBrowsers() []*StatsEntry {
+	if x != nil {
+		return x.Browsers
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetOs() []*StatsEntry {
+	if x != nil {
+		return x.Os
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetCountries() []*StatsEntry {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *GetLinkStatsResponse) GetVisitors() int64 {
+	if x != nil {
+		return x.Visitors
+	}
+	return 0
+}
+
+func (x *GetLinkStatsResponse) GetIncludeBots() bool {
+	if x != nil {
+		return x.IncludeBots
+	}
+	return false
+}
+
+func (x *GetLinkStatsResponse) GetBots() int64 {
+	if x != nil {
+		return x.Bots
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Окно в днях для created_per_day и top_links, 0 — 30 дней.
+	Days int32 `protobuf:"varint,1,opt,name=days,proto3" json:"days,omitempty"`
+	// Учитывать переходы ботов в top_links; по умолчанию только люди.
+	IncludeBots   bool `protobuf:"varint,2,opt,name=include_bots,json=includeBots,proto3" json:"include_bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetStatsRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *GetStatsRequest) GetIncludeBots() bool {
+	if x != nil {
+		return x.IncludeBots
+	}
+	return false
+}
+
+type TopLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl   string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	Clicks        int64                  `protobuf:"varint,3,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopLink) Reset() {
+	*x = TopLink{}
+	mi := &file_shortener_v2_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopLink) ProtoMessage() {}
+
+func (x *TopLink) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopLink.ProtoReflect.Descriptor instead.
+func (*TopLink) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TopLink) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *TopLink) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *TopLink) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type DayCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Начало дня (UTC), Unix-секунды.
+	Day           int64 `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	Count         int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DayCount) Reset() {
+	*x = DayCount{}
+	mi := &file_shortener_v2_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DayCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DayCount) ProtoMessage() {}
+
+func (x *DayCount) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DayCount.ProtoReflect.Descriptor instead.
+func (*DayCount) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DayCount) GetDay() int64 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *DayCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Total    int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Active   int64                  `protobuf:"varint,2,opt,name=active,proto3" json:"active,omitempty"`
+	Deleted  int64                  `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Disabled int64                  `protobuf:"varint,4,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Users    int64                  `protobuf:"varint,5,opt,name=users,proto3" json:"users,omitempty"`
+	// Начало окна, Unix-секунды.
+	Since         int64       `protobuf:"varint,6,opt,name=since,proto3" json:"since,omitempty"`
+	CreatedPerDay []*DayCount `protobuf:"bytes,7,rep,name=created_per_day,json=createdPerDay,proto3" json:"created_per_day,omitempty"`
+	TopLinks      []*TopLink  `protobuf:"bytes,8,rep,name=top_links,json=topLinks,proto3" json:"top_links,omitempty"`
+	// Оценка уникальных посетителей-людей всех ссылок с начала окна.
+	Visitors      int64 `protobuf:"varint,9,opt,name=visitors,proto3" json:"visitors,omitempty"`
+	IncludeBots   bool  `protobuf:"varint,10,opt,name=include_bots,json=includeBots,proto3" json:"include_bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetStatsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetActive() int64 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetDisabled() int64 {
+	if x != nil {
+		return x.Disabled
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetCreatedPerDay() []*DayCount {
+	if x != nil {
+		return x.CreatedPerDay
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetTopLinks() []*TopLink {
+	if x != nil {
+		return x.TopLinks
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetVisitors() int64 {
+	if x != nil {
+		return x.Visitors
+	}
+	return 0
+}
+
+func (x *GetStatsResponse) GetIncludeBots() bool {
+	if x != nil {
+		return x.IncludeBots
+	}
+	return false
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListTrashRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type TrashItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	// Время удаления и окончания срока восстановления, Unix-секунды.
+	DeletedAt     int64 `protobuf:"varint,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	ExpiresAt     int64 `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashItem) Reset() {
+	*x = TrashItem{}
+	mi := &file_shortener_v2_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashItem) ProtoMessage() {}
+
+func (x *TrashItem) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashItem.ProtoReflect.Descriptor instead.
+func (*TrashItem) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TrashItem) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *TrashItem) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *TrashItem) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *TrashItem) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*TrashItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListTrashResponse) GetItems() []*TrashItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RestoreURLsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ShortUrls     []string               `protobuf:"bytes,2,rep,name=short_urls,json=shortUrls,proto3" json:"short_urls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreURLsRequest) Reset() {
+	*x = RestoreURLsRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreURLsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreURLsRequest) ProtoMessage() {}
+
+func (x *RestoreURLsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreURLsRequest.ProtoReflect.Descriptor instead.
+func (*RestoreURLsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RestoreURLsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestoreURLsRequest) GetShortUrls() []string {
+	if x != nil {
+		return x.ShortUrls
+	}
+	return nil
+}
+
+type RestoreURLsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Restored      []string               `protobuf:"bytes,1,rep,name=restored,proto3" json:"restored,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreURLsResponse) Reset() {
+	*x = RestoreURLsResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreURLsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreURLsResponse) ProtoMessage() {}
+
+func (x *RestoreURLsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreURLsResponse.ProtoReflect.Descriptor instead.
+func (*RestoreURLsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RestoreURLsResponse) GetRestored() []string {
+	if x != nil {
+		return x.Restored
+	}
+	return nil
+}
+
+type WatchClicksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchClicksRequest) Reset() {
+	*x = WatchClicksRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchClicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchClicksRequest) ProtoMessage() {}
+
+func (x *WatchClicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchClicksRequest.ProtoReflect.Descriptor instead.
+func (*WatchClicksRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WatchClicksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ClickEvent struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	// Время перехода, Unix-секунды.
+	Time          int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Referrer      string `protobuf:"bytes,3,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	Browser       string `protobuf:"bytes,4,opt,name=browser,proto3" json:"browser,omitempty"`
+	Os            string `protobuf:"bytes,5,opt,name=os,proto3" json:"os,omitempty"`
+	Country       string `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Variant       string `protobuf:"bytes,7,opt,name=variant,proto3" json:"variant,omitempty"`
+	Bot           bool   `protobuf:"varint,8,opt,name=bot,proto3" json:"bot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickEvent) Reset() {
+	*x = ClickEvent{}
+	mi := &file_shortener_v2_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickEvent) ProtoMessage() {}
+
+func (x *ClickEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickEvent.ProtoReflect.Descriptor instead.
+func (*ClickEvent) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ClickEvent) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ClickEvent) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetBrowser() string {
+	if x != nil {
+		return x.Browser
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *ClickEvent) GetBot() bool {
+	if x != nil {
+		return x.Bot
+	}
+	return false
+}
+
+type WatchClicksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*WatchClicksResponse_Click
+	//	*WatchClicksResponse_Heartbeat
+	Event         isWatchClicksResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchClicksResponse) Reset() {
+	*x = WatchClicksResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchClicksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchClicksResponse) ProtoMessage() {}
+
+func (x *WatchClicksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchClicksResponse.ProtoReflect.Descriptor instead.
+func (*WatchClicksResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *WatchClicksResponse) GetEvent() isWatchClicksResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *WatchClicksResponse) GetClick() *ClickEvent {
+	if x != nil {
+		if x, ok := x.Event.(*WatchClicksResponse_Click); ok {
+			return x.Click
+		}
+	}
+	return nil
+}
+
+func (x *WatchClicksResponse) GetHeartbeat() int64 {
+	if x != nil {
+		if x, ok := x.Event.(*WatchClicksResponse_Heartbeat); ok {
+			return x.Heartbeat
+		}
+	}
+	return 0
+}
+
+type isWatchClicksResponse_Event interface {
+	isWatchClicksResponse_Event()
+}
+
+type WatchClicksResponse_Click struct {
+	Click *ClickEvent `protobuf:"bytes,1,opt,name=click,proto3,oneof"`
+}
+
+type WatchClicksResponse_Heartbeat struct {
+	// Служебное сообщение при отсутствии переходов, Unix-секунды.
+	Heartbeat int64 `protobuf:"varint,2,opt,name=heartbeat,proto3,oneof"`
+}
+
+func (*WatchClicksResponse_Click) isWatchClicksResponse_Event() {}
+
+func (*WatchClicksResponse_Heartbeat) isWatchClicksResponse_Event() {}
+
+type Organization struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Роль вызывающего: owner, editor или viewer.
+	Role string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	// Время создания, Unix-секунды.
+	Created       int64 `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Organization) Reset() {
+	*x = Organization{}
+	mi := &file_shortener_v2_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Organization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Organization) ProtoMessage() {}
+
+func (x *Organization) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Organization.ProtoReflect.Descriptor instead.
+func (*Organization) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Organization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Organization) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Organization) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Organization) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type CreateOrganizationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrganizationRequest) Reset() {
+	*x = CreateOrganizationRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrganizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrganizationRequest) ProtoMessage() {}
+
+func (x *CreateOrganizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrganizationRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrganizationRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateOrganizationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListOrganizationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsRequest) Reset() {
+	*x = ListOrganizationsRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsRequest) ProtoMessage() {}
+
+func (x *ListOrganizationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{47}
+}
+
+type ListOrganizationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Organizations []*Organization        `protobuf:"bytes,1,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrganizationsResponse) Reset() {
+	*x = ListOrganizationsResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrganizationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrganizationsResponse) ProtoMessage() {}
+
+func (x *ListOrganizationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrganizationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrganizationsResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListOrganizationsResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+type Member struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role   string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	// Время вступления, Unix-секунды.
+	Created       int64 `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Member) Reset() {
+	*x = Member{}
+	mi := &file_shortener_v2_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Member) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Member) ProtoMessage() {}
+
+func (x *Member) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Member.ProtoReflect.Descriptor instead.
+func (*Member) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Member) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Member) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Member) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type ListMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersRequest) Reset() {
+	*x = ListMembersRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersRequest) ProtoMessage() {}
+
+func (x *ListMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListMembersRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*Member              `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMembersResponse) Reset() {
+	*x = ListMembersResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMembersResponse) ProtoMessage() {}
+
+func (x *ListMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListMembersResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListMembersResponse) GetMembers() []*Member {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type SetMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberRequest) Reset() {
+	*x = SetMemberRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberRequest) ProtoMessage() {}
+
+func (x *SetMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberRequest.ProtoReflect.Descriptor instead.
+func (*SetMemberRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SetMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *SetMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetMemberRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type RemoveMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberRequest) Reset() {
+	*x = RemoveMemberRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberRequest) ProtoMessage() {}
+
+func (x *RemoveMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RemoveMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *RemoveMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveMemberResponse) Reset() {
+	*x = RemoveMemberResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveMemberResponse) ProtoMessage() {}
+
+func (x *RemoveMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{54}
+}
+
+type AdminSearchLinksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Хост адреса назначения; поддомены тоже подходят.
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Границы времени создания [from, to), Unix-секунды; 0 — без ограничения.
+	From           int64 `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To             int64 `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	IncludeDeleted bool  `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// 0 — 100 ссылок, не больше 1000.
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,7,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminSearchLinksRequest) Reset() {
+	*x = AdminSearchLinksRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminSearchLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSearchLinksRequest) ProtoMessage() {}
+
+func (x *AdminSearchLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSearchLinksRequest.ProtoReflect.Descriptor instead.
+func (*AdminSearchLinksRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AdminSearchLinksRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *AdminSearchLinksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdminSearchLinksRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AdminSearchLinksRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *AdminSearchLinksRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *AdminSearchLinksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AdminSearchLinksRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type AdminLink struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl    string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	OriginalUrl string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	UserId      string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Время создания, Unix-секунды.
+	Created        int64  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	Deleted        bool   `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Disabled       bool   `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	DisabledReason string `protobuf:"bytes,7,opt,name=disabled_reason,json=disabledReason,proto3" json:"disabled_reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AdminLink) Reset() {
+	*x = AdminLink{}
+	mi := &file_shortener_v2_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminLink) ProtoMessage() {}
+
+func (x *AdminLink) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminLink.ProtoReflect.Descriptor instead.
+func (*AdminLink) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AdminLink) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *AdminLink) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *AdminLink) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdminLink) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *AdminLink) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *AdminLink) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *AdminLink) GetDisabledReason() string {
+	if x != nil {
+		return x.DisabledReason
+	}
+	return ""
+}
+
+type AdminSearchLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*AdminLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminSearchLinksResponse) Reset() {
+	*x = AdminSearchLinksResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminSearchLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminSearchLinksResponse) ProtoMessage() {}
+
+func (x *AdminSearchLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminSearchLinksResponse.ProtoReflect.Descriptor instead.
+func (*AdminSearchLinksResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AdminSearchLinksResponse) GetLinks() []*AdminLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type AdminDisableLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminDisableLinkRequest) Reset() {
+	*x = AdminDisableLinkRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDisableLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDisableLinkRequest) ProtoMessage() {}
+
+func (x *AdminDisableLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDisableLinkRequest.ProtoReflect.Descriptor instead.
+func (*AdminDisableLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AdminDisableLinkRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *AdminDisableLinkRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AdminDisableLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminDisableLinkResponse) Reset() {
+	*x = AdminDisableLinkResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDisableLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDisableLinkResponse) ProtoMessage() {}
+
+func (x *AdminDisableLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDisableLinkResponse.ProtoReflect.Descriptor instead.
+func (*AdminDisableLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{59}
+}
+
+type AdminDeleteLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShortUrl      string                 `protobuf:"bytes,1,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminDeleteLinkRequest) Reset() {
+	*x = AdminDeleteLinkRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDeleteLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDeleteLinkRequest) ProtoMessage() {}
+
+func (x *AdminDeleteLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDeleteLinkRequest.ProtoReflect.Descriptor instead.
+func (*AdminDeleteLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AdminDeleteLinkRequest) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+type AdminDeleteLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminDeleteLinkResponse) Reset() {
+	*x = AdminDeleteLinkResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminDeleteLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminDeleteLinkResponse) ProtoMessage() {}
+
+func (x *AdminDeleteLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminDeleteLinkResponse.ProtoReflect.Descriptor instead.
+func (*AdminDeleteLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{61}
+}
+
+type Ban struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Администратор, выставивший запрет.
+	By string `protobuf:"bytes,3,opt,name=by,proto3" json:"by,omitempty"`
+	// Время запрета, Unix-секунды.
+	Created       int64 `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ban) Reset() {
+	*x = Ban{}
+	mi := &file_shortener_v2_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ban) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ban) ProtoMessage() {}
+
+func (x *Ban) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ban.ProtoReflect.Descriptor instead.
+func (*Ban) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *Ban) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Ban) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Ban) GetBy() string {
+	if x != nil {
+		return x.By
+	}
+	return ""
+}
+
+func (x *Ban) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type BanUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Отключить активные ссылки пользователя.
+	DisableLinks  bool `protobuf:"varint,3,opt,name=disable_links,json=disableLinks,proto3" json:"disable_links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanUserRequest) Reset() {
+	*x = BanUserRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanUserRequest) ProtoMessage() {}
+
+func (x *BanUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanUserRequest.ProtoReflect.Descriptor instead.
+func (*BanUserRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *BanUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BanUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BanUserRequest) GetDisableLinks() bool {
+	if x != nil {
+		return x.DisableLinks
+	}
+	return false
+}
+
+type BanUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ban           *Ban                   `protobuf:"bytes,1,opt,name=ban,proto3" json:"ban,omitempty"`
+	DisabledLinks int32                  `protobuf:"varint,2,opt,name=disabled_links,json=disabledLinks,proto3" json:"disabled_links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanUserResponse) Reset() {
+	*x = BanUserResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanUserResponse) ProtoMessage() {}
+
+func (x *BanUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanUserResponse.ProtoReflect.Descriptor instead.
+func (*BanUserResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *BanUserResponse) GetBan() *Ban {
+	if x != nil {
+		return x.Ban
+	}
+	return nil
+}
+
+func (x *BanUserResponse) GetDisabledLinks() int32 {
+	if x != nil {
+		return x.DisabledLinks
+	}
+	return 0
+}
+
+type UnbanUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanUserRequest) Reset() {
+	*x = UnbanUserRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanUserRequest) ProtoMessage() {}
+
+func (x *UnbanUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanUserRequest.ProtoReflect.Descriptor instead.
+func (*UnbanUserRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *UnbanUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UnbanUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanUserResponse) Reset() {
+	*x = UnbanUserResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanUserResponse) ProtoMessage() {}
+
+func (x *UnbanUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanUserResponse.ProtoReflect.Descriptor instead.
+func (*UnbanUserResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{66}
+}
+
+type GetUserActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserActivityRequest) Reset() {
+	*x = GetUserActivityRequest{}
+	mi := &file_shortener_v2_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserActivityRequest) ProtoMessage() {}
+
+func (x *GetUserActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetUserActivityRequest) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetUserActivityRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetUserActivityResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Отсутствует, если запрета нет.
+	Ban      *Ban  `protobuf:"bytes,2,opt,name=ban,proto3" json:"ban,omitempty"`
+	Links    int64 `protobuf:"varint,3,opt,name=links,proto3" json:"links,omitempty"`
+	Active   int64 `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	Deleted  int64 `protobuf:"varint,5,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Disabled int64 `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// Время создания последней ссылки, Unix-секунды; 0 — ссылок нет.
+	LastCreated   int64           `protobuf:"varint,7,opt,name=last_created,json=lastCreated,proto3" json:"last_created,omitempty"`
+	Recent        []*AdminLink    `protobuf:"bytes,8,rep,name=recent,proto3" json:"recent,omitempty"`
+	ApiKeys       int32           `protobuf:"varint,9,opt,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	Organizations []*Organization `protobuf:"bytes,10,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserActivityResponse) Reset() {
+	*x = GetUserActivityResponse{}
+	mi := &file_shortener_v2_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserActivityResponse) ProtoMessage() {}
+
+func (x *GetUserActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shortener_v2_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserActivityResponse.ProtoReflect.Descriptor instead.
+func (*GetUserActivityResponse) Descriptor() ([]byte, []int) {
+	return file_shortener_v2_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetUserActivityResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserActivityResponse) GetBan() *Ban {
+	if x != nil {
+		return x.Ban
+	}
+	return nil
+}
+
+func (x *GetUserActivityResponse) GetLinks() int64 {
+	if x != nil {
+		return x.Links
+	}
+	return 0
+}
+
+func (x *GetUserActivityResponse) GetActive() int64 {
+	if x != nil {
+		return x.Active
+	}
+	return 0
+}
+
+func (x *GetUserActivityResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *GetUserActivityResponse) GetDisabled() int64 {
+	if x != nil {
+		return x.Disabled
+	}
+	return 0
+}
+
+func (x *GetUserActivityResponse) GetLastCreated() int64 {
+	if x != nil {
+		return x.LastCreated
+	}
+	return 0
+}
+
+func (x *GetUserActivityResponse) GetRecent() []*AdminLink {
+	if x != nil {
+		return x.Recent
+	}
+	return nil
+}
+
+func (x *GetUserActivityResponse) GetApiKeys() int32 {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return 0
+}
+
+func (x *GetUserActivityResponse) GetOrganizations() []*Organization {
+	if x != nil {
+		return x.Organizations
+	}
+	return nil
+}
+
+var File_shortener_v2_proto protoreflect.FileDescriptor
+
+const file_shortener_v2_proto_rawDesc = "" +
+	"\n" +
+	"\x12shortener_v2.proto\x12\fshortener.v2\"^\n" +
+	"\x13BatchShortenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x04urls\x18\x02 \x03(\v2\x1a.shortener.v2.BatchURLItemR\x04urls\"X\n" +
+	"\fBatchURLItem\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\"N\n" +
+	"\x14BatchShortenResponse\x126\n" +
+	"\x05items\x18\x01 \x03(\v2 .shortener.v2.BatchShortenResultR\x05items\"X\n" +
+	"\x12BatchShortenResult\x12%\n" +
+	"\x0ecorrelation_id\x18\x01 \x01(\tR\rcorrelationId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"R\n" +
+	"\x0eShortenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\tR\x05orgId\".\n" +
+	"\x0fShortenResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"u\n" +
+	"\x0eResolveRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12'\n" +
+	"\x0faccept_language\x18\x03 \x01(\tR\x0eacceptLanguage\"Y\n" +
+	"\x0fResolveResponse\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12#\n" +
+	"\rforce_preview\x18\x02 \x01(\bR\fforcePreview\"D\n" +
+	"\x12GetUserURLsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06org_id\x18\x02 \x01(\tR\x05orgId\"\x91\x01\n" +
+	"\x17GetUserURLsResponseItem\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x126\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x1a.shortener.v2.LinkMetadataR\bmetadata\"\xed\x01\n" +
+	"\fLinkMetadata\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12H\n" +
+	"\n" +
+	"open_graph\x18\x03 \x03(\v2).shortener.v2.LinkMetadata.OpenGraphEntryR\topenGraph\x12\x1d\n" +
+	"\n" +
+	"fetched_at\x18\x04 \x01(\x03R\tfetchedAt\x1a<\n" +
+	"\x0eOpenGraphEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"P\n" +
+	"\x13GetUserURLsResponse\x129\n" +
+	"\x04urls\x18\x01 \x03(\v2%.shortener.v2.GetUserURLsResponseItemR\x04urls\"f\n" +
+	"\x15DeleteUserURLsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"short_urls\x18\x02 \x03(\tR\tshortUrls\x12\x15\n" +
+	"\x06org_id\x18\x03 \x01(\tR\x05orgId\"0\n" +
+	"\x16DeleteUserURLsResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"\x96\x02\n" +
+	"\fRedirectRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\adevices\x18\x02 \x03(\tR\adevices\x12\x1c\n" +
+	"\tlanguages\x18\x03 \x03(\tR\tlanguages\x12\x1a\n" +
+	"\bweekdays\x18\x04 \x03(\tR\bweekdays\x12\x1b\n" +
+	"\ttime_from\x18\x05 \x01(\tR\btimeFrom\x12\x17\n" +
+	"\atime_to\x18\x06 \x01(\tR\x06timeTo\x12\x1b\n" +
+	"\tdate_from\x18\a \x01(\tR\bdateFrom\x12\x17\n" +
+	"\adate_to\x18\b \x01(\tR\x06dateTo\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x12\x16\n" +
+	"\x06target\x18\n" +
+	" \x01(\tR\x06target\"O\n" +
+	"\x17GetRedirectRulesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"L\n" +
+	"\x18GetRedirectRulesResponse\x120\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1a.shortener.v2.RedirectRuleR\x05rules\"\x81\x01\n" +
+	"\x17SetRedirectRulesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x120\n" +
+	"\x05rules\x18\x03 \x03(\v2\x1a.shortener.v2.RedirectRuleR\x05rules\"2\n" +
+	"\x18SetRedirectRulesResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"c\n" +
+	"\x11SetPreviewRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\",\n" +
+	"\x12SetPreviewResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"0\n" +
+	"\x15ListBrokenURLsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb7\x01\n" +
+	"\tBrokenURL\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\x12\x1d\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\x03R\tcheckedAt\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"E\n" +
+	"\x16ListBrokenURLsResponse\x12+\n" +
+	"\x04urls\x18\x01 \x03(\v2\x17.shortener.v2.BrokenURLR\x04urls\"Z\n" +
+	"\x10UpdateURLRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"v\n" +
+	"\x11UpdateURLResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12!\n" +
+	"\fprevious_url\x18\x03 \x01(\tR\vpreviousUrl\"L\n" +
+	"\x14ListRevisionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\"z\n" +
+	"\bRevision\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x01 \x01(\tR\tchangedBy\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x02 \x01(\x03R\tchangedAt\x12\x17\n" +
+	"\aold_url\x18\x03 \x01(\tR\x06oldUrl\x12\x17\n" +
+	"\anew_url\x18\x04 \x01(\tR\x06newUrl\"M\n" +
+	"\x15ListRevisionsResponse\x124\n" +
+	"\trevisions\x18\x01 \x03(\v2\x16.shortener.v2.RevisionR\trevisions\"\xae\x01\n" +
+	"\x13GetLinkStatsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\x03R\x02to\x12\x1a\n" +
+	"\binterval\x18\x05 \x01(\tR\binterval\x12!\n" +
+	"\finclude_bots\x18\x06 \x01(\bR\vincludeBots\"W\n" +
+	"\vStatsBucket\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x03R\x05start\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12\x1a\n" +
+	"\bvisitors\x18\x03 \x01(\x03R\bvisitors\"8\n" +
+	"\n" +
+	"StatsEntry\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"\xe1\x03\n" +
+	"\x14GetLinkStatsResponse\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\x12\x1a\n" +
+	"\binterval\x18\x04 \x01(\tR\binterval\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x03R\x05total\x123\n" +
+	"\abuckets\x18\x06 \x03(\v2\x19.shortener.v2.StatsBucketR\abuckets\x126\n" +
+	"\treferrers\x18\a \x03(\v2\x18.shortener.v2.StatsEntryR\treferrers\x124\n" +
+	"\bbrowsers\x18\b \x03(\v2\x18.shortener.v2.StatsEntryR\bbrowsers\x12(\n" +
+	"\x02os\x18\t \x03(\v2\x18.shortener.v2.StatsEntryR\x02os\x126\n" +
+	"\tcountries\x18\n" +
+	" \x03(\v2\x18.shortener.v2.StatsEntryR\tcountries\x12\x1a\n" +
+	"\bvisitors\x18\v \x01(\x03R\bvisitors\x12!\n" +
+	"\finclude_bots\x18\f \x01(\bR\vincludeBots\x12\x12\n" +
+	"\x04bots\x18\r \x01(\x03R\x04bots\"H\n" +
+	"\x0fGetStatsRequest\x12\x12\n" +
+	"\x04days\x18\x01 \x01(\x05R\x04days\x12!\n" +
+	"\finclude_bots\x18\x02 \x01(\bR\vincludeBots\"a\n" +
+	"\aTopLink\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x16\n" +
+	"\x06clicks\x18\x03 \x01(\x03R\x06clicks\"2\n" +
+	"\bDayCount\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\x03R\x03day\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xd5\x02\n" +
+	"\x10GetStatsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x16\n" +
+	"\x06active\x18\x02 \x01(\x03R\x06active\x12\x18\n" +
+	"\adeleted\x18\x03 \x01(\x03R\adeleted\x12\x1a\n" +
+	"\bdisabled\x18\x04 \x01(\x03R\bdisabled\x12\x14\n" +
+	"\x05users\x18\x05 \x01(\x03R\x05users\x12\x14\n" +
+	"\x05since\x18\x06 \x01(\x03R\x05since\x12>\n" +
+	"\x0fcreated_per_day\x18\a \x03(\v2\x16.shortener.v2.DayCountR\rcreatedPerDay\x122\n" +
+	"\ttop_links\x18\b \x03(\v2\x15.shortener.v2.TopLinkR\btopLinks\x12\x1a\n" +
+	"\bvisitors\x18\t \x01(\x03R\bvisitors\x12!\n" +
+	"\finclude_bots\x18\n" +
+	" \x01(\bR\vincludeBots\"+\n" +
+	"\x10ListTrashRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x89\x01\n" +
+	"\tTrashItem\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\x03R\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"B\n" +
+	"\x11ListTrashResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.shortener.v2.TrashItemR\x05items\"L\n" +
+	"\x12RestoreURLsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"short_urls\x18\x02 \x03(\tR\tshortUrls\"1\n" +
+	"\x13RestoreURLsResponse\x12\x1a\n" +
+	"\brestored\x18\x01 \x03(\tR\brestored\"-\n" +
+	"\x12WatchClicksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xc9\x01\n" +
+	"\n" +
+	"ClickEvent\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x1a\n" +
+	"\breferrer\x18\x03 \x01(\tR\breferrer\x12\x18\n" +
+	"\abrowser\x18\x04 \x01(\tR\abrowser\x12\x0e\n" +
+	"\x02os\x18\x05 \x01(\tR\x02os\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x18\n" +
+	"\avariant\x18\a \x01(\tR\avariant\x12\x10\n" +
+	"\x03bot\x18\b \x01(\bR\x03bot\"p\n" +
+	"\x13WatchClicksResponse\x120\n" +
+	"\x05click\x18\x01 \x01(\v2\x18.shortener.v2.ClickEventH\x00R\x05click\x12\x1e\n" +
+	"\theartbeat\x18\x02 \x01(\x03H\x00R\theartbeatB\a\n" +
+	"\x05event\"`\n" +
+	"\fOrganization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x03R\acreated\"/\n" +
+	"\x19CreateOrganizationRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x1a\n" +
+	"\x18ListOrganizationsRequest\"]\n" +
+	"\x19ListOrganizationsResponse\x12@\n" +
+	"\rorganizations\x18\x01 \x03(\v2\x1a.shortener.v2.OrganizationR\rorganizations\"O\n" +
+	"\x06Member\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x03R\acreated\"+\n" +
+	"\x12ListMembersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"E\n" +
+	"\x13ListMembersResponse\x12.\n" +
+	"\amembers\x18\x01 \x03(\v2\x14.shortener.v2.MemberR\amembers\"V\n" +
+	"\x10SetMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"E\n" +
+	"\x13RemoveMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x16\n" +
+	"\x14RemoveMemberResponse\"\xc5\x01\n" +
+	"\x17AdminSearchLinksRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\x03R\x02to\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\a \x01(\x05R\x06offset\"\xdd\x01\n" +
+	"\tAdminLink\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x03R\acreated\x12\x18\n" +
+	"\adeleted\x18\x05 \x01(\bR\adeleted\x12\x1a\n" +
+	"\bdisabled\x18\x06 \x01(\bR\bdisabled\x12'\n" +
+	"\x0fdisabled_reason\x18\a \x01(\tR\x0edisabledReason\"I\n" +
+	"\x18AdminSearchLinksResponse\x12-\n" +
+	"\x05links\x18\x01 \x03(\v2\x17.shortener.v2.AdminLinkR\x05links\"N\n" +
+	"\x17AdminDisableLinkRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x1a\n" +
+	"\x18AdminDisableLinkResponse\"5\n" +
+	"\x16AdminDeleteLinkRequest\x12\x1b\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"\x19\n" +
+	"\x17AdminDeleteLinkResponse\"`\n" +
+	"\x03Ban\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x0e\n" +
+	"\x02by\x18\x03 \x01(\tR\x02by\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x03R\acreated\"f\n" +
+	"\x0eBanUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12#\n" +
+	"\rdisable_links\x18\x03 \x01(\bR\fdisableLinks\"]\n" +
+	"\x0fBanUserResponse\x12#\n" +
+	"\x03ban\x18\x01 \x01(\v2\x11.shortener.v2.BanR\x03ban\x12%\n" +
+	"\x0edisabled_links\x18\x02 \x01(\x05R\rdisabledLinks\"+\n" +
+	"\x10UnbanUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x13\n" +
+	"\x11UnbanUserResponse\"1\n" +
+	"\x16GetUserActivityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xec\x02\n" +
+	"\x17GetUserActivityResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\x03ban\x18\x02 \x01(\v2\x11.shortener.v2.BanR\x03ban\x12\x14\n" +
+	"\x05links\x18\x03 \x01(\x03R\x05links\x12\x16\n" +
+	"\x06active\x18\x04 \x01(\x03R\x06active\x12\x18\n" +
+	"\adeleted\x18\x05 \x01(\x03R\adeleted\x12\x1a\n" +
+	"\bdisabled\x18\x06 \x01(\x03R\bdisabled\x12!\n" +
+	"\flast_created\x18\a \x01(\x03R\vlastCreated\x12/\n" +
+	"\x06recent\x18\b \x03(\v2\x17.shortener.v2.AdminLinkR\x06recent\x12\x19\n" +
+	"\bapi_keys\x18\t \x01(\x05R\aapiKeys\x12@\n" +
+	"\rorganizations\x18\n" +
+	" \x03(\v2\x1a.shortener.v2.OrganizationR\rorganizations2\xab\x12\n" +
+	"\x10ShortenerService\x12F\n" +
+	"\aShorten\x12\x1c.shortener.v2.ShortenRequest\x1a\x1d.shortener.v2.ShortenResponse\x12F\n" +
+	"\aResolve\x12\x1c.shortener.v2.ResolveRequest\x1a\x1d.shortener.v2.ResolveResponse\x12U\n" +
+	"\fBatchShorten\x12!.shortener.v2.BatchShortenRequest\x1a\".shortener.v2.BatchShortenResponse\x12R\n" +
+	"\vGetUserURLs\x12 .shortener.v2.GetUserURLsRequest\x1a!.shortener.v2.GetUserURLsResponse\x12[\n" +
+	"\x0eDeleteUserURLs\x12#.shortener.v2.DeleteUserURLsRequest\x1a$.shortener.v2.DeleteUserURLsResponse\x12a\n" +
+	"\x10GetRedirectRules\x12%.shortener.v2.GetRedirectRulesRequest\x1a&.shortener.v2.GetRedirectRulesResponse\x12a\n" +
+	"\x10SetRedirectRules\x12%.shortener.v2.SetRedirectRulesRequest\x1a&.shortener.v2.SetRedirectRulesResponse\x12O\n" +
+	"\n" +
+	"SetPreview\x12\x1f.shortener.v2.SetPreviewRequest\x1a .shortener.v2.SetPreviewResponse\x12[\n" +
+	"\x0eListBrokenURLs\x12#.shortener.v2.ListBrokenURLsRequest\x1a$.shortener.v2.ListBrokenURLsResponse\x12L\n" +
+	"\tUpdateURL\x12\x1e.shortener.v2.UpdateURLRequest\x1a\x1f.shortener.v2.UpdateURLResponse\x12X\n" +
+	"\rListRevisions\x12\".shortener.v2.ListRevisionsRequest\x1a#.shortener.v2.ListRevisionsResponse\x12U\n" +
+	"\fGetLinkStats\x12!.shortener.v2.GetLinkStatsRequest\x1a\".shortener.v2.GetLinkStatsResponse\x12I\n" +
+	"\bGetStats\x12\x1d.shortener.v2.GetStatsRequest\x1a\x1e.shortener.v2.GetStatsResponse\x12L\n" +
+	"\tListTrash\x12\x1e.shortener.v2.ListTrashRequest\x1a\x1f.shortener.v2.ListTrashResponse\x12R\n" +
+	"\vRestoreURLs\x12 .shortener.v2.RestoreURLsRequest\x1a!.shortener.v2.RestoreURLsResponse\x12T\n" +
+	"\vWatchClicks\x12 .shortener.v2.WatchClicksRequest\x1a!.shortener.v2.WatchClicksResponse0\x01\x12Y\n" +
+	"\x12CreateOrganization\x12'.shortener.v2.CreateOrganizationRequest\x1a\x1a.shortener.v2.Organization\x12d\n" +
+	"\x11ListOrganizations\x12&.shortener.v2.ListOrganizationsRequest\x1a'.shortener.v2.ListOrganizationsResponse\x12R\n" +
+	"\vListMembers\x12 .shortener.v2.ListMembersRequest\x1a!.shortener.v2.ListMembersResponse\x12A\n" +
+	"\tSetMember\x12\x1e.shortener.v2.SetMemberRequest\x1a\x14.shortener.v2.Member\x12U\n" +
+	"\fRemoveMember\x12!.shortener.v2.RemoveMemberRequest\x1a\".shortener.v2.RemoveMemberResponse\x12a\n" +
+	"\x10AdminSearchLinks\x12%.shortener.v2.AdminSearchLinksRequest\x1a&.shortener.v2.AdminSearchLinksResponse\x12a\n" +
+	"\x10AdminDisableLink\x12%.shortener.v2.AdminDisableLinkRequest\x1a&.shortener.v2.AdminDisableLinkResponse\x12^\n" +
+	"\x0fAdminDeleteLink\x12$.shortener.v2.AdminDeleteLinkRequest\x1a%.shortener.v2.AdminDeleteLinkResponse\x12F\n" +
+	"\aBanUser\x12\x1c.shortener.v2.BanUserRequest\x1a\x1d.shortener.v2.BanUserResponse\x12L\n" +
+	"\tUnbanUser\x12\x1e.shortener.v2.UnbanUserRequest\x1a\x1f.shortener.v2.UnbanUserResponse\x12^\n" +
+	"\x0fGetUserActivity\x12$.shortener.v2.GetUserActivityRequest\x1a%.shortener.v2.GetUserActivityResponseB\x03Z\x01.b\x06proto3"
+
+var (
+	file_shortener_v2_proto_rawDescOnce sync.Once
+	file_shortener_v2_proto_rawDescData []byte
+)
+
+func file_shortener_v2_proto_rawDescGZIP() []byte {
+	file_shortener_v2_proto_rawDescOnce.Do(func() {
+		file_shortener_v2_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shortener_v2_proto_rawDesc), len(file_shortener_v2_proto_rawDesc)))
+	})
+	return file_shortener_v2_proto_rawDescData
+}
+
+var file_shortener_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 70)
+var file_shortener_v2_proto_goTypes = []any{
+	(*BatchShortenRequest)(nil),       // 0: shortener.v2.BatchShortenRequest
+	(*BatchURLItem)(nil),              // 1: shortener.v2.BatchURLItem
+	(*BatchShortenResponse)(nil),      // 2: shortener.v2.BatchShortenResponse
+	(*BatchShortenResult)(nil),        // 3: shortener.v2.BatchShortenResult
+	(*ShortenRequest)(nil),            // 4: shortener.v2.ShortenRequest
+	(*ShortenResponse)(nil),           // 5: shortener.v2.ShortenResponse
+	(*ResolveRequest)(nil),            // 6: shortener.v2.ResolveRequest
+	(*ResolveResponse)(nil),           // 7: shortener.v2.ResolveResponse
+	(*GetUserURLsRequest)(nil),        // 8: shortener.v2.GetUserURLsRequest
+	(*GetUserURLsResponseItem)(nil),   // 9: shortener.v2.GetUserURLsResponseItem
+	(*LinkMetadata)(nil),              // 10: shortener.v2.LinkMetadata
+	(*GetUserURLsResponse)(nil),       // 11: shortener.v2.GetUserURLsResponse
+	(*DeleteUserURLsRequest)(nil),     // 12: shortener.v2.DeleteUserURLsRequest
+	(*DeleteUserURLsResponse)(nil),    // 13: shortener.v2.DeleteUserURLsResponse
+	(*RedirectRule)(nil),              // 14: shortener.v2.RedirectRule
+	(*GetRedirectRulesRequest)(nil),   // 15: shortener.v2.GetRedirectRulesRequest
+	(*GetRedirectRulesResponse)(nil),  // 16: shortener.v2.GetRedirectRulesResponse
+	(*SetRedirectRulesRequest)(nil),   // 17: shortener.v2.SetRedirectRulesRequest
+	(*SetRedirectRulesResponse)(nil),  // 18: shortener.v2.SetRedirectRulesResponse
+	(*SetPreviewRequest)(nil),         // 19: shortener.v2.SetPreviewRequest
+	(*SetPreviewResponse)(nil),        // 20: shortener.v2.SetPreviewResponse
+	(*ListBrokenURLsRequest)(nil),     // 21: shortener.v2.ListBrokenURLsRequest
+	(*BrokenURL)(nil),                 // 22: shortener.v2.BrokenURL
+	(*ListBrokenURLsResponse)(nil),    // 23: shortener.v2.ListBrokenURLsResponse
+	(*UpdateURLRequest)(nil),          // 24: shortener.v2.UpdateURLRequest
+	(*UpdateURLResponse)(nil),         // 25: shortener.v2.UpdateURLResponse
+	(*ListRevisionsRequest)(nil),      // 26: shortener.v2.ListRevisionsRequest
+	(*Revision)(nil),                  // 27: shortener.v2.Revision
+	(*ListRevisionsResponse)(nil),     // 28: shortener.v2.ListRevisionsResponse
+	(*GetLinkStatsRequest)(nil),       // 29: shortener.v2.GetLinkStatsRequest
+	(*StatsBucket)(nil),               // 30: shortener.v2.StatsBucket
+	(*StatsEntry)(nil),                // 31: shortener.v2.StatsEntry
+	(*GetLinkStatsResponse)(nil),      // 32: shortener.v2.GetLinkStatsResponse
+	(*GetStatsRequest)(nil),           // 33: shortener.v2.GetStatsRequest
+	(*TopLink)(nil),                   // 34: shortener.v2.TopLink
+	(*DayCount)(nil),                  // 35: shortener.v2.DayCount
+	(*GetStatsResponse)(nil),          // 36: shortener.v2.GetStatsResponse
+	(*ListTrashRequest)(nil),          // 37: shortener.v2.ListTrashRequest
+	(*TrashItem)(nil),                 // 38: shortener.v2.TrashItem
+	(*ListTrashResponse)(nil),         // 39: shortener.v2.ListTrashResponse
+	(*RestoreURLsRequest)(nil),        // 40: shortener.v2.RestoreURLsRequest
+	(*RestoreURLsResponse)(nil),       // 41: shortener.v2.RestoreURLsResponse
+	(*WatchClicksRequest)(nil),        // 42: shortener.v2.WatchClicksRequest
+	(*ClickEvent)(nil),                // 43: shortener.v2.ClickEvent
+	(*WatchClicksResponse)(nil),       // 44: shortener.v2.WatchClicksResponse
+	(*Organization)(nil),              // 45: shortener.v2.Organization
+	(*CreateOrganizationRequest)(nil), // 46: shortener.v2.CreateOrganizationRequest
+	(*ListOrganizationsRequest)(nil),  // 47: shortener.v2.ListOrganizationsRequest
+	(*ListOrganizationsResponse)(nil), // 48: shortener.v2.ListOrganizationsResponse
+	(*Member)(nil),                    // 49: shortener.v2.Member
+	(*ListMembersRequest)(nil),        // 50: shortener.v2.ListMembersRequest
+	(*ListMembersResponse)(nil),       // 51: shortener.v2.ListMembersResponse
+	(*SetMemberRequest)(nil),          // 52: shortener.v2.SetMemberRequest
+	(*RemoveMemberRequest)(nil),       // 53: shortener.v2.RemoveMemberRequest
+	(*RemoveMemberResponse)(nil),      // 54: shortener.v2.RemoveMemberResponse
+	(*AdminSearchLinksRequest)(nil),   // 55: shortener.v2.AdminSearchLinksRequest
+	(*AdminLink)(nil),                 // 56: shortener.v2.AdminLink
+	(*AdminSearchLinksResponse)(nil),  // 57: shortener.v2.AdminSearchLinksResponse
+	(*AdminDisableLinkRequest)(nil),   // 58: shortener.v2.AdminDisableLinkRequest
+	(*AdminDisableLinkResponse)(nil),  // 59: shortener.v2.AdminDisableLinkResponse
+	(*AdminDeleteLinkRequest)(nil),    // 60: shortener.v2.AdminDeleteLinkRequest
+	(*AdminDeleteLinkResponse)(nil),   // 61: shortener.v2.AdminDeleteLinkResponse
+	(*Ban)(nil),                       // 62: shortener.v2.Ban
+	(*BanUserRequest)(nil),            // 63: shortener.v2.BanUserRequest
+	(*BanUserResponse)(nil),           // 64: shortener.v2.BanUserResponse
+	(*UnbanUserRequest)(nil),          // 65: shortener.v2.UnbanUserRequest
+	(*UnbanUserResponse)(nil),         // 66: shortener.v2.UnbanUserResponse
+	(*GetUserActivityRequest)(nil),    // 67: shortener.v2.GetUserActivityRequest
+	(*GetUserActivityResponse)(nil),   // 68: shortener.v2.GetUserActivityResponse
+	nil,                               // 69: shortener.v2.LinkMetadata.OpenGraphEntry
+}
+var file_shortener_v2_proto_depIdxs = []int32{
+	1,  // 0: shortener.v2.BatchShortenRequest.urls:type_name -> shortener.v2.BatchURLItem
+	3,  // 1: shortener.v2.BatchShortenResponse.items:type_name -> shortener.v2.BatchShortenResult
+	10, // 2: shortener.v2.GetUserURLsResponseItem.metadata:type_name -> shortener.v2.LinkMetadata
+	69, // 3: shortener.v2.LinkMetadata.open_graph:type_name -> shortener.v2.LinkMetadata.OpenGraphEntry
+	9,  // 4: shortener.v2.GetUserURLsResponse.urls:type_name -> shortener.v2.GetUserURLsResponseItem
+	14, // 5: shortener.v2.GetRedirectRulesResponse.rules:type_name -> shortener.v2.RedirectRule
+	14, // 6: shortener.v2.SetRedirectRulesRequest.rules:type_name -> shortener.v2.RedirectRule
+	22, // 7: shortener.v2.ListBrokenURLsResponse.urls:type_name -> shortener.v2.BrokenURL
+	27, // 8: shortener.v2.ListRevisionsResponse.revisions:type_name -> shortener.v2.Revision
+	30, // 9: shortener.v2.GetLinkStatsResponse.buckets:type_name -> shortener.v2.StatsBucket
+	31, // 10: shortener.v2.GetLinkStatsResponse.referrers:type_name -> shortener.v2.StatsEntry
+	31, // 11: shortener.v2.GetLinkStatsResponse.browsers:type_name -> shortener.v2.StatsEntry
+	31, // 12: shortener.v2.GetLinkStatsResponse.os:type_name -> shortener.v2.StatsEntry
+	31, // 13: shortener.v2.GetLinkStatsResponse.countries:type_name -> shortener.v2.StatsEntry
+	35, // 14: shortener.v2.GetStatsResponse.created_per_day:type_name -> shortener.v2.DayCount
+	34, // 15: shortener.v2.GetStatsResponse.top_links:type_name -> shortener.v2.TopLink
+	38, // 16: shortener.v2.ListTrashResponse.items:type_name -> shortener.v2.TrashItem
+	43, // 17: shortener.v2.WatchClicksResponse.click:type_name -> shortener.v2.ClickEvent
+	45, // 18: shortener.v2.ListOrganizationsResponse.organizations:type_name -> shortener.v2.Organization
+	49, // 19: shortener.v2.ListMembersResponse.members:type_name -> shortener.v2.Member
+	56, // 20: shortener.v2.AdminSearchLinksResponse.links:type_name -> shortener.v2.AdminLink
+	62, // 21: shortener.v2.BanUserResponse.ban:type_name -> shortener.v2.Ban
+	62, // 22: shortener.v2.GetUserActivityResponse.ban:type_name -> shortener.v2.Ban
+	56, // 23: shortener.v2.GetUserActivityResponse.recent:type_name -> shortener.v2.AdminLink
+	45, // 24: shortener.v2.GetUserActivityResponse.organizations:type_name -> shortener.v2.Organization
+	4,  // 25: shortener.v2.ShortenerService.Shorten:input_type -> shortener.v2.ShortenRequest
+	6,  // 26: shortener.v2.ShortenerService.Resolve:input_type -> shortener.v2.ResolveRequest
+	0,  // 27: shortener.v2.ShortenerService.BatchShorten:input_type -> shortener.v2.BatchShortenRequest
+	8,  // 28: shortener.v2.ShortenerService.GetUserURLs:input_type -> shortener.v2.GetUserURLsRequest
+	12, // 29: shortener.v2.ShortenerService.DeleteUserURLs:input_type -> shortener.v2.DeleteUserURLsRequest
+	15, // 30: shortener.v2.ShortenerService.GetRedirectRules:input_type -> shortener.v2.GetRedirectRulesRequest
+	17, // 31: shortener.v2.ShortenerService.SetRedirectRules:input_type -> shortener.v2.SetRedirectRulesRequest
+	19, // 32: shortener.v2.ShortenerService.SetPreview:input_type -> shortener.v2.SetPreviewRequest
+	21, // 33: shortener.v2.ShortenerService.ListBrokenURLs:input_type -> shortener.v2.ListBrokenURLsRequest
+	24, // 34: shortener.v2.ShortenerService.UpdateURL:input_type -> shortener.v2.UpdateURLRequest
+	26, // 35: shortener.v2.ShortenerService.ListRevisions:input_type -> shortener.v2.ListRevisionsRequest
+	29, // 36: shortener.v2.ShortenerService.GetLinkStats:input_type -> shortener.v2.GetLinkStatsRequest
+	33, // 37: shortener.v2.ShortenerService.GetStats:input_type -> shortener.v2.GetStatsRequest
+	37, // 38: shortener.v2.ShortenerService.ListTrash:input_type -> shortener.v2.ListTrashRequest
+	40, // 39: shortener.v2.ShortenerService.RestoreURLs:input_type -> shortener.v2.RestoreURLsRequest
+	42, // 40: shortener.v2.ShortenerService.WatchClicks:input_type -> shortener.v2.WatchClicksRequest
+	46, // 41: shortener.v2.ShortenerService.CreateOrganization:input_type -> shortener.v2.CreateOrganizationRequest
+	47, // 42: shortener.v2.ShortenerService.ListOrganizations:input_type -> shortener.v2.ListOrganizationsRequest
+	50, // 43: shortener.v2.ShortenerService.ListMembers:input_type -> shortener.v2.ListMembersRequest
+	52, // 44: shortener.v2.ShortenerService.SetMember:input_type -> shortener.v2.SetMemberRequest
+	53, // 45: shortener.v2.ShortenerService.RemoveMember:input_type -> shortener.v2.RemoveMemberRequest
+	55, // 46: shortener.v2.ShortenerService.AdminSearchLinks:input_type -> shortener.v2.AdminSearchLinksRequest
+	58, // 47: shortener.v2.ShortenerService.AdminDisableLink:input_type -> shortener.v2.AdminDisableLinkRequest
+	60, // 48: shortener.v2.ShortenerService.AdminDeleteLink:input_type -> shortener.v2.AdminDeleteLinkRequest
+	63, // 49: shortener.v2.ShortenerService.BanUser:input_type -> shortener.v2.BanUserRequest
+	65, // 50: shortener.v2.ShortenerService.UnbanUser:input_type -> shortener.v2.UnbanUserRequest
+	67, // 51: shortener.v2.ShortenerService.GetUserActivity:input_type -> shortener.v2.GetUserActivityRequest
+	5,  // 52: shortener.v2.ShortenerService.Shorten:output_type -> shortener.v2.ShortenResponse
+	7,  // 53: shortener.v2.ShortenerService.Resolve:output_type -> shortener.v2.ResolveResponse
+	2,  // 54: shortener.v2.ShortenerService.BatchShorten:output_type -> shortener.v2.BatchShortenResponse
+	11, // 55: shortener.v2.ShortenerService.GetUserURLs:output_type -> shortener.v2.GetUserURLsResponse
+	13, // 56: shortener.v2.ShortenerService.DeleteUserURLs:output_type -> shortener.v2.DeleteUserURLsResponse
+	16, // 57: shortener.v2.ShortenerService.GetRedirectRules:output_type -> shortener.v2.GetRedirectRulesResponse
+	18, // 58: shortener.v2.ShortenerService.SetRedirectRules:output_type -> shortener.v2.SetRedirectRulesResponse
+	20, // 59: shortener.v2.ShortenerService.SetPreview:output_type -> shortener.v2.SetPreviewResponse
+	23, // 60: shortener.v2.ShortenerService.ListBrokenURLs:output_type -> shortener.v2.ListBrokenURLsResponse
+	25, // 61: shortener.v2.ShortenerService.UpdateURL:output_type -> shortener.v2.UpdateURLResponse
+	28, // 62: shortener.v2.ShortenerService.ListRevisions:output_type -> shortener.v2.ListRevisionsResponse
+	32, // 63: shortener.v2.ShortenerService.GetLinkStats:output_type -> shortener.v2.GetLinkStatsResponse
+	36, // 64: shortener.v2.ShortenerService.GetStats:output_type -> shortener.v2.GetStatsResponse
+	39, // 65: shortener.v2.ShortenerService.ListTrash:output_type -> shortener.v2.ListTrashResponse
+	41, // 66: shortener.v2.ShortenerService.RestoreURLs:output_type -> shortener.v2.RestoreURLsResponse
+	44, // 67: shortener.v2.ShortenerService.WatchClicks:output_type -> shortener.v2.WatchClicksResponse
+	45, // 68: shortener.v2.ShortenerService.CreateOrganization:output_type -> shortener.v2.Organization
+	48, // 69: shortener.v2.ShortenerService.ListOrganizations:output_type -> shortener.v2.ListOrganizationsResponse
+	51, // 70: shortener.v2.ShortenerService.ListMembers:output_type -> shortener.v2.ListMembersResponse
+	49, // 71: shortener.v2.ShortenerService.SetMember:output_type -> shortener.v2.Member
+	54, // 72: shortener.v2.ShortenerService.RemoveMember:output_type -> shortener.v2.RemoveMemberResponse
+	57, // 73: shortener.v2.ShortenerService.AdminSearchLinks:output_type -> shortener.v2.AdminSearchLinksResponse
+	59, // 74: shortener.v2.ShortenerService.AdminDisableLink:output_type -> shortener.v2.AdminDisableLinkResponse
+	61, // 75: shortener.v2.ShortenerService.AdminDeleteLink:output_type -> shortener.v2.AdminDeleteLinkResponse
+	64, // 76: shortener.v2.ShortenerService.BanUser:output_type -> shortener.v2.BanUserResponse
+	66, // 77: shortener.v2.ShortenerService.UnbanUser:output_type -> shortener.v2.UnbanUserResponse
+	68, // 78: shortener.v2.ShortenerService.GetUserActivity:output_type -> shortener.v2.GetUserActivityResponse
+	52, // [52:79] is the sub-list for method output_type
+	25, // [25:52] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_shortener_v2_proto_init() }
+func file_shortener_v2_proto_init() {
+	if File_shortener_v2_proto != nil {
+		return
+	}
+	file_shortener_v2_proto_msgTypes[44].OneofWrappers = []any{
+		(*WatchClicksResponse_Click)(nil),
+		(*WatchClicksResponse_Heartbeat)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -746,7 +4577,7 @@ func file_shortener_v2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shortener_v2_proto_rawDesc), len(file_shortener_v2_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   70,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
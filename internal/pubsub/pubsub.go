@@ -0,0 +1,134 @@
+// Package pubsub реализует внутрипроцессную рассылку событий по темам.
+// У каждого подписчика свой ограниченный буфер; подписчик, не успевающий
+// читать события, отключается, чтобы не задерживать публикацию.
+package pubsub
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Hub рассылает события подписчикам темы.
+type Hub[T any] struct {
+	bufferSize int
+
+	mu   sync.RWMutex
+	subs map[string]map[*Subscription[T]]struct{}
+
+	evicted atomic.Uint64
+}
+
+// New создаёт Hub с буфером bufferSize событий на подписчика.
+func New[T any](bufferSize int) *Hub[T] {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &Hub[T]{
+		bufferSize: bufferSize,
+		subs:       make(map[string]map[*Subscription[T]]struct{}),
+	}
+}
+
+// Subscription — подписка на одну тему.
+type Subscription[T any] struct {
+	hub   *Hub[T]
+	topic string
+
+	events chan T
+	done   chan struct{}
+	once   sync.Once
+
+	evicted atomic.Bool
+}
+
+// Subscribe подписывает на события темы. Подписку нужно закрыть через Close.
+func (h *Hub[T]) Subscribe(topic string) *Subscription[T] {
+	sub := &Subscription[T]{
+		hub:    h,
+		topic:  topic,
+		events: make(chan T, h.bufferSize),
+		done:   make(chan struct{}),
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[*Subscription[T]]struct{})
+	}
+	h.subs[topic][sub] = struct{}{}
+	return sub
+}
+
+// Publish передаёт событие всем подписчикам темы без блокировки.
+// Подписчики с заполненным буфером отключаются.
+func (h *Hub[T]) Publish(topic string, event T) {
+	var slow []*Subscription[T]
+
+	h.mu.RLock()
+	for sub := range h.subs[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, sub := range slow {
+		if sub.evicted.CompareAndSwap(false, true) {
+			h.evicted.Add(1)
+		}
+		sub.Close()
+	}
+}
+
+// Subscribers возвращает текущее количество подписчиков.
+func (h *Hub[T]) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	n := 0
+	for _, subs := range h.subs {
+		n += len(subs)
+	}
+	return n
+}
+
+// Evicted возвращает количество подписчиков, отключённых за медленное чтение.
+func (h *Hub[T]) Evicted() uint64 {
+	return h.evicted.Load()
+}
+
+func (h *Hub[T]) remove(sub *Subscription[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subs[sub.topic], sub)
+	if len(h.subs[sub.topic]) == 0 {
+		delete(h.subs, sub.topic)
+	}
+}
+
+// Events возвращает канал событий подписки. Канал не закрывается:
+// об окончании подписки сообщает Done.
+func (s *Subscription[T]) Events() <-chan T {
+	return s.events
+}
+
+// Done закрывается, когда подписка завершена вызовом Close или отключением.
+func (s *Subscription[T]) Done() <-chan struct{} {
+	return s.done
+}
+
+// Evicted сообщает, была ли подписка отключена из-за переполнения буфера.
+func (s *Subscription[T]) Evicted() bool {
+	return s.evicted.Load()
+}
+
+// Close отменяет подписку. Повторные вызовы безопасны.
+func (s *Subscription[T]) Close() {
+	s.once.Do(func() {
+		s.hub.remove(s)
+		close(s.done)
+	})
+}
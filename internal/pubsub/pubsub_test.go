@@ -0,0 +1,62 @@
+package pubsub_test
+
+import (
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishDeliversToTopicOnly(t *testing.T) {
+	hub := pubsub.New[int](4)
+	alice := hub.Subscribe("alice")
+	defer alice.Close()
+	bob := hub.Subscribe("bob")
+	defer bob.Close()
+
+	hub.Publish("alice", 1)
+	hub.Publish("alice", 2)
+
+	assert.Equal(t, 1, <-alice.Events())
+	assert.Equal(t, 2, <-alice.Events())
+	assert.Empty(t, bob.Events())
+	assert.Equal(t, 2, hub.Subscribers())
+}
+
+func TestSlowSubscriberIsEvicted(t *testing.T) {
+	hub := pubsub.New[int](2)
+	slow := hub.Subscribe("alice")
+	fast := hub.Subscribe("alice")
+	defer fast.Close()
+
+	for i := 0; i < 3; i++ {
+		hub.Publish("alice", i)
+		<-fast.Events()
+	}
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("slow subscriber was not evicted")
+	}
+	assert.True(t, slow.Evicted())
+	assert.False(t, fast.Evicted())
+	assert.Equal(t, uint64(1), hub.Evicted())
+	assert.Equal(t, 1, hub.Subscribers())
+
+	// Отключённый подписчик больше не получает события, а публикация не блокируется.
+	hub.Publish("alice", 42)
+	assert.Equal(t, 42, <-fast.Events())
+}
+
+func TestCloseIsIdempotent(t *testing.T) {
+	hub := pubsub.New[string](1)
+	sub := hub.Subscribe("alice")
+	sub.Close()
+	sub.Close()
+
+	hub.Publish("alice", "ignored")
+	assert.Equal(t, 0, hub.Subscribers())
+	assert.False(t, sub.Evicted())
+	assert.Empty(t, sub.Events())
+}
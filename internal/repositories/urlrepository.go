@@ -2,13 +2,18 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Totarae/URLShortener/internal/analytics"
 	"github.com/Totarae/URLShortener/internal/database"
+	"github.com/Totarae/URLShortener/internal/hll"
 	"github.com/Totarae/URLShortener/internal/model"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // URLRepositoryInterface определяет методы репозитория и с хранилищем URL.
@@ -23,8 +28,57 @@ type URLRepositoryInterface interface {
 	CountURLs(ctx context.Context) (int, error)
 	CountUsers(ctx context.Context) (int, error)
 	GetStats(ctx context.Context) (urlCount int, userCount int, err error)
+	UpdateRules(ctx context.Context, shorten, userID string, rules []model.RedirectRule) (bool, error)
+	SetForcePreview(ctx context.Context, shorten, userID string, enabled bool) (bool, error)
+	ForEachActiveURL(ctx context.Context, fn func(*model.URLObject) error) error
+	DisableURLs(ctx context.Context, ids []string, reason string) error
+	SaveHealth(ctx context.Context, results map[string]model.LinkHealth) error
+	GetBrokenURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error)
+	SaveMetadata(ctx context.Context, shorten, origin string, meta model.LinkMetadata) (bool, error)
+	UpdateOrigin(ctx context.Context, shorten, userID, origin string) (model.Revision, bool, error)
+	GetRevisions(ctx context.Context, shorten string) ([]model.Revision, error)
+	GetDeletedURLsByUserID(ctx context.Context, userID string, since time.Time) ([]*model.URLObject, error)
+	RestoreURLs(ctx context.Context, ids []string, userID string, since time.Time) ([]string, error)
+	SaveClicks(ctx context.Context, clicks []model.Click) error
+	GetClickSummary(ctx context.Context, shorten string, from, to time.Time, interval string, includeBots bool) (model.ClickSummary, error)
+	CountLinks(ctx context.Context) (model.LinkCounts, error)
+	CountCreatedByDay(ctx context.Context, since time.Time) (map[time.Time]int64, error)
+	TopClickedURLs(ctx context.Context, since time.Time, limit int, includeBots bool) ([]model.TopLink, error)
+	GetSketches(ctx context.Context, shorten string, from, to time.Time) (map[time.Time]*hll.Sketch, error)
+	CreateAPIKey(ctx context.Context, key model.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*model.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id string) (bool, error)
+	CreateAccount(ctx context.Context, acc model.Account) (bool, error)
+	GetAccountByEmail(ctx context.Context, email string) (*model.Account, error)
+	CreateSession(ctx context.Context, s model.Session) error
+	GetSession(ctx context.Context, id string) (*model.Session, error)
+	DeleteSession(ctx context.Context, id string) error
+	ReassignUser(ctx context.Context, from, to string) (int64, error)
+	ResolveIdentity(ctx context.Context, issuer, subject, newUserID string) (string, error)
+	CreateOrganization(ctx context.Context, org model.Organization, owner model.Membership) error
+	GetMembership(ctx context.Context, orgID, userID string) (*model.Membership, error)
+	ListWorkspaces(ctx context.Context, userID string) ([]model.Workspace, error)
+	ListMembers(ctx context.Context, orgID string) ([]model.Membership, error)
+	SetMembership(ctx context.Context, m model.Membership) error
+	DeleteMembership(ctx context.Context, orgID, userID string) (bool, error)
+	SearchURLs(ctx context.Context, q model.LinkSearch) ([]*model.URLObject, error)
+	CountUserLinks(ctx context.Context, userID string) (model.LinkCounts, error)
+	SetBan(ctx context.Context, ban model.Ban) error
+	GetBan(ctx context.Context, userID string) (*model.Ban, error)
+	DeleteBan(ctx context.Context, userID string) (bool, error)
 }
 
+var (
+	// ErrShortenTaken — сокращённый идентификатор уже занят ссылкой с другим адресом.
+	ErrShortenTaken = errors.New("short code is taken by another destination")
+	// ErrOriginExists — для адреса назначения уже существует другая короткая ссылка.
+	ErrOriginExists = errors.New("origin already has a short code")
+)
+
+// uniqueViolation — код ошибки PostgreSQL при нарушении уникальности.
+const uniqueViolation = "23505"
+
 // URLRepository реализует URLRepositoryInterface с использованием PostgreSQL.
 type URLRepository struct {
 	DB database.DBInterface
@@ -52,7 +106,7 @@ func NewURLRepository(db database.DBInterface) *URLRepository {
 func (r *URLRepository) SaveURL(ctx context.Context, urlObj *model.URLObject) error {
 	query := `INSERT INTO urls (origin, shorten, created, user_id) 
               VALUES ($1, $2, $3, $4) 
-              ON CONFLICT (origin) DO NOTHING 
+              ON CONFLICT DO NOTHING 
               RETURNING id`
 
 	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, urlObj.Origin, urlObj.Shorten, time.Now(), urlObj.UserID).Scan(&urlObj.ID)
@@ -63,6 +117,10 @@ func (r *URLRepository) SaveURL(ctx context.Context, urlObj *model.URLObject) er
 			if lookupErr != nil {
 				return fmt.Errorf("failed to fetch existing short URL: %w", lookupErr)
 			}
+			if existingShortURL == "" {
+				// Конфликт не по origin: идентификатор занят ссылкой, адрес которой изменили
+				return ErrShortenTaken
+			}
 			urlObj.Shorten = existingShortURL
 			return pgx.ErrNoRows // Нам нужно дать понять обработчику, что это не новая запись
 		}
@@ -71,22 +129,51 @@ func (r *URLRepository) SaveURL(ctx context.Context, urlObj *model.URLObject) er
 	return nil
 }
 
-// GetURL извлекает оригинальный URL по сокращённому идентификатору.
+// GetURL извлекает запись по сокращённому идентификатору.
+// Если ссылка не найдена, возвращает nil без ошибки.
 func (r *URLRepository) GetURL(ctx context.Context, shorten string) (*model.URLObject, error) {
-	query := `SELECT id, origin, shorten, created, is_deleted  FROM urls WHERE shorten = $1`
+	query := `SELECT id, origin, shorten, created, COALESCE(user_id, ''), is_deleted, COALESCE(rules, '[]'::jsonb), force_preview,
+                     is_disabled, COALESCE(disabled_reason, ''), metadata
+              FROM urls WHERE shorten = $1`
 	urlObj := &model.URLObject{}
 	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, shorten).Scan(
-		&urlObj.ID, &urlObj.Origin, &urlObj.Shorten, &urlObj.Created, &urlObj.IsDeleted,
+		&urlObj.ID, &urlObj.Origin, &urlObj.Shorten, &urlObj.Created, &urlObj.UserID, &urlObj.IsDeleted, &urlObj.Rules,
+		&urlObj.ForcePreview, &urlObj.IsDisabled, &urlObj.DisabledReason, &urlObj.Metadata,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("URL not found: %w", err)
+			return nil, nil
 		}
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 	return urlObj, nil
 }
 
+// UpdateRules заменяет правила перенаправления ссылки пользователя.
+// Возвращает false, если ссылка не найдена или принадлежит другому пользователю.
+func (r *URLRepository) UpdateRules(ctx context.Context, shorten, userID string, rules []model.RedirectRule) (bool, error) {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode rules: %w", err)
+	}
+	query := `UPDATE urls SET rules = $1::jsonb WHERE shorten = $2 AND user_id = $3 AND is_deleted = FALSE`
+	tag, err := r.DB.(*database.DB).Pool.Exec(ctx, query, string(data), shorten, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update rules: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetForcePreview включает или выключает обязательный предпросмотр ссылки пользователя.
+func (r *URLRepository) SetForcePreview(ctx context.Context, shorten, userID string, enabled bool) (bool, error) {
+	query := `UPDATE urls SET force_preview = $1 WHERE shorten = $2 AND user_id = $3 AND is_deleted = FALSE`
+	tag, err := r.DB.(*database.DB).Pool.Exec(ctx, query, enabled, shorten, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update preview flag: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
 // Ping проверяет доступность базы данных.
 func (r *URLRepository) Ping(ctx context.Context) error {
 	_, err := r.DB.(*database.DB).Pool.Exec(ctx, "SELECT 1")
@@ -94,6 +181,8 @@ func (r *URLRepository) Ping(ctx context.Context) error {
 }
 
 // SaveBatchURLs сохраняет список URL-объектов в базе данных в рамках транзакции.
+// Если идентификатор занят, транзакция откатывается с ErrShortenTaken; ID выданы
+// только ссылкам перед конфликтной.
 func (r *URLRepository) SaveBatchURLs(ctx context.Context, urlObjs []*model.URLObject) error {
 	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
 	if err != nil {
@@ -101,9 +190,13 @@ func (r *URLRepository) SaveBatchURLs(ctx context.Context, urlObjs []*model.URLO
 	}
 	defer tx.Rollback(ctx)
 
-	query := `INSERT INTO urls (origin, shorten, created, user_id) VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO urls (origin, shorten, created, user_id) VALUES ($1, $2, $3, $4)
+              ON CONFLICT (shorten) DO NOTHING RETURNING id`
 	for _, obj := range urlObjs {
 		err := tx.QueryRow(ctx, query, obj.Origin, obj.Shorten, obj.Created, obj.UserID).Scan(&obj.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrShortenTaken
+		}
 		if err != nil {
 			return fmt.Errorf("failed to insert batch URLs: %w", err)
 		}
@@ -131,7 +224,7 @@ func (r *URLRepository) GetShortURLByOrigin(ctx context.Context, originalURL str
 
 // GetURLsByUserID возвращает все сокращённые ссылки пользователя.
 func (r *URLRepository) GetURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error) {
-	query := `SELECT id, origin, shorten, created FROM urls WHERE user_id = $1`
+	query := `SELECT id, origin, shorten, created, metadata, is_disabled FROM urls WHERE user_id = $1 AND is_deleted = FALSE`
 	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query URLs by user: %w", err)
@@ -141,7 +234,7 @@ func (r *URLRepository) GetURLsByUserID(ctx context.Context, userID string) ([]*
 	var results []*model.URLObject
 	for rows.Next() {
 		obj := &model.URLObject{}
-		err := rows.Scan(&obj.ID, &obj.Origin, &obj.Shorten, &obj.Created)
+		err := rows.Scan(&obj.ID, &obj.Origin, &obj.Shorten, &obj.Created, &obj.Metadata, &obj.IsDisabled)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
@@ -160,7 +253,7 @@ func (r *URLRepository) MarkURLsAsDeleted(ctx context.Context, ids []string, use
 	// Подготавливаем SQL для batch-обновления
 	query := `
 		UPDATE urls 
-		SET is_deleted = TRUE 
+		SET is_deleted = TRUE, deleted_at = CASE WHEN is_deleted THEN deleted_at ELSE NOW() END
 		WHERE shorten = ANY($1) AND user_id = $2
 	`
 	_, err := r.DB.(*database.DB).Pool.Exec(ctx, query, ids, userID)
@@ -180,6 +273,847 @@ func (r *URLRepository) CountURLs(ctx context.Context) (int, error) {
 // CountUsers количество пользователей
 func (r *URLRepository) CountUsers(ctx context.Context) (int, error) {
 	var count int
-	err := r.DB.(*database.DB).Pool.QueryRow(ctx, "SELECT COUNT(DISTINCT user_id) FROM urls WHERE user_id IS NOT NULL").Scan(&count)
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, "SELECT COUNT(DISTINCT user_id) FROM urls WHERE user_id IS NOT NULL AND user_id NOT LIKE 'org:%'").Scan(&count)
 	return count, err
 }
+
+// CountLinks возвращает количество ссылок по состояниям и число владельцев.
+// Отключённые ссылки, находящиеся в корзине, считаются удалёнными.
+func (r *URLRepository) CountLinks(ctx context.Context) (model.LinkCounts, error) {
+	var c model.LinkCounts
+	query := `SELECT COUNT(*),
+                     COUNT(*) FILTER (WHERE NOT is_deleted AND NOT is_disabled),
+                     COUNT(*) FILTER (WHERE is_deleted),
+                     COUNT(*) FILTER (WHERE is_disabled AND NOT is_deleted),
+                     COUNT(DISTINCT user_id) FILTER (WHERE user_id IS NOT NULL AND user_id <> '' AND user_id NOT LIKE 'org:%')
+              FROM urls`
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query).Scan(&c.Total, &c.Active, &c.Deleted, &c.Disabled, &c.Users)
+	if err != nil {
+		return c, fmt.Errorf("failed to count links: %w", err)
+	}
+	return c, nil
+}
+
+// CountCreatedByDay возвращает количество созданных ссылок по дням (UTC), начиная с since.
+func (r *URLRepository) CountCreatedByDay(ctx context.Context, since time.Time) (map[time.Time]int64, error) {
+	query := `SELECT date_trunc('day', created), COUNT(*) FROM urls WHERE created >= $1 GROUP BY 1`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count created links: %w", err)
+	}
+	defer rows.Close()
+
+	result := map[time.Time]int64{}
+	for rows.Next() {
+		var (
+			day   time.Time
+			count int64
+		)
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		result[day.UTC()] = count
+	}
+	return result, rows.Err()
+}
+
+// TopClickedURLs возвращает ссылки с наибольшим числом переходов начиная с since.
+func (r *URLRepository) TopClickedURLs(ctx context.Context, since time.Time, limit int, includeBots bool) ([]model.TopLink, error) {
+	query := `SELECT c.shorten, COALESCE(u.origin, ''), COUNT(*) AS clicks
+              FROM clicks c LEFT JOIN urls u ON u.shorten = c.shorten
+              WHERE c.clicked_at >= $1 AND ($3 OR NOT c.is_bot)
+              GROUP BY c.shorten, u.origin
+              ORDER BY clicks DESC, c.shorten
+              LIMIT $2`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, since.UTC(), limit, includeBots)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top links: %w", err)
+	}
+	defer rows.Close()
+
+	var result []model.TopLink
+	for rows.Next() {
+		var l model.TopLink
+		if err := rows.Scan(&l.Short, &l.Origin, &l.Clicks); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		result = append(result, l)
+	}
+	return result, rows.Err()
+}
+
+// ForEachActiveURL вызывает fn для каждой неудалённой и неотключённой ссылки.
+func (r *URLRepository) ForEachActiveURL(ctx context.Context, fn func(*model.URLObject) error) error {
+	query := `SELECT id, origin, shorten, created, COALESCE(user_id, '')
+              FROM urls WHERE is_deleted = FALSE AND is_disabled = FALSE`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query active URLs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		obj := &model.URLObject{}
+		if err := rows.Scan(&obj.ID, &obj.Origin, &obj.Shorten, &obj.Created, &obj.UserID); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+		if err := fn(obj); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// DisableURLs отключает ссылки с указанием причины.
+func (r *URLRepository) DisableURLs(ctx context.Context, ids []string, reason string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `UPDATE urls SET is_disabled = TRUE, disabled_reason = $1 WHERE shorten = ANY($2)`
+	if _, err := r.DB.(*database.DB).Pool.Exec(ctx, query, reason, ids); err != nil {
+		return fmt.Errorf("failed to disable URLs: %w", err)
+	}
+	return nil
+}
+
+// SaveHealth сохраняет результаты проверки доступности ссылок в рамках транзакции.
+func (r *URLRepository) SaveHealth(ctx context.Context, results map[string]model.LinkHealth) error {
+	if len(results) == 0 {
+		return nil
+	}
+	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE urls SET health_status = $1, health_latency_ms = $2, health_error = $3, health_checked_at = $4
+              WHERE shorten = $5`
+	for short, h := range results {
+		if _, err := tx.Exec(ctx, query, h.Status, h.LatencyMs, h.Error, h.CheckedAt, short); err != nil {
+			return fmt.Errorf("failed to save link health: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetBrokenURLsByUserID возвращает ссылки пользователя, последняя проверка которых
+// завершилась ошибкой или статусом 4xx/5xx.
+func (r *URLRepository) GetBrokenURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error) {
+	query := `SELECT id, origin, shorten, created, health_status, health_latency_ms, COALESCE(health_error, ''), health_checked_at
+              FROM urls
+              WHERE user_id = $1 AND is_deleted = FALSE AND health_checked_at IS NOT NULL
+                AND (health_status = 0 OR health_status >= 400)
+              ORDER BY health_checked_at DESC`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query broken URLs: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*model.URLObject
+	for rows.Next() {
+		obj := &model.URLObject{Health: &model.LinkHealth{}}
+		err := rows.Scan(&obj.ID, &obj.Origin, &obj.Shorten, &obj.Created,
+			&obj.Health.Status, &obj.Health.LatencyMs, &obj.Health.Error, &obj.Health.CheckedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, obj)
+	}
+	return results, rows.Err()
+}
+
+// SaveMetadata сохраняет метаданные страницы origin, если ссылка всё ещё ведёт на неё.
+// Возвращает false, если адрес назначения успел измениться.
+func (r *URLRepository) SaveMetadata(ctx context.Context, shorten, origin string, meta model.LinkMetadata) (bool, error) {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	query := `UPDATE urls SET metadata = $1::jsonb WHERE shorten = $2 AND origin = $3`
+	tag, err := r.DB.(*database.DB).Pool.Exec(ctx, query, string(data), shorten, origin)
+	if err != nil {
+		return false, fmt.Errorf("failed to save metadata: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// UpdateOrigin меняет адрес назначения ссылки пользователя и записывает ревизию.
+// Возвращает false, если ссылка не найдена или принадлежит другому пользователю.
+// Сведения о доступности и метаданные прежнего адреса сбрасываются.
+func (r *URLRepository) UpdateOrigin(ctx context.Context, shorten, userID, origin string) (model.Revision, bool, error) {
+	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
+	if err != nil {
+		return model.Revision{}, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rev := model.Revision{ChangedBy: userID, ChangedAt: time.Now(), NewURL: origin}
+	err = tx.QueryRow(ctx,
+		`SELECT origin FROM urls WHERE shorten = $1 AND user_id = $2 AND is_deleted = FALSE FOR UPDATE`,
+		shorten, userID,
+	).Scan(&rev.OldURL)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Revision{}, false, nil
+		}
+		return model.Revision{}, false, fmt.Errorf("failed to lock URL: %w", err)
+	}
+	if rev.OldURL == origin {
+		return rev, true, nil
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE urls SET origin = $1, metadata = NULL,
+                  health_status = NULL, health_latency_ms = NULL, health_error = NULL, health_checked_at = NULL
+              WHERE shorten = $2`, origin, shorten)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return model.Revision{}, false, ErrOriginExists
+		}
+		return model.Revision{}, false, fmt.Errorf("failed to update origin: %w", err)
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO url_revisions (shorten, changed_by, changed_at, old_origin, new_origin) VALUES ($1, $2, $3, $4, $5)`,
+		shorten, rev.ChangedBy, rev.ChangedAt, rev.OldURL, rev.NewURL,
+	)
+	if err != nil {
+		return model.Revision{}, false, fmt.Errorf("failed to record revision: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return model.Revision{}, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return rev, true, nil
+}
+
+// GetRevisions возвращает историю изменений адреса назначения от старых к новым.
+func (r *URLRepository) GetRevisions(ctx context.Context, shorten string) ([]model.Revision, error) {
+	query := `SELECT changed_by, changed_at, old_origin, new_origin FROM url_revisions
+              WHERE shorten = $1 ORDER BY changed_at, id`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, shorten)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []model.Revision
+	for rows.Next() {
+		var rev model.Revision
+		if err := rows.Scan(&rev.ChangedBy, &rev.ChangedAt, &rev.OldURL, &rev.NewURL); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+	return revisions, rows.Err()
+}
+
+// GetDeletedURLsByUserID возвращает ссылки пользователя, удалённые не раньше since.
+func (r *URLRepository) GetDeletedURLsByUserID(ctx context.Context, userID string, since time.Time) ([]*model.URLObject, error) {
+	query := `SELECT id, origin, shorten, created, deleted_at FROM urls
+              WHERE user_id = $1 AND is_deleted = TRUE AND deleted_at >= $2
+              ORDER BY deleted_at DESC`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deleted URLs: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*model.URLObject
+	for rows.Next() {
+		obj := &model.URLObject{IsDeleted: true, UserID: userID}
+		if err := rows.Scan(&obj.ID, &obj.Origin, &obj.Shorten, &obj.Created, &obj.DeletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, obj)
+	}
+	return results, rows.Err()
+}
+
+// RestoreURLs снимает пометку удаления со ссылок пользователя, удалённых не раньше since.
+// Возвращает идентификаторы восстановленных ссылок.
+func (r *URLRepository) RestoreURLs(ctx context.Context, ids []string, userID string, since time.Time) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `UPDATE urls SET is_deleted = FALSE, deleted_at = NULL
+              WHERE shorten = ANY($1) AND user_id = $2 AND is_deleted = TRUE AND deleted_at >= $3
+              RETURNING shorten`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, ids, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore URLs: %w", err)
+	}
+	defer rows.Close()
+
+	var restored []string
+	for rows.Next() {
+		var short string
+		if err := rows.Scan(&short); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		restored = append(restored, short)
+	}
+	return restored, rows.Err()
+}
+
+// SaveClicks записывает пачку событий переходов через COPY и в той же
+// транзакции объединяет дневные скетчи уникальных посетителей.
+func (r *URLRepository) SaveClicks(ctx context.Context, clicks []model.Click) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	columns := []string{"shorten", "clicked_at", "referrer", "user_agent", "ip_hash", "variant", "country", "is_bot"}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"clicks"}, columns,
+		pgx.CopyFromSlice(len(clicks), func(i int) ([]any, error) {
+			c := clicks[i]
+			return []any{c.Short, c.Time.UTC(), c.Referrer, c.UserAgent, c.IPHash, c.Variant, c.Country, c.Bot}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save clicks: %w", err)
+	}
+	for key, sketch := range analytics.Sketches(clicks) {
+		if err := mergeSketch(ctx, tx, key, sketch); err != nil {
+			return fmt.Errorf("failed to merge visitor sketch: %w", err)
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// mergeSketch объединяет дневной скетч посетителей с сохранённым.
+// Строка блокируется на время объединения, поэтому параллельные записи
+// с других экземпляров сервиса не теряют данные.
+func mergeSketch(ctx context.Context, tx pgx.Tx, key analytics.SketchKey, sketch *hll.Sketch) error {
+	data, err := sketch.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `INSERT INTO click_sketches (shorten, day, sketch) VALUES ($1, $2, $3)
+              ON CONFLICT (shorten, day) DO NOTHING`, key.Short, key.Day, data)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 1 {
+		return nil
+	}
+
+	var stored []byte
+	err = tx.QueryRow(ctx, `SELECT sketch FROM click_sketches WHERE shorten = $1 AND day = $2 FOR UPDATE`,
+		key.Short, key.Day).Scan(&stored)
+	if err != nil {
+		return err
+	}
+	old, err := hll.Decode(stored)
+	if err != nil {
+		return err
+	}
+	sketch.Merge(old)
+	if data, err = sketch.MarshalBinary(); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE click_sketches SET sketch = $3 WHERE shorten = $1 AND day = $2`,
+		key.Short, key.Day, data)
+	return err
+}
+
+// GetSketches возвращает дневные скетчи посетителей ссылки за дни,
+// пересекающиеся с периодом [from, to). Для analytics.AllLinks — скетчи всего сервиса.
+func (r *URLRepository) GetSketches(ctx context.Context, shorten string, from, to time.Time) (map[time.Time]*hll.Sketch, error) {
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, `SELECT day, sketch FROM click_sketches
+              WHERE shorten = $1 AND day >= $2 AND day < $3`, shorten, analytics.Day(from), to.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query visitor sketches: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[time.Time]*hll.Sketch)
+	for rows.Next() {
+		var (
+			day  time.Time
+			data []byte
+		)
+		if err := rows.Scan(&day, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan visitor sketch: %w", err)
+		}
+		sketch, err := hll.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		days[analytics.Day(day)] = sketch
+	}
+	return days, rows.Err()
+}
+
+// GetClickSummary агрегирует переходы по ссылке за период [from, to).
+// interval — единица date_trunc ("hour" или "day"). Переходы ботов попадают
+// в сводку, только если includeBots, но всегда учитываются в summary.Bots.
+func (r *URLRepository) GetClickSummary(ctx context.Context, shorten string, from, to time.Time, interval string, includeBots bool) (model.ClickSummary, error) {
+	summary := model.NewClickSummary()
+	pool := r.DB.(*database.DB).Pool
+	from, to = from.UTC(), to.UTC()
+
+	err := pool.QueryRow(ctx, `SELECT count(*) FROM clicks
+              WHERE shorten = $1 AND clicked_at >= $2 AND clicked_at < $3 AND is_bot`,
+		shorten, from, to).Scan(&summary.Bots)
+	if err != nil {
+		return summary, fmt.Errorf("failed to count bot clicks: %w", err)
+	}
+
+	rows, err := pool.Query(ctx, `SELECT date_trunc($4, clicked_at), count(*) FROM clicks
+              WHERE shorten = $1 AND clicked_at >= $2 AND clicked_at < $3 AND ($5 OR NOT is_bot) GROUP BY 1`,
+		shorten, from, to, interval, includeBots)
+	if err != nil {
+		return summary, fmt.Errorf("failed to query click buckets: %w", err)
+	}
+	for rows.Next() {
+		var (
+			start time.Time
+			count int64
+		)
+		if err := rows.Scan(&start, &count); err != nil {
+			rows.Close()
+			return summary, fmt.Errorf("failed to scan click bucket: %w", err)
+		}
+		summary.Buckets[start.UTC()] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return summary, err
+	}
+
+	dimensions := []struct {
+		column string
+		dst    map[string]int64
+	}{
+		{"referrer", summary.Referrers},
+		{"user_agent", summary.UserAgents},
+		{"country", summary.Countries},
+	}
+	for _, d := range dimensions {
+		// Имя столбца берётся из списка выше, а не из запроса клиента.
+		query := fmt.Sprintf(`SELECT COALESCE(%[1]s, ''), count(*) FROM clicks
+              WHERE shorten = $1 AND clicked_at >= $2 AND clicked_at < $3 AND ($4 OR NOT is_bot) GROUP BY 1`, d.column)
+		if err := r.collectCounts(ctx, query, d.dst, shorten, from, to, includeBots); err != nil {
+			return summary, fmt.Errorf("failed to query clicks by %s: %w", d.column, err)
+		}
+	}
+	return summary, nil
+}
+
+func (r *URLRepository) collectCounts(ctx context.Context, query string, dst map[string]int64, args ...any) error {
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			name  string
+			count int64
+		)
+		if err := rows.Scan(&name, &count); err != nil {
+			return err
+		}
+		dst[name] += count
+	}
+	return rows.Err()
+}
+
+// CreateAPIKey сохраняет новый ключ API.
+func (r *URLRepository) CreateAPIKey(ctx context.Context, key model.APIKey) error {
+	query := `INSERT INTO api_keys (id, user_id, name, hash, scopes, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.DB.(*database.DB).Pool.Exec(ctx, query, key.ID, key.UserID, key.Name, key.Hash, key.Scopes, key.Created)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKey возвращает ключ API по идентификатору, включая отозванные; nil, если ключа нет.
+func (r *URLRepository) GetAPIKey(ctx context.Context, id string) (*model.APIKey, error) {
+	query := `SELECT id, user_id, name, hash, scopes, created_at, revoked_at FROM api_keys WHERE id = $1`
+	key := &model.APIKey{}
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, id).Scan(
+		&key.ID, &key.UserID, &key.Name, &key.Hash, &key.Scopes, &key.Created, &key.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return key, nil
+}
+
+// ListAPIKeys возвращает неотозванные ключи API пользователя в порядке создания.
+func (r *URLRepository) ListAPIKeys(ctx context.Context, userID string) ([]model.APIKey, error) {
+	query := `SELECT id, user_id, name, hash, scopes, created_at FROM api_keys
+              WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at, id`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []model.APIKey
+	for rows.Next() {
+		var key model.APIKey
+		if err := rows.Scan(&key.ID, &key.UserID, &key.Name, &key.Hash, &key.Scopes, &key.Created); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey отзывает ключ API пользователя.
+// Возвращает false, если активного ключа с таким идентификатором у пользователя нет.
+func (r *URLRepository) RevokeAPIKey(ctx context.Context, userID, id string) (bool, error) {
+	query := `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+	tag, err := r.DB.(*database.DB).Pool.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CreateAccount сохраняет учётную запись. Возвращает false, если адрес уже занят.
+func (r *URLRepository) CreateAccount(ctx context.Context, acc model.Account) (bool, error) {
+	query := `INSERT INTO accounts (id, email, password_hash, created_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.DB.(*database.DB).Pool.Exec(ctx, query, acc.ID, acc.Email, acc.PasswordHash, acc.Created)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to insert account: %w", err)
+	}
+	return true, nil
+}
+
+// GetAccountByEmail возвращает учётную запись по адресу; nil, если её нет.
+func (r *URLRepository) GetAccountByEmail(ctx context.Context, email string) (*model.Account, error) {
+	query := `SELECT id, email, password_hash, created_at FROM accounts WHERE email = $1`
+	acc := &model.Account{}
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, email).Scan(&acc.ID, &acc.Email, &acc.PasswordHash, &acc.Created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return acc, nil
+}
+
+// CreateSession сохраняет сессию и удаляет истёкшие сессии пользователя.
+func (r *URLRepository) CreateSession(ctx context.Context, s model.Session) error {
+	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM sessions WHERE user_id = $1 AND expires_at <= CURRENT_TIMESTAMP`, s.UserID); err != nil {
+		return fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	query := `INSERT INTO sessions (id, user_id, created_at, expires_at) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.Exec(ctx, query, s.ID, s.UserID, s.Created, s.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to insert session: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// GetSession возвращает сессию по хешу токена, включая истёкшие; nil, если её нет.
+func (r *URLRepository) GetSession(ctx context.Context, id string) (*model.Session, error) {
+	query := `SELECT id, user_id, created_at, expires_at FROM sessions WHERE id = $1`
+	s := &model.Session{}
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, id).Scan(&s.ID, &s.UserID, &s.Created, &s.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return s, nil
+}
+
+// DeleteSession удаляет сессию по хешу токена.
+func (r *URLRepository) DeleteSession(ctx context.Context, id string) error {
+	if _, err := r.DB.(*database.DB).Pool.Exec(ctx, `DELETE FROM sessions WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// ReassignUser передаёт ссылки, ключи API и участие в организациях пользователя from пользователю to.
+// Возвращает число переданных ссылок.
+func (r *URLRepository) ReassignUser(ctx context.Context, from, to string) (int64, error) {
+	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE urls SET user_id = $2 WHERE user_id = $1`, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign urls: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE api_keys SET user_id = $2 WHERE user_id = $1`, from, to); err != nil {
+		return 0, fmt.Errorf("failed to reassign api keys: %w", err)
+	}
+	// Если to уже состоит в организации, ему достаётся старшая из двух ролей:
+	// иначе единственный владелец мог бы пропасть при слиянии
+	raise := `
+		UPDATE memberships t SET role = m.role FROM memberships m
+		WHERE m.user_id = $1 AND t.user_id = $2 AND t.org_id = m.org_id
+		AND CASE m.role WHEN 'owner' THEN 3 WHEN 'editor' THEN 2 ELSE 1 END
+		  > CASE t.role WHEN 'owner' THEN 3 WHEN 'editor' THEN 2 ELSE 1 END`
+	if _, err := tx.Exec(ctx, raise, from, to); err != nil {
+		return 0, fmt.Errorf("failed to reassign memberships: %w", err)
+	}
+	memberships := `
+		UPDATE memberships m SET user_id = $2 WHERE m.user_id = $1
+		AND NOT EXISTS (SELECT 1 FROM memberships t WHERE t.org_id = m.org_id AND t.user_id = $2)`
+	if _, err := tx.Exec(ctx, memberships, from, to); err != nil {
+		return 0, fmt.Errorf("failed to reassign memberships: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM memberships WHERE user_id = $1`, from); err != nil {
+		return 0, fmt.Errorf("failed to reassign memberships: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ResolveIdentity возвращает пользователя, сопоставленного субъекту провайдера.
+// Если сопоставления нет, субъект закрепляется за newUserID.
+func (r *URLRepository) ResolveIdentity(ctx context.Context, issuer, subject, newUserID string) (string, error) {
+	query := `
+		INSERT INTO oidc_identities (issuer, subject, user_id) VALUES ($1, $2, $3)
+		ON CONFLICT (issuer, subject) DO UPDATE SET issuer = EXCLUDED.issuer
+		RETURNING user_id`
+	var userID string
+	if err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, issuer, subject, newUserID).Scan(&userID); err != nil {
+		return "", fmt.Errorf("failed to resolve identity: %w", err)
+	}
+	return userID, nil
+}
+
+// CreateOrganization сохраняет организацию вместе с её владельцем.
+func (r *URLRepository) CreateOrganization(ctx context.Context, org model.Organization, owner model.Membership) error {
+	tx, err := r.DB.(*database.DB).Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `INSERT INTO organizations (id, name, created_at) VALUES ($1, $2, $3)`,
+		org.ID, org.Name, org.Created); err != nil {
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO memberships (org_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)`,
+		owner.OrgID, owner.UserID, owner.Role, owner.Created); err != nil {
+		return fmt.Errorf("failed to insert membership: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// GetMembership возвращает участие пользователя в организации; nil, если он не участник.
+func (r *URLRepository) GetMembership(ctx context.Context, orgID, userID string) (*model.Membership, error) {
+	query := `SELECT org_id, user_id, role, created_at FROM memberships WHERE org_id = $1 AND user_id = $2`
+	m := &model.Membership{}
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, orgID, userID).Scan(&m.OrgID, &m.UserID, &m.Role, &m.Created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return m, nil
+}
+
+// ListWorkspaces возвращает организации пользователя по имени.
+func (r *URLRepository) ListWorkspaces(ctx context.Context, userID string) ([]model.Workspace, error) {
+	query := `
+		SELECT o.id, o.name, o.created_at, m.role
+		FROM memberships m JOIN organizations o ON o.id = m.org_id
+		WHERE m.user_id = $1
+		ORDER BY o.name`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query workspaces: %w", err)
+	}
+	defer rows.Close()
+
+	var result []model.Workspace
+	for rows.Next() {
+		var w model.Workspace
+		if err := rows.Scan(&w.ID, &w.Name, &w.Created, &w.Role); err != nil {
+			return nil, fmt.Errorf("failed to scan workspace: %w", err)
+		}
+		result = append(result, w)
+	}
+	return result, rows.Err()
+}
+
+// ListMembers возвращает участников организации в порядке вступления.
+func (r *URLRepository) ListMembers(ctx context.Context, orgID string) ([]model.Membership, error) {
+	query := `SELECT org_id, user_id, role, created_at FROM memberships WHERE org_id = $1 ORDER BY created_at`
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query members: %w", err)
+	}
+	defer rows.Close()
+
+	var result []model.Membership
+	for rows.Next() {
+		var m model.Membership
+		if err := rows.Scan(&m.OrgID, &m.UserID, &m.Role, &m.Created); err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		result = append(result, m)
+	}
+	return result, rows.Err()
+}
+
+// SetMembership добавляет участника или меняет его роль.
+func (r *URLRepository) SetMembership(ctx context.Context, m model.Membership) error {
+	query := `
+		INSERT INTO memberships (org_id, user_id, role, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET role = EXCLUDED.role`
+	if _, err := r.DB.(*database.DB).Pool.Exec(ctx, query, m.OrgID, m.UserID, m.Role, m.Created); err != nil {
+		return fmt.Errorf("failed to set membership: %w", err)
+	}
+	return nil
+}
+
+// DeleteMembership удаляет участника. Возвращает false, если его не было.
+func (r *URLRepository) DeleteMembership(ctx context.Context, orgID, userID string) (bool, error) {
+	tag, err := r.DB.(*database.DB).Pool.Exec(ctx, `DELETE FROM memberships WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete membership: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// originHost — выражение для хоста адреса назначения в нижнем регистре.
+const originHost = `lower(substring(origin from '^[a-zA-Z][a-zA-Z0-9+.-]*://(?:[^@/?#]*@)?([^/:?#]+)'))`
+
+// SearchURLs ищет ссылки всех пользователей, начиная с недавно созданных.
+// Домен должен быть нормализован moderation.NormalizeDomain.
+func (r *URLRepository) SearchURLs(ctx context.Context, q model.LinkSearch) ([]*model.URLObject, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if q.Domain != "" {
+		d := arg(q.Domain)
+		where = append(where, fmt.Sprintf("(%s = %s OR %s LIKE '%%.' || %s)", originHost, d, originHost, d))
+	}
+	if q.UserID != "" {
+		where = append(where, "user_id = "+arg(q.UserID))
+	}
+	if !q.From.IsZero() {
+		where = append(where, "created >= "+arg(q.From))
+	}
+	if !q.To.IsZero() {
+		where = append(where, "created < "+arg(q.To))
+	}
+	if !q.IncludeDeleted {
+		where = append(where, "is_deleted = FALSE")
+	}
+	query := `SELECT id, origin, shorten, created, COALESCE(user_id, ''), is_deleted, deleted_at,
+                     is_disabled, COALESCE(disabled_reason, '')
+              FROM urls`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created DESC, shorten LIMIT " + arg(q.Limit) + " OFFSET " + arg(q.Offset)
+
+	rows, err := r.DB.(*database.DB).Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search URLs: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*model.URLObject
+	for rows.Next() {
+		obj := &model.URLObject{}
+		if err := rows.Scan(&obj.ID, &obj.Origin, &obj.Shorten, &obj.Created, &obj.UserID, &obj.IsDeleted,
+			&obj.DeletedAt, &obj.IsDisabled, &obj.DisabledReason); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		results = append(results, obj)
+	}
+	return results, rows.Err()
+}
+
+// CountUserLinks возвращает количество ссылок пользователя по состояниям.
+func (r *URLRepository) CountUserLinks(ctx context.Context, userID string) (model.LinkCounts, error) {
+	var c model.LinkCounts
+	query := `SELECT COUNT(*),
+                     COUNT(*) FILTER (WHERE NOT is_deleted AND NOT is_disabled),
+                     COUNT(*) FILTER (WHERE is_deleted),
+                     COUNT(*) FILTER (WHERE is_disabled AND NOT is_deleted)
+              FROM urls WHERE user_id = $1`
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, userID).Scan(&c.Total, &c.Active, &c.Deleted, &c.Disabled)
+	if err != nil {
+		return c, fmt.Errorf("failed to count user links: %w", err)
+	}
+	if c.Total > 0 {
+		c.Users = 1
+	}
+	return c, nil
+}
+
+// SetBan сохраняет запрет пользователя, заменяя прежний.
+func (r *URLRepository) SetBan(ctx context.Context, ban model.Ban) error {
+	query := `
+		INSERT INTO bans (user_id, reason, banned_by, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET reason = EXCLUDED.reason, banned_by = EXCLUDED.banned_by, created_at = EXCLUDED.created_at`
+	if _, err := r.DB.(*database.DB).Pool.Exec(ctx, query, ban.UserID, ban.Reason, ban.By, ban.Created); err != nil {
+		return fmt.Errorf("failed to set ban: %w", err)
+	}
+	return nil
+}
+
+// GetBan возвращает запрет пользователя; nil, если его нет.
+func (r *URLRepository) GetBan(ctx context.Context, userID string) (*model.Ban, error) {
+	query := `SELECT user_id, reason, banned_by, created_at FROM bans WHERE user_id = $1`
+	ban := &model.Ban{}
+	err := r.DB.(*database.DB).Pool.QueryRow(ctx, query, userID).Scan(&ban.UserID, &ban.Reason, &ban.By, &ban.Created)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	return ban, nil
+}
+
+// DeleteBan снимает запрет. Возвращает false, если его не было.
+func (r *URLRepository) DeleteBan(ctx context.Context, userID string) (bool, error) {
+	tag, err := r.DB.(*database.DB).Pool.Exec(ctx, `DELETE FROM bans WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete ban: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
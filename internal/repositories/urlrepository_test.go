@@ -0,0 +1,61 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/database"
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/orgs"
+	"github.com/Totarae/URLShortener/internal/repositories"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo подключается к PostgreSQL из DATABASE_DSN и применяет миграции;
+// без DATABASE_DSN тест пропускается.
+func newTestRepo(t *testing.T) *repositories.URLRepository {
+	dsn := os.Getenv("DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_DSN is not set")
+	}
+	m, err := migrate.New("file://../migrations", dsn)
+	require.NoError(t, err)
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+	pool, err := pgxpool.New(context.Background(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return repositories.NewURLRepository(&database.DB{Pool: pool})
+}
+
+func TestReassignUser_KeepsSoleOwner(t *testing.T) {
+	repo := newTestRepo(t)
+	ctx := context.Background()
+	org := model.Organization{ID: uuid.NewString(), Name: "Zeta"}
+	anon, account, other := uuid.NewString(), uuid.NewString(), uuid.NewString()
+
+	require.NoError(t, repo.CreateOrganization(ctx, org, model.Membership{OrgID: org.ID, UserID: anon, Role: orgs.RoleOwner}))
+	require.NoError(t, repo.SetMembership(ctx, model.Membership{OrgID: org.ID, UserID: account, Role: orgs.RoleViewer}))
+	require.NoError(t, repo.SetMembership(ctx, model.Membership{OrgID: org.ID, UserID: other, Role: orgs.RoleEditor}))
+
+	_, err := repo.ReassignUser(ctx, anon, account)
+	require.NoError(t, err)
+
+	members, err := repo.ListMembers(ctx, org.ID)
+	require.NoError(t, err)
+	roles := map[string]string{}
+	for _, m := range members {
+		roles[m.UserID] = m.Role
+	}
+	assert.Equal(t, map[string]string{account: orgs.RoleOwner, other: orgs.RoleEditor}, roles,
+		"единственный владелец не теряется, роль остальных не меняется")
+}
@@ -1,38 +1,106 @@
 package router
 
 import (
+	"net/http"
 	"net/http/pprof"
 
+	"github.com/Totarae/URLShortener/internal/auth"
 	"github.com/Totarae/URLShortener/internal/handlers"
+	"github.com/Totarae/URLShortener/internal/metrics"
 	"github.com/Totarae/URLShortener/internal/middleware"
+	"github.com/Totarae/URLShortener/internal/tracing"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
-// NewRouter создаёт и настраивает маршрутизатор
-func NewRouter(handler *handlers.Handler, logger *zap.Logger) *chi.Mux {
+// NewRouter создаёт и настраивает маршрутизатор.
+// Если m не nil, запросы учитываются в метриках, а метрики отдаются по /metrics,
+// когда для них не настроен отдельный адрес.
+func NewRouter(handler *handlers.Handler, logger *zap.Logger, m *metrics.Metrics, serveMetrics bool) *chi.Mux {
 	r := chi.NewRouter()
 
-	r.Use(middleware.LoggingMiddleware(logger)) // Подключаем логирование
-	r.Use(middleware.GzipMiddleware)            // Gzip-сжатие
+	r.Use(tracing.Middleware) // Трассировка OpenTelemetry
+	if m != nil {
+		r.Use(middleware.LoggingMiddleware(logger, m)) // Подключаем логирование и метрики
+	} else {
+		r.Use(middleware.LoggingMiddleware(logger)) // Подключаем логирование
+	}
+	r.Use(middleware.GzipMiddleware) // Gzip-сжатие
+	// Области доступа проверяются у Bearer-токенов и ключей API; владельцу cookie доступно всё
+	scope := func(string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	if handler.Auth != nil {
+		r.Use(handler.Auth.Authenticate) // Bearer-токены и ключи API, 401 на недействительные
+		scope = handler.Auth.RequireScope
+	}
+	write := r.With(scope(auth.ScopeLinksWrite))
+	read := r.With(scope(auth.ScopeLinksRead))
+	del := r.With(scope(auth.ScopeLinksDelete))
+	stats := r.With(scope(auth.ScopeStatsRead))
 
-	r.Post("/", handler.ReceiveURL)
+	write.Post("/", handler.ReceiveURL)
 
 	r.Get("/{id}", handler.ResponseURL)
-	r.Get("/ping", handler.PingHandler) // Проверка соединения с БД
+	r.Head("/{id}", handler.ResponseURL) // Сборщики превью проверяют ссылку через HEAD
+	r.Get("/ping", handler.PingHandler)  // Проверка соединения с БД
+	if m != nil && serveMetrics {
+		r.Get("/metrics", m.Handler().ServeHTTP)
+	}
 
-	r.Route("/api/shorten", func(r chi.Router) {
+	r.Post("/api/auth/token", handler.IssueToken) // Выпуск Bearer-токена
+	r.Post("/api/auth/register", handler.Register)
+	r.Post("/api/auth/login", handler.Login)
+	r.Post("/api/auth/logout", handler.Logout)
+	r.Get("/api/auth/oidc/login", handler.SSOLogin) // Вход через OpenID Connect
+	r.Get("/api/auth/oidc/callback", handler.SSOCallback)
+
+	// Ключи API пользователя
+	r.Post("/api/user/keys", handler.CreateAPIKey)
+	r.Get("/api/user/keys", handler.ListAPIKeys)
+	r.Delete("/api/user/keys/{id}", handler.RevokeAPIKey)
+
+	// Организации и их общие ссылки; роли проверяет сервис
+	write.Post("/api/orgs", handler.CreateOrganization)
+	read.Get("/api/orgs", handler.ListOrganizations)
+	read.Get("/api/orgs/{org}/members", handler.ListMembers)
+	write.Put("/api/orgs/{org}/members/{user}", handler.SetMember)
+	write.Delete("/api/orgs/{org}/members/{user}", handler.RemoveMember)
+	write.Post("/api/orgs/{org}/urls", handler.ShortenForOrganization)
+	read.Get("/api/orgs/{org}/urls", handler.GetOrganizationURLs)
+	del.Delete("/api/orgs/{org}/urls", handler.DeleteOrganizationURLs)
+
+	write.Route("/api/shorten", func(r chi.Router) {
 		r.Post("/", handler.ReceiveShorten)
 		r.Post("/batch", handler.BatchShortenHandler)
 	})
 
 	// Защищённый маршрут — только для авторизованных пользователей
-	r.Get("/api/user/urls", handler.GetUserURLs)
-	r.Delete("/api/user/urls", handler.DeleteUserURLs)
+	read.Get("/api/user/urls", handler.GetUserURLs)
+	del.Delete("/api/user/urls", handler.DeleteUserURLs)
+	read.Get("/api/user/urls/broken", handler.GetBrokenURLs)
+	read.Get("/api/user/urls/trash", handler.GetTrash)
+	stats.Get("/api/user/urls/stream", handler.StreamClicks)
+	del.Post("/api/user/urls/restore", handler.RestoreURLs)
+	del.Post("/api/user/urls/{id}/restore", handler.RestoreURL)
+	write.Patch("/api/user/urls/{id}", handler.UpdateURL)
+	read.Get("/api/user/urls/{id}/revisions", handler.GetRevisions)
+	stats.Get("/api/user/urls/{id}/stats", handler.GetLinkStats)
+	read.Get("/api/user/urls/{id}/rules", handler.GetRedirectRules)
+	write.Put("/api/user/urls/{id}/rules", handler.SetRedirectRules)
+	write.Put("/api/user/urls/{id}/preview", handler.SetForcePreview)
 
 	// Защищеный маршрут для подсети
 	r.Get("/api/internal/stats", handler.GetStatsHandler)
 
+	// Модерация: только администраторы, проверяет обработчик
+	r.Get("/api/admin/urls", handler.SearchLinks)
+	r.Post("/api/admin/urls/{id}/disable", handler.DisableLink)
+	r.Delete("/api/admin/urls/{id}", handler.DeleteLink)
+	r.Put("/api/admin/users/{user}/ban", handler.BanUser)
+	r.Delete("/api/admin/users/{user}/ban", handler.UnbanUser)
+	r.Get("/api/admin/users/{user}/activity", handler.GetUserActivity)
+
 	// === Подключение pprof ===
 	r.Route("/debug/pprof", func(r chi.Router) {
 		r.Get("/", pprof.Index)
@@ -0,0 +1,265 @@
+// Package rules вычисляет адрес перенаправления короткой ссылки
+// по упорядоченному списку правил (устройство, язык, временное окно).
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // правила могут ссылаться на IANA-зоны, которых нет в образе
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/useragent"
+	"github.com/Totarae/URLShortener/internal/util"
+)
+
+// MaxRules ограничивает количество правил на одну ссылку.
+const MaxRules = 20
+
+// DefaultVariant — имя варианта, когда ни одно правило не сработало.
+const DefaultVariant = "default"
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Request содержит атрибуты запроса, по которым проверяются правила.
+type Request struct {
+	UserAgent      string
+	AcceptLanguage string
+	Time           time.Time
+}
+
+// NewRequest собирает Request из входящего HTTP-запроса.
+func NewRequest(r *http.Request) Request {
+	return Request{
+		UserAgent:      r.UserAgent(),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+		Time:           time.Now(),
+	}
+}
+
+// Resolve возвращает адрес перенаправления и имя сработавшего варианта.
+// Если ни одно правило не подошло, возвращается оригинальный URL ссылки.
+func Resolve(urlObj *model.URLObject, req Request) (target, variant string) {
+	idx := Select(urlObj.Rules, req)
+	if idx < 0 {
+		return urlObj.Origin, DefaultVariant
+	}
+	return urlObj.Rules[idx].Target, VariantName(urlObj.Rules[idx], idx)
+}
+
+// Select возвращает индекс первого сработавшего правила или -1.
+func Select(list []model.RedirectRule, req Request) int {
+	if len(list) == 0 {
+		return -1
+	}
+	device := useragent.Device(req.UserAgent)
+	lang := preferredLanguage(req.AcceptLanguage)
+	for i, rule := range list {
+		if matches(rule, device, lang, req.Time) {
+			return i
+		}
+	}
+	return -1
+}
+
+// VariantName возвращает имя правила или его порядковый номер, если имя не задано.
+func VariantName(rule model.RedirectRule, idx int) string {
+	if rule.Name != "" {
+		return rule.Name
+	}
+	return "rule-" + strconv.Itoa(idx+1)
+}
+
+func matches(rule model.RedirectRule, device, lang string, now time.Time) bool {
+	if len(rule.Devices) > 0 && !containsFold(rule.Devices, device) {
+		return false
+	}
+	if len(rule.Languages) > 0 && !matchLanguage(rule.Languages, lang) {
+		return false
+	}
+
+	loc := time.UTC
+	if rule.Timezone != "" {
+		// Зона проверена в Validate, ошибка здесь означает повреждённые данные.
+		l, err := time.LoadLocation(rule.Timezone)
+		if err != nil {
+			return false
+		}
+		loc = l
+	}
+	local := now.In(loc)
+
+	if len(rule.Weekdays) > 0 {
+		ok := false
+		for _, d := range rule.Weekdays {
+			if wd, known := weekdays[strings.ToLower(d)]; known && wd == local.Weekday() {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	day := local.Format(dateLayout)
+	if rule.DateFrom != "" && day < rule.DateFrom {
+		return false
+	}
+	if rule.DateTo != "" && day > rule.DateTo {
+		return false
+	}
+
+	if rule.TimeFrom != "" || rule.TimeTo != "" {
+		from, to := "00:00", "24:00"
+		if rule.TimeFrom != "" {
+			from = rule.TimeFrom
+		}
+		if rule.TimeTo != "" {
+			to = rule.TimeTo
+		}
+		clock := local.Format(clockLayout)
+		if from <= to {
+			if clock < from || clock >= to {
+				return false
+			}
+		} else if clock < from && clock >= to {
+			// Окно через полночь, например 22:00–06:00.
+			return false
+		}
+	}
+	return true
+}
+
+// matchLanguage сравнивает язык клиента с тегами правила.
+// Тег "en" совпадает с "en-US", тег "en-US" — только с "en-US".
+func matchLanguage(tags []string, lang string) bool {
+	if lang == "" {
+		return false
+	}
+	for _, tag := range tags {
+		tag = strings.ToLower(tag)
+		if tag == lang || strings.HasPrefix(lang, tag+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// preferredLanguage возвращает наиболее предпочтительный язык из Accept-Language.
+func preferredLanguage(header string) string {
+	type langQ struct {
+		tag string
+		q   float64
+	}
+	var langs []langQ
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if v, ok := strings.CutPrefix(param, "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			langs = append(langs, langQ{tag: tag, q: q})
+		}
+	}
+	if len(langs) == 0 {
+		return ""
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+	return langs[0].tag
+}
+
+func containsFold(list []string, v string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate проверяет корректность списка правил перед сохранением.
+func Validate(list []model.RedirectRule) error {
+	if len(list) > MaxRules {
+		return fmt.Errorf("too many rules: %d, max %d", len(list), MaxRules)
+	}
+	for i, rule := range list {
+		if err := validateRule(rule); err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func validateRule(rule model.RedirectRule) error {
+	if _, err := util.ValidateURL(rule.Target); err != nil {
+		return errors.New("invalid target URL")
+	}
+	for _, d := range rule.Devices {
+		if !useragent.IsDevice(strings.ToLower(d)) {
+			return fmt.Errorf("unknown device %q", d)
+		}
+	}
+	for _, l := range rule.Languages {
+		if strings.TrimSpace(l) == "" {
+			return errors.New("empty language tag")
+		}
+	}
+	for _, d := range rule.Weekdays {
+		if _, ok := weekdays[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("unknown weekday %q", d)
+		}
+	}
+	for _, t := range []string{rule.TimeFrom, rule.TimeTo} {
+		if t == "" {
+			continue
+		}
+		if _, err := time.Parse(clockLayout, t); err != nil || len(t) != len(clockLayout) {
+			return fmt.Errorf("invalid time %q, expected HH:MM", t)
+		}
+	}
+	for _, d := range []string{rule.DateFrom, rule.DateTo} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse(dateLayout, d); err != nil {
+			return fmt.Errorf("invalid date %q, expected YYYY-MM-DD", d)
+		}
+	}
+	if rule.DateFrom != "" && rule.DateTo != "" && rule.DateFrom > rule.DateTo {
+		return errors.New("date_from is after date_to")
+	}
+	if rule.Timezone != "" {
+		if _, err := time.LoadLocation(rule.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", rule.Timezone)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package rules_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/rules"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	iPhoneUA  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15"
+	androidUA = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/120.0 Mobile"
+	desktopUA = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0"
+)
+
+// 2025-06-04 — среда
+var noon = time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+func TestResolve_NoRulesFallsBackToOrigin(t *testing.T) {
+	obj := &model.URLObject{Origin: "https://example.com"}
+	target, variant := rules.Resolve(obj, rules.Request{UserAgent: iPhoneUA, Time: noon})
+	assert.Equal(t, "https://example.com", target)
+	assert.Equal(t, rules.DefaultVariant, variant)
+}
+
+func TestResolve_FirstMatchingRuleWins(t *testing.T) {
+	obj := &model.URLObject{
+		Origin: "https://example.com",
+		Rules: []model.RedirectRule{
+			{Name: "ios-ru", Devices: []string{"ios"}, Languages: []string{"ru"}, Target: "https://example.com/ios-ru"},
+			{Name: "ios", Devices: []string{"ios"}, Target: "https://example.com/ios"},
+			{Name: "ru", Languages: []string{"ru"}, Target: "https://example.com/ru"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		req     rules.Request
+		target  string
+		variant string
+	}{
+		{"ios and ru", rules.Request{UserAgent: iPhoneUA, AcceptLanguage: "ru-RU,ru;q=0.9", Time: noon}, "https://example.com/ios-ru", "ios-ru"},
+		{"ios only", rules.Request{UserAgent: iPhoneUA, AcceptLanguage: "en-US", Time: noon}, "https://example.com/ios", "ios"},
+		{"ru on android", rules.Request{UserAgent: androidUA, AcceptLanguage: "ru", Time: noon}, "https://example.com/ru", "ru"},
+		{"no match", rules.Request{UserAgent: desktopUA, AcceptLanguage: "de", Time: noon}, "https://example.com", rules.DefaultVariant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, variant := rules.Resolve(obj, tt.req)
+			assert.Equal(t, tt.target, target)
+			assert.Equal(t, tt.variant, variant)
+		})
+	}
+}
+
+func TestSelect_PreferredLanguageByQuality(t *testing.T) {
+	list := []model.RedirectRule{
+		{Languages: []string{"en"}, Target: "https://example.com/en"},
+		{Languages: []string{"ru"}, Target: "https://example.com/ru"},
+	}
+	idx := rules.Select(list, rules.Request{AcceptLanguage: "en;q=0.5, ru-RU;q=0.9", Time: noon})
+	assert.Equal(t, 1, idx)
+}
+
+func TestSelect_TimeWindows(t *testing.T) {
+	night := model.RedirectRule{TimeFrom: "22:00", TimeTo: "06:00", Target: "https://example.com/night"}
+	weekend := model.RedirectRule{Weekdays: []string{"sat", "sun"}, Target: "https://example.com/weekend"}
+	promo := model.RedirectRule{DateFrom: "2025-06-01", DateTo: "2025-06-04", Target: "https://example.com/promo"}
+	moscow := model.RedirectRule{TimeFrom: "14:00", TimeTo: "16:00", Timezone: "Europe/Moscow", Target: "https://example.com/msk"}
+
+	assert.Equal(t, 0, rules.Select([]model.RedirectRule{night}, rules.Request{Time: noon.Add(11 * time.Hour)}))
+	assert.Equal(t, 0, rules.Select([]model.RedirectRule{night}, rules.Request{Time: noon.Add(-7 * time.Hour)}))
+	assert.Equal(t, -1, rules.Select([]model.RedirectRule{night}, rules.Request{Time: noon}))
+
+	assert.Equal(t, -1, rules.Select([]model.RedirectRule{weekend}, rules.Request{Time: noon}))
+	assert.Equal(t, 0, rules.Select([]model.RedirectRule{weekend}, rules.Request{Time: noon.AddDate(0, 0, 3)}))
+
+	assert.Equal(t, 0, rules.Select([]model.RedirectRule{promo}, rules.Request{Time: noon}))
+	assert.Equal(t, -1, rules.Select([]model.RedirectRule{promo}, rules.Request{Time: noon.AddDate(0, 0, 1)}))
+
+	// 12:00 UTC — это 15:00 в Москве
+	assert.Equal(t, 0, rules.Select([]model.RedirectRule{moscow}, rules.Request{Time: noon}))
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, rules.Validate([]model.RedirectRule{
+		{Devices: []string{"ios", "android"}, TimeFrom: "09:00", TimeTo: "18:00", Timezone: "Europe/Moscow", Target: "https://example.com"},
+	}))
+
+	invalid := []model.RedirectRule{
+		{Target: "not a url"},
+		{Devices: []string{"tv"}, Target: "https://example.com"},
+		{TimeFrom: "9:00", Target: "https://example.com"},
+		{Weekdays: []string{"someday"}, Target: "https://example.com"},
+		{DateFrom: "2025-02-01", DateTo: "2025-01-01", Target: "https://example.com"},
+		{Timezone: "Mars/Olympus", Target: "https://example.com"},
+	}
+	for _, r := range invalid {
+		assert.Error(t, rules.Validate([]model.RedirectRule{r}), "%+v", r)
+	}
+}
@@ -2,22 +2,187 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
+	"github.com/Totarae/URLShortener/internal/accounts"
+	"github.com/Totarae/URLShortener/internal/analytics"
+	"github.com/Totarae/URLShortener/internal/apikeys"
+	"github.com/Totarae/URLShortener/internal/auth"
+	"github.com/Totarae/URLShortener/internal/blocklist"
+	"github.com/Totarae/URLShortener/internal/botdetect"
+	"github.com/Totarae/URLShortener/internal/cache"
+	"github.com/Totarae/URLShortener/internal/canonical"
+	"github.com/Totarae/URLShortener/internal/clicks"
+	"github.com/Totarae/URLShortener/internal/healthcheck"
+	"github.com/Totarae/URLShortener/internal/hll"
+	"github.com/Totarae/URLShortener/internal/linkguard"
+	"github.com/Totarae/URLShortener/internal/metadata"
 	"github.com/Totarae/URLShortener/internal/model"
+	"github.com/Totarae/URLShortener/internal/moderation"
+	"github.com/Totarae/URLShortener/internal/orgs"
+	"github.com/Totarae/URLShortener/internal/pubsub"
+	"github.com/Totarae/URLShortener/internal/repositories"
+	"github.com/Totarae/URLShortener/internal/rules"
+	"github.com/Totarae/URLShortener/internal/storage"
+	"github.com/Totarae/URLShortener/internal/tracing"
 	"github.com/Totarae/URLShortener/internal/util"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrURLNotFound — ссылка не найдена или не принадлежит пользователю.
+	ErrURLNotFound = errors.New("url not found")
+	// ErrInvalidRules — список правил перенаправления не прошёл проверку.
+	ErrInvalidRules = errors.New("invalid redirect rules")
+	// ErrDestinationTaken — для нового адреса назначения уже есть другая короткая ссылка.
+	ErrDestinationTaken = errors.New("destination already has another short link")
+	// ErrStreamUnavailable — поток событий переходов отключён в конфигурации.
+	ErrStreamUnavailable = errors.New("click stream is disabled")
+	// ErrAPIKeyNotFound — ключ API не найден, отозван или принадлежит другому пользователю.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrAccountExists — учётная запись с таким адресом уже есть.
+	ErrAccountExists = errors.New("account already exists")
+	// ErrInvalidCredentials — неверный адрес или пароль.
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrInvalidAccount — адрес или пароль не прошли проверку при регистрации.
+	ErrInvalidAccount = errors.New("invalid account data")
+	// ErrOrganizationNotFound — организации нет или пользователь в ней не состоит.
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrForbidden — роли пользователя в организации недостаточно для действия.
+	ErrForbidden = errors.New("insufficient role")
+	// ErrInvalidOrganization — имя организации или роль участника не прошли проверку.
+	ErrInvalidOrganization = errors.New("invalid organization data")
+	// ErrLastOwner — действие оставило бы организацию без владельца.
+	ErrLastOwner = errors.New("organization must keep an owner")
+	// ErrUserBanned — пользователю запрещено создавать ссылки.
+	ErrUserBanned = errors.New("user is banned")
+	// ErrBanNotFound — у пользователя нет запрета.
+	ErrBanNotFound = errors.New("ban not found")
+	// ErrInvalidAdminRequest — параметры административного запроса не прошли проверку.
+	ErrInvalidAdminRequest = errors.New("invalid admin request")
+)
+
+// DefaultTrashRetention — срок хранения удалённых ссылок по умолчанию.
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// DefaultStreamHeartbeat — интервал служебных сообщений потока событий по умолчанию.
+const DefaultStreamHeartbeat = 15 * time.Second
+
+// DefaultSessionTTL — срок жизни сессии учётной записи по умолчанию.
+const DefaultSessionTTL = 30 * 24 * time.Hour
+
+// Допустимая длина пароля; верхняя граница защищает от дорогого хеширования.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 256
+)
+
+// MaxOrganizationName — максимальная длина имени организации в символах.
+const MaxOrganizationName = 100
+
+// Размер страницы административного поиска: по умолчанию и максимальный.
+const (
+	DefaultSearchLimit = 100
+	MaxSearchLimit     = 1000
+)
+
+// ActivityRecentLinks — число последних ссылок в сводке по пользователю.
+const ActivityRecentLinks = 20
+
+// Окно сводной статистики в днях: по умолчанию и максимальное.
+const (
+	DefaultStatsDays = 30
+	MaxStatsDays     = 365
+)
+
+// maxCodeAttempts ограничивает подбор свободного идентификатора, если код
+// занят ссылкой, адрес назначения которой был изменён.
+const maxCodeAttempts = 5
+
+// Коды отклонения адреса назначения, возвращаемые клиентам REST и gRPC.
+const (
+	CodeSelfReference  = "self_reference"
+	CodeShortenerChain = "shortener_chain"
+	CodeRedirectLoop   = "redirect_loop"
+	CodeInvalidURL     = "invalid_url"
+	CodeBlocked        = "blocked"
+)
+
+// ErrBlocked — адрес назначения подпадает под правило блокировки.
+var ErrBlocked = errors.New("destination is blocked")
+
+// DestinationError — адрес назначения отклонён политикой сервиса.
+type DestinationError struct {
+	Code          string
+	URL           string
+	CorrelationID string
+	// Rule — сработавшее правило блокировки, если причина в нём.
+	Rule string
+	Err  error
+}
+
+func (e *DestinationError) Error() string {
+	return fmt.Sprintf("destination %q rejected: %v", e.URL, e.Err)
+}
+
+func (e *DestinationError) Unwrap() error {
+	return e.Err
+}
+
 type Repository interface {
 	SaveURL(ctx context.Context, urlObj *model.URLObject) error
 	GetURL(ctx context.Context, short string) (*model.URLObject, error)
 	MarkURLsAsDeleted(ctx context.Context, ids []string, userID string) error
 	GetURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error)
-	GetStats(ctx context.Context) (urlCount int, userCount int, err error)
+	CountLinks(ctx context.Context) (model.LinkCounts, error)
+	CountCreatedByDay(ctx context.Context, since time.Time) (map[time.Time]int64, error)
+	TopClickedURLs(ctx context.Context, since time.Time, limit int, includeBots bool) ([]model.TopLink, error)
 	Ping(ctx context.Context) error
 	SaveBatchURLs(ctx context.Context, urls []*model.URLObject) error
+	UpdateRules(ctx context.Context, shorten, userID string, rules []model.RedirectRule) (bool, error)
+	SetForcePreview(ctx context.Context, shorten, userID string, enabled bool) (bool, error)
+	ForEachActiveURL(ctx context.Context, fn func(*model.URLObject) error) error
+	DisableURLs(ctx context.Context, ids []string, reason string) error
+	SaveHealth(ctx context.Context, results map[string]model.LinkHealth) error
+	GetBrokenURLsByUserID(ctx context.Context, userID string) ([]*model.URLObject, error)
+	SaveMetadata(ctx context.Context, shorten, origin string, meta model.LinkMetadata) (bool, error)
+	UpdateOrigin(ctx context.Context, shorten, userID, origin string) (model.Revision, bool, error)
+	GetRevisions(ctx context.Context, shorten string) ([]model.Revision, error)
+	GetDeletedURLsByUserID(ctx context.Context, userID string, since time.Time) ([]*model.URLObject, error)
+	RestoreURLs(ctx context.Context, ids []string, userID string, since time.Time) ([]string, error)
+	GetClickSummary(ctx context.Context, shorten string, from, to time.Time, interval string, includeBots bool) (model.ClickSummary, error)
+	GetSketches(ctx context.Context, shorten string, from, to time.Time) (map[time.Time]*hll.Sketch, error)
+	CreateAPIKey(ctx context.Context, key model.APIKey) error
+	GetAPIKey(ctx context.Context, id string) (*model.APIKey, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id string) (bool, error)
+	CreateAccount(ctx context.Context, acc model.Account) (bool, error)
+	GetAccountByEmail(ctx context.Context, email string) (*model.Account, error)
+	CreateSession(ctx context.Context, s model.Session) error
+	GetSession(ctx context.Context, id string) (*model.Session, error)
+	DeleteSession(ctx context.Context, id string) error
+	ReassignUser(ctx context.Context, from, to string) (int64, error)
+	ResolveIdentity(ctx context.Context, issuer, subject, newUserID string) (string, error)
+	CreateOrganization(ctx context.Context, org model.Organization, owner model.Membership) error
+	GetMembership(ctx context.Context, orgID, userID string) (*model.Membership, error)
+	ListWorkspaces(ctx context.Context, userID string) ([]model.Workspace, error)
+	ListMembers(ctx context.Context, orgID string) ([]model.Membership, error)
+	SetMembership(ctx context.Context, m model.Membership) error
+	DeleteMembership(ctx context.Context, orgID, userID string) (bool, error)
+	SearchURLs(ctx context.Context, q model.LinkSearch) ([]*model.URLObject, error)
+	CountUserLinks(ctx context.Context, userID string) (model.LinkCounts, error)
+	SetBan(ctx context.Context, ban model.Ban) error
+	GetBan(ctx context.Context, userID string) (*model.Ban, error)
+	DeleteBan(ctx context.Context, userID string) (bool, error)
 }
 
 type Store interface {
@@ -25,6 +190,19 @@ type Store interface {
 	Get(short string) (string, bool)
 	GetByUser(userID string) map[string]string
 	MarkDeleted(shortenIDs []string, userID string)
+	GetEntry(short string) (model.Entry, bool)
+	FindByOrigin(origin string) (model.Entry, bool)
+	SetRules(short, userID string, rules []model.RedirectRule) bool
+	SetForcePreview(short, userID string, enabled bool) bool
+	DisableWhere(match func(model.Entry) bool, reason string) []string
+	ActiveEntries() []model.Entry
+	Entries() []model.Entry
+	GetEntriesByUser(userID string) []model.Entry
+	SetHealth(short string, health model.LinkHealth) bool
+	SetMetadata(short, url string, meta model.LinkMetadata) bool
+	UpdateOrigin(short, userID, origin string) (model.Revision, bool, error)
+	Restore(shortenIDs []string, userID string, since time.Time) []string
+	Reassign(from, to string) int
 }
 
 type ShortenerService struct {
@@ -33,6 +211,46 @@ type ShortenerService struct {
 	Logger  *zap.Logger
 	Mode    string
 	BaseURL string
+	// Guard отклоняет ссылки на собственные домены и цепочки сокращателей.
+	Guard *linkguard.Guard
+	// Canonicalizer приводит адреса к каноническому виду до хеширования.
+	Canonicalizer *canonical.Canonicalizer
+	// Blocklist содержит правила блокировки адресов назначения.
+	Blocklist *blocklist.List
+	// Checker проверяет доступность адресов назначения.
+	Checker *healthcheck.Checker
+	// Metadata загружает метаданные страниц назначения новых ссылок.
+	Metadata *metadata.Worker
+	// Cache хранит разрешённые ссылки; сбрасывается при любом изменении ссылки.
+	Cache *cache.Cache[*model.URLObject]
+	// TrashRetention — срок, в течение которого удалённую ссылку можно восстановить.
+	TrashRetention time.Duration
+	// Clicks принимает события переходов по ссылкам.
+	Clicks *clicks.Tracker
+	// ClicksPath — NDJSON-файл событий переходов для режимов без базы данных.
+	ClicksPath string
+	// Bots отличает переходы ботов и сборщиков превью от переходов людей.
+	Bots *botdetect.Classifier
+	// Sketches хранит скетчи уникальных посетителей для режимов без базы данных.
+	Sketches *analytics.SketchFile
+	// ClickStream рассылает события переходов владельцам ссылок в реальном времени.
+	ClickStream *pubsub.Hub[model.Click]
+	// StreamHeartbeat — интервал служебных сообщений в потоке событий.
+	StreamHeartbeat time.Duration
+	// APIKeys хранит ключи API для режимов без базы данных.
+	APIKeys *apikeys.FileStore
+	// Accounts хранит учётные записи и сессии для режимов без базы данных.
+	Accounts *accounts.FileStore
+	// SessionTTL — срок жизни сессии учётной записи.
+	SessionTTL time.Duration
+	// PasswordParams — параметры argon2id для новых паролей.
+	PasswordParams accounts.Params
+	// Orgs хранит организации и участников для режимов без базы данных.
+	Orgs *orgs.FileStore
+	// Bans хранит запреты пользователей для режимов без базы данных.
+	Bans *moderation.FileStore
+
+	pendingDeletes atomic.Int64
 }
 
 func NewShortenerService(repo Repository, store Store, logger *zap.Logger, mode, baseURL string) *ShortenerService {
@@ -42,47 +260,579 @@ func NewShortenerService(repo Repository, store Store, logger *zap.Logger, mode,
 		Logger:  logger,
 		Mode:    mode,
 		BaseURL: baseURL,
+		Guard:   linkguard.New([]string{baseURL}, nil, false),
+
+		Canonicalizer:  canonical.New(canonical.Options{}),
+		TrashRetention: DefaultTrashRetention,
+		APIKeys:        &apikeys.FileStore{},
+		Accounts:       &accounts.FileStore{},
+		SessionTTL:     DefaultSessionTTL,
+		PasswordParams: accounts.DefaultParams,
+		Orgs:           &orgs.FileStore{},
+		Bans:           &moderation.FileStore{},
+	}
+}
+
+// checkDestination приводит адрес назначения к каноническому виду, проверяет его
+// и возвращает адрес для сохранения.
+func (s *ShortenerService) checkDestination(ctx context.Context, raw string) (string, error) {
+	normalized, err := s.canonicalize(raw)
+	if err != nil {
+		return "", err
+	}
+	if err := s.checkBlocklist(raw, normalized); err != nil {
+		return "", err
+	}
+	if s.Guard == nil {
+		return normalized, nil
+	}
+	checked, err := s.Guard.Check(ctx, normalized)
+	if err != nil {
+		code := CodeShortenerChain
+		switch {
+		case errors.Is(err, linkguard.ErrSelfReference):
+			code = CodeSelfReference
+		case errors.Is(err, linkguard.ErrRedirectLoop):
+			code = CodeRedirectLoop
+		}
+		return "", &DestinationError{Code: code, URL: raw, Err: err}
+	}
+	if checked != normalized {
+		// Развёрнутый адрес сокращателя тоже приводим к каноническому виду и проверяем
+		if checked, err = s.canonicalize(checked); err != nil {
+			return "", err
+		}
+		if err := s.checkBlocklist(raw, checked); err != nil {
+			return "", err
+		}
+	}
+	return checked, nil
+}
+
+func (s *ShortenerService) checkBlocklist(raw, normalized string) error {
+	if s.Blocklist == nil {
+		return nil
+	}
+	if rule, ok := s.Blocklist.Match(normalized); ok {
+		return &DestinationError{Code: CodeBlocked, URL: raw, Rule: rule.String(), Err: ErrBlocked}
+	}
+	return nil
+}
+
+// DisableMatching отключает существующие ссылки, подпадающие под правила блокировки.
+// Возвращает количество отключённых ссылок.
+func (s *ShortenerService) DisableMatching(ctx context.Context, list []blocklist.Rule) (int, error) {
+	ctx, span := startSpan(ctx, "DisableMatching")
+	defer span.End()
+
+	if len(list) == 0 {
+		return 0, nil
+	}
+	match := func(origin string) (blocklist.Rule, bool) {
+		for _, rule := range list {
+			if rule.Match(origin) {
+				return rule, true
+			}
+		}
+		return blocklist.Rule{}, false
+	}
+
+	if s.Mode != "database" {
+		total := 0
+		for _, rule := range list {
+			disabled := s.Store.DisableWhere(func(e model.Entry) bool { return rule.Match(e.OriginalURL) }, "blocklist: "+rule.String())
+			s.invalidate(disabled...)
+			total += len(disabled)
+		}
+		return total, nil
+	}
+
+	byRule := make(map[string][]string)
+	err := s.Repo.ForEachActiveURL(ctx, func(u *model.URLObject) error {
+		if rule, ok := match(u.Origin); ok {
+			byRule[rule.String()] = append(byRule[rule.String()], u.Shorten)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for rule, ids := range byRule {
+		if err := s.Repo.DisableURLs(ctx, ids, "blocklist: "+rule); err != nil {
+			return total, err
+		}
+		s.invalidate(ids...)
+		total += len(ids)
+	}
+	return total, nil
+}
+
+func (s *ShortenerService) canonicalize(raw string) (string, error) {
+	if s.Canonicalizer == nil {
+		return raw, nil
 	}
+	normalized, err := s.Canonicalizer.Canonicalize(raw)
+	if err != nil {
+		return "", &DestinationError{Code: CodeInvalidURL, URL: raw, Err: err}
+	}
+	return normalized, nil
 }
 
 func (s *ShortenerService) ShortenURL(ctx context.Context, userID, originalURL string) (string, error) {
-	short := util.GenerateShortURL(originalURL)
-	urlObj := &model.URLObject{
-		Origin:  originalURL,
-		Shorten: short,
-		Created: time.Now(),
-		UserID:  userID,
+	ctx, span := startSpan(ctx, "ShortenURL")
+	defer span.End()
+
+	if err := s.checkBanned(ctx, userID); err != nil {
+		return "", err
+	}
+	originalURL, err := s.checkDestination(ctx, originalURL)
+	if err != nil {
+		return "", err
 	}
 
 	if s.Mode == "database" {
-		err := s.Repo.SaveURL(ctx, urlObj)
-		return short, err
+		for attempt := 0; ; attempt++ {
+			urlObj := &model.URLObject{
+				Origin:  originalURL,
+				Shorten: shortCode(originalURL, attempt),
+				Created: time.Now(),
+				UserID:  userID,
+			}
+			err := s.Repo.SaveURL(ctx, urlObj)
+			if errors.Is(err, repositories.ErrShortenTaken) && attempt+1 < maxCodeAttempts {
+				continue
+			}
+			if err == nil {
+				s.fetchMetadata(urlObj.Shorten, originalURL)
+			}
+			return urlObj.Shorten, err
+		}
+	}
+	short, created, err := s.saveToStore(originalURL, userID)
+	if err != nil {
+		return "", err
+	}
+	if created {
+		s.fetchMetadata(short, originalURL)
 	}
-	s.Store.Save(short, originalURL, userID)
 	return short, nil
 }
 
+// shortCode возвращает идентификатор ссылки; при повторных попытках хеш
+// вычисляется с номером попытки, чтобы обойти занятый код.
+func shortCode(originalURL string, attempt int) string {
+	if attempt == 0 {
+		return util.GenerateShortURL(originalURL)
+	}
+	return util.GenerateShortURL(fmt.Sprintf("%s#%d", originalURL, attempt))
+}
+
+// saveToStore сохраняет ссылку в файловое хранилище. Адрес назначения уникален,
+// как в базе данных: если активная ссылка на него уже есть, даже под кодом,
+// заданным правкой адреса, возвращает её код и false; удалённая ссылка на этот
+// адрес создаётся заново под своим кодом. Если все попытки подобрать код заняты
+// ссылками с другими адресами, возвращает ErrShortenTaken.
+func (s *ShortenerService) saveToStore(originalURL, userID string) (string, bool, error) {
+	if entry, exists := s.Store.FindByOrigin(originalURL); exists {
+		if !entry.IsDeleted {
+			return entry.ShortURL, false, nil
+		}
+		s.Store.Save(entry.ShortURL, originalURL, userID)
+		return entry.ShortURL, true, nil
+	}
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		short := shortCode(originalURL, attempt)
+		if _, exists := s.Store.GetEntry(short); exists {
+			continue
+		}
+		s.Store.Save(short, originalURL, userID)
+		return short, true, nil
+	}
+	return "", false, repositories.ErrShortenTaken
+}
+
+// invalidate удаляет ссылки из кеша разрешения.
+func (s *ShortenerService) invalidate(ids ...string) {
+	if s.Cache != nil && len(ids) > 0 {
+		s.Cache.Delete(ids...)
+	}
+}
+
+// fetchMetadata ставит загрузку метаданных страницы назначения в очередь.
+func (s *ShortenerService) fetchMetadata(short, originalURL string) {
+	if s.Metadata != nil {
+		s.Metadata.Enqueue(short, originalURL)
+	}
+}
+
+// SaveMetadata сохраняет метаданные страницы url, загруженные для ссылки short.
+// Если адрес назначения ссылки с тех пор изменился, метаданные устарели и отбрасываются.
+func (s *ShortenerService) SaveMetadata(ctx context.Context, short, url string, meta model.LinkMetadata) error {
+	ctx, span := startSpan(ctx, "SaveMetadata")
+	defer span.End()
+
+	var ok bool
+	if s.Mode == "database" {
+		var err error
+		ok, err = s.Repo.SaveMetadata(ctx, short, url, meta)
+		if err != nil {
+			return err
+		}
+	} else {
+		ok = s.Store.SetMetadata(short, url, meta)
+	}
+	if ok {
+		s.invalidate(short)
+	}
+	return nil
+}
+
 func (s *ShortenerService) ResolveURL(ctx context.Context, id string) (*model.URLObject, error) {
-	if s.Mode == "memory" || s.Mode == "file" {
-		original, ok := s.Store.Get(id)
+	ctx, span := startSpan(ctx, "ResolveURL")
+	defer span.End()
+
+	if s.Cache != nil {
+		if urlObj, ok := s.Cache.Get(id); ok {
+			return urlObj, nil
+		}
+	}
+
+	var urlObj *model.URLObject
+	if s.Mode != "database" {
+		entry, ok := s.Store.GetEntry(id)
 		if !ok {
 			return nil, nil
 		}
-		return &model.URLObject{Origin: original}, nil
+		urlObj = entryToURLObject(entry)
+	} else {
+		var err error
+		urlObj, err = s.Repo.GetURL(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if urlObj != nil && s.Cache != nil {
+		s.Cache.Set(id, urlObj)
+	}
+	return urlObj, nil
+}
+
+// RecordClick передаёт событие перехода в фоновую запись, сохраняя вместо
+// адреса клиента его хеш, и публикует его подписчикам владельца ссылки.
+// Не блокирует вызывающего.
+func (s *ShortenerService) RecordClick(click model.Click, owner, clientIP string) {
+	if s.Clicks != nil {
+		click.IPHash = s.Clicks.HashIP(clientIP)
+		s.Clicks.Track(click)
+	}
+	if s.ClickStream != nil && owner != "" {
+		s.ClickStream.Publish(owner, click)
+	}
+}
+
+// SubscribeClicks подписывает пользователя на переходы по его ссылкам.
+// Подписку нужно закрыть через Close.
+func (s *ShortenerService) SubscribeClicks(userID string) (*pubsub.Subscription[model.Click], error) {
+	if s.ClickStream == nil {
+		return nil, ErrStreamUnavailable
+	}
+	return s.ClickStream.Subscribe(userID), nil
+}
+
+// Heartbeat возвращает интервал служебных сообщений потока событий.
+func (s *ShortenerService) Heartbeat() time.Duration {
+	if s.StreamHeartbeat <= 0 {
+		return DefaultStreamHeartbeat
+	}
+	return s.StreamHeartbeat
+}
+
+// UpdateDestination меняет адрес назначения ссылки владельца, сохраняя код.
+// Каждое изменение записывается в историю ревизий.
+func (s *ShortenerService) UpdateDestination(ctx context.Context, userID, short, rawURL string) (model.Revision, error) {
+	ctx, span := startSpan(ctx, "UpdateDestination")
+	defer span.End()
+
+	if err := s.checkBanned(ctx, userID); err != nil {
+		return model.Revision{}, err
+	}
+	origin, err := s.checkDestination(ctx, rawURL)
+	if err != nil {
+		return model.Revision{}, err
+	}
+
+	var (
+		rev model.Revision
+		ok  bool
+	)
+	if s.Mode == "database" {
+		rev, ok, err = s.Repo.UpdateOrigin(ctx, short, userID, origin)
+		if errors.Is(err, repositories.ErrOriginExists) {
+			return model.Revision{}, ErrDestinationTaken
+		}
+		if err != nil {
+			return model.Revision{}, err
+		}
+	} else {
+		rev, ok, err = s.Store.UpdateOrigin(short, userID, origin)
+		if errors.Is(err, storage.ErrOriginExists) {
+			return model.Revision{}, ErrDestinationTaken
+		}
+		if err != nil {
+			return model.Revision{}, err
+		}
 	}
+	if !ok {
+		return model.Revision{}, ErrURLNotFound
+	}
+
+	s.invalidate(short)
+	if rev.OldURL != rev.NewURL {
+		s.fetchMetadata(short, origin)
+	}
+	return rev, nil
+}
 
-	urlObj, err := s.Repo.GetURL(ctx, id)
+// GetRevisions возвращает историю изменений адреса назначения ссылки владельца.
+func (s *ShortenerService) GetRevisions(ctx context.Context, userID, short string) ([]model.Revision, error) {
+	ctx, span := startSpan(ctx, "GetRevisions")
+	defer span.End()
+
+	urlObj, err := s.ResolveURL(ctx, short)
 	if err != nil {
 		return nil, err
 	}
-	return urlObj, nil
+	if urlObj == nil || urlObj.IsDeleted || urlObj.UserID != userID {
+		return nil, ErrURLNotFound
+	}
+	if s.Mode == "database" {
+		return s.Repo.GetRevisions(ctx, short)
+	}
+	entry, _ := s.Store.GetEntry(short)
+	return entry.Revisions, nil
+}
+
+// GetLinkStats возвращает статистику переходов по ссылке владельца за период запроса.
+func (s *ShortenerService) GetLinkStats(ctx context.Context, userID, short string, q analytics.Query) (*model.LinkStats, error) {
+	ctx, span := startSpan(ctx, "GetLinkStats")
+	defer span.End()
+
+	urlObj, err := s.ResolveURL(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+	if urlObj == nil || urlObj.IsDeleted || urlObj.UserID != userID {
+		return nil, ErrURLNotFound
+	}
+
+	var summary model.ClickSummary
+	switch {
+	case s.Mode == "database":
+		summary, err = s.Repo.GetClickSummary(ctx, short, q.From, q.To, q.Interval, q.IncludeBots)
+	case s.ClicksPath != "":
+		summary, err = analytics.ScanFile(s.ClicksPath, short, q)
+	default:
+		summary = model.NewClickSummary()
+	}
+	if err != nil {
+		return nil, err
+	}
+	stats := analytics.Build(short, q, summary)
+
+	days, err := s.visitorSketches(ctx, short, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	analytics.Visitors(stats, q, days)
+	return stats, nil
+}
+
+// visitorSketches возвращает дневные скетчи посетителей ссылки за период.
+func (s *ShortenerService) visitorSketches(ctx context.Context, short string, from, to time.Time) (map[time.Time]*hll.Sketch, error) {
+	switch {
+	case s.Mode == "database":
+		return s.Repo.GetSketches(ctx, short, from, to)
+	case s.Sketches != nil:
+		return s.Sketches.Load(short, from, to)
+	default:
+		return nil, nil
+	}
+}
+
+// startSpan открывает span уровня сервиса.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "ShortenerService."+name)
+}
+
+func entryToURLObject(entry model.Entry) *model.URLObject {
+	return &model.URLObject{
+		Origin:       entry.OriginalURL,
+		Shorten:      entry.ShortURL,
+		Created:      entry.Created,
+		UserID:       entry.UserID,
+		IsDeleted:    entry.IsDeleted,
+		DeletedAt:    entry.DeletedAt,
+		Rules:        entry.Rules,
+		ForcePreview: entry.ForcePreview,
+
+		IsDisabled:     entry.IsDisabled,
+		DisabledReason: entry.DisabledReason,
+		Health:         entry.Health,
+		Metadata:       entry.Metadata,
+	}
+}
+
+// CheckDestinations проверяет доступность адресов всех активных ссылок
+// и сохраняет результаты. Возвращает количество проверенных ссылок.
+func (s *ShortenerService) CheckDestinations(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "CheckDestinations")
+	defer span.End()
+
+	if s.Checker == nil {
+		return 0, nil
+	}
+
+	var targets []healthcheck.Target
+	if s.Mode != "database" {
+		for _, entry := range s.Store.ActiveEntries() {
+			targets = append(targets, healthcheck.Target{Short: entry.ShortURL, URL: entry.OriginalURL})
+		}
+	} else {
+		err := s.Repo.ForEachActiveURL(ctx, func(u *model.URLObject) error {
+			targets = append(targets, healthcheck.Target{Short: u.Shorten, URL: u.Origin})
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	results := s.Checker.CheckAll(ctx, targets)
+	if s.Mode != "database" {
+		for _, r := range results {
+			s.Store.SetHealth(r.Short, r.Health)
+		}
+		return len(results), nil
+	}
+
+	byShort := make(map[string]model.LinkHealth, len(results))
+	for _, r := range results {
+		byShort[r.Short] = r.Health
+	}
+	if err := s.Repo.SaveHealth(ctx, byShort); err != nil {
+		return 0, err
+	}
+	return len(results), nil
+}
+
+// GetBrokenURLs возвращает ссылки пользователя, адрес назначения которых
+// при последней проверке оказался недоступен.
+func (s *ShortenerService) GetBrokenURLs(ctx context.Context, userID string) ([]*model.URLObject, error) {
+	ctx, span := startSpan(ctx, "GetBrokenURLs")
+	defer span.End()
+
+	if s.Mode == "database" {
+		return s.Repo.GetBrokenURLsByUserID(ctx, userID)
+	}
+	var result []*model.URLObject
+	for _, entry := range s.Store.GetEntriesByUser(userID) {
+		if entry.IsDeleted || entry.Health == nil || !entry.Health.Broken() {
+			continue
+		}
+		result = append(result, entryToURLObject(entry))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Health.CheckedAt.After(result[j].Health.CheckedAt)
+	})
+	return result, nil
+}
+
+// SetRedirectRules заменяет упорядоченный список правил перенаправления ссылки.
+// Пустой список отключает правила, ссылка снова ведёт на оригинальный URL.
+func (s *ShortenerService) SetRedirectRules(ctx context.Context, userID, short string, list []model.RedirectRule) error {
+	ctx, span := startSpan(ctx, "SetRedirectRules")
+	defer span.End()
+
+	if err := rules.Validate(list); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRules, err)
+	}
+	for i := range list {
+		target, err := s.checkDestination(ctx, list[i].Target)
+		if err != nil {
+			return err
+		}
+		list[i].Target = target
+	}
+
+	var ok bool
+	if s.Mode == "database" {
+		var err error
+		ok, err = s.Repo.UpdateRules(ctx, short, userID, list)
+		if err != nil {
+			return err
+		}
+	} else {
+		ok = s.Store.SetRules(short, userID, list)
+	}
+	if !ok {
+		return ErrURLNotFound
+	}
+	s.invalidate(short)
+	return nil
+}
+
+// GetRedirectRules возвращает правила перенаправления ссылки пользователя.
+func (s *ShortenerService) GetRedirectRules(ctx context.Context, userID, short string) ([]model.RedirectRule, error) {
+	ctx, span := startSpan(ctx, "GetRedirectRules")
+	defer span.End()
+
+	urlObj, err := s.ResolveURL(ctx, short)
+	if err != nil {
+		return nil, err
+	}
+	if urlObj == nil || urlObj.IsDeleted || urlObj.UserID != userID {
+		return nil, ErrURLNotFound
+	}
+	return urlObj.Rules, nil
+}
+
+// SetForcePreview включает или выключает показ страницы предпросмотра
+// вместо немедленного перенаправления для ссылки владельца.
+func (s *ShortenerService) SetForcePreview(ctx context.Context, userID, short string, enabled bool) error {
+	ctx, span := startSpan(ctx, "SetForcePreview")
+	defer span.End()
+
+	var ok bool
+	if s.Mode == "database" {
+		var err error
+		ok, err = s.Repo.SetForcePreview(ctx, short, userID, enabled)
+		if err != nil {
+			return err
+		}
+	} else {
+		ok = s.Store.SetForcePreview(short, userID, enabled)
+	}
+	if !ok {
+		return ErrURLNotFound
+	}
+	s.invalidate(short)
+	return nil
 }
 
 func (s *ShortenerService) BatchShorten(ctx context.Context, userID string, items []model.BatchItem) ([]model.BatchResult, error) {
+	ctx, span := startSpan(ctx, "BatchShorten")
+	defer span.End()
+
 	results := make([]model.BatchResult, 0, len(items))
 	for _, item := range items {
 		short, err := s.ShortenURL(ctx, userID, item.OriginalURL)
 		if err != nil {
+			var destErr *DestinationError
+			if errors.As(err, &destErr) {
+				destErr.CorrelationID = item.CorrelationID
+			}
 			return nil, err
 		}
 		results = append(results, model.BatchResult{
@@ -93,7 +843,25 @@ func (s *ShortenerService) BatchShorten(ctx context.Context, userID string, item
 	return results, nil
 }
 
+// DeleteURLsAsync удаляет ссылки в фоне, не задерживая ответ клиенту.
+func (s *ShortenerService) DeleteURLsAsync(userID string, ids []string) {
+	s.pendingDeletes.Add(1)
+	go func() {
+		defer s.pendingDeletes.Add(-1)
+		s.DeleteURLs(context.Background(), userID, ids)
+	}()
+}
+
+// PendingDeletions возвращает количество фоновых удалений, которые ещё не завершены.
+func (s *ShortenerService) PendingDeletions() int64 {
+	return s.pendingDeletes.Load()
+}
+
 func (s *ShortenerService) DeleteURLs(ctx context.Context, userID string, ids []string) {
+	ctx, span := startSpan(ctx, "DeleteURLs")
+	defer span.End()
+
+	defer s.invalidate(ids...)
 	if s.Mode == "database" {
 		s.Repo.MarkURLsAsDeleted(ctx, ids, userID)
 		return
@@ -101,7 +869,53 @@ func (s *ShortenerService) DeleteURLs(ctx context.Context, userID string, ids []
 	s.Store.MarkDeleted(ids, userID)
 }
 
+// GetTrash возвращает удалённые ссылки пользователя, которые ещё можно восстановить,
+// начиная с недавно удалённых.
+func (s *ShortenerService) GetTrash(ctx context.Context, userID string) ([]*model.URLObject, error) {
+	ctx, span := startSpan(ctx, "GetTrash")
+	defer span.End()
+
+	since := time.Now().Add(-s.TrashRetention)
+	if s.Mode == "database" {
+		return s.Repo.GetDeletedURLsByUserID(ctx, userID, since)
+	}
+	var result []*model.URLObject
+	for _, entry := range s.Store.GetEntriesByUser(userID) {
+		if entry.IsDeleted && entry.DeletedAt != nil && !entry.DeletedAt.Before(since) {
+			result = append(result, entryToURLObject(entry))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].DeletedAt.After(*result[j].DeletedAt)
+	})
+	return result, nil
+}
+
+// RestoreURLs восстанавливает удалённые ссылки пользователя в пределах срока хранения.
+// Возвращает идентификаторы восстановленных ссылок.
+func (s *ShortenerService) RestoreURLs(ctx context.Context, userID string, ids []string) ([]string, error) {
+	ctx, span := startSpan(ctx, "RestoreURLs")
+	defer span.End()
+
+	since := time.Now().Add(-s.TrashRetention)
+	var restored []string
+	if s.Mode == "database" {
+		var err error
+		restored, err = s.Repo.RestoreURLs(ctx, ids, userID, since)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		restored = s.Store.Restore(ids, userID, since)
+	}
+	s.invalidate(restored...)
+	return restored, nil
+}
+
 func (s *ShortenerService) GetUserURLs(ctx context.Context, userID string) ([]model.BatchResult, error) {
+	ctx, span := startSpan(ctx, "GetUserURLs")
+	defer span.End()
+
 	var results []model.BatchResult
 	if s.Mode == "database" {
 		urls, err := s.Repo.GetURLsByUserID(ctx, userID)
@@ -112,70 +926,997 @@ func (s *ShortenerService) GetUserURLs(ctx context.Context, userID string) ([]mo
 			results = append(results, model.BatchResult{
 				ShortURL:    u.Shorten,
 				OriginalURL: u.Origin,
+				Metadata:    u.Metadata,
 			})
 		}
 		return results, nil
 	}
-	m := s.Store.GetByUser(userID)
-	for short, origin := range m {
+	for _, entry := range s.Store.GetEntriesByUser(userID) {
+		if entry.IsDeleted {
+			continue
+		}
 		results = append(results, model.BatchResult{
-			ShortURL:    short,
-			OriginalURL: origin,
+			ShortURL:    entry.ShortURL,
+			OriginalURL: entry.OriginalURL,
+			Metadata:    entry.Metadata,
 		})
 	}
 	return results, nil
 }
 
-func (s *ShortenerService) GetStats(ctx context.Context) (int, int, error) {
-	if s.Mode != "database" {
-		return 0, 0, nil // В file/memory режимах статистика недоступна
-	}
-	urls, users, err := s.Repo.GetStats(ctx)
-	if err != nil {
-		s.Logger.Error("Failed to retrieve stats", zap.Error(err))
-		return 0, 0, err
-	}
-	return urls, users, nil
-}
+// GetStats возвращает сводную статистику сервиса: количество ссылок по
+// состояниям, число пользователей, созданные по дням ссылки и самые
+// популярные ссылки за последние days дней (включая текущий). Переходы ботов
+// учитываются в популярности ссылок, только если includeBots.
+func (s *ShortenerService) GetStats(ctx context.Context, days int, includeBots bool) (*model.ServiceStats, error) {
+	ctx, span := startSpan(ctx, "GetStats")
+	defer span.End()
 
-func (s *ShortenerService) Ping(ctx context.Context) error {
-	if s.Mode != "database" {
-		return nil // Ping актуален только для database
-	}
-	return s.Repo.Ping(ctx)
+	now := time.Now().UTC()
+	window := analytics.Query{Interval: analytics.IntervalDay}
+	window.To = window.Truncate(now).AddDate(0, 0, 1)
+	window.From = window.To.AddDate(0, 0, -days)
+
+	var (
+		counts  model.LinkCounts
+		created map[time.Time]int64
+		top     []model.TopLink
+		err     error
+	)
+	if s.Mode == "database" {
+		if counts, err = s.Repo.CountLinks(ctx); err != nil {
+			return nil, err
+		}
+		if created, err = s.Repo.CountCreatedByDay(ctx, window.From); err != nil {
+			return nil, err
+		}
+		if top, err = s.Repo.TopClickedURLs(ctx, window.From, analytics.TopN, includeBots); err != nil {
+			return nil, err
+		}
+	} else {
+		entries := s.Store.Entries()
+		counts, created = countEntries(entries, window)
+		if top, err = s.topLinksFromFile(entries, window.From, includeBots); err != nil {
+			return nil, err
+		}
+	}
+
+	sketches, err := s.visitorSketches(ctx, analytics.AllLinks, window.From, window.To)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &model.ServiceStats{
+		URLs:       counts.Active,
+		LinkCounts: counts,
+		Since:      window.From,
+		Visitors:   analytics.Estimate(sketches),
+
+		IncludeBots: includeBots,
+		TopLinks:    top,
+	}
+	buckets, _ := analytics.Series(window, created)
+	stats.CreatedPerDay = make([]model.DayCount, 0, len(buckets))
+	for _, b := range buckets {
+		stats.CreatedPerDay = append(stats.CreatedPerDay, model.DayCount{Day: b.Start, Count: b.Clicks})
+	}
+	if stats.TopLinks == nil {
+		stats.TopLinks = []model.TopLink{}
+	}
+	return stats, nil
+}
+
+// countEntries считает записи хранилища по состояниям и по дням создания в окне.
+func countEntries(entries []model.Entry, window analytics.Query) (model.LinkCounts, map[time.Time]int64) {
+	var counts model.LinkCounts
+	created := map[time.Time]int64{}
+	users := map[string]struct{}{}
+	for _, e := range entries {
+		counts.Total++
+		switch {
+		case e.IsDeleted:
+			counts.Deleted++
+		case e.IsDisabled:
+			counts.Disabled++
+		default:
+			counts.Active++
+		}
+		if e.UserID != "" && !orgs.IsOwner(e.UserID) {
+			users[e.UserID] = struct{}{}
+		}
+		if window.Contains(e.Created) {
+			created[window.Truncate(e.Created)]++
+		}
+	}
+	counts.Users = int64(len(users))
+	return counts, created
+}
+
+// topLinksFromFile выбирает самые популярные ссылки по файлу событий переходов.
+func (s *ShortenerService) topLinksFromFile(entries []model.Entry, since time.Time, includeBots bool) ([]model.TopLink, error) {
+	if s.ClicksPath == "" {
+		return nil, nil
+	}
+	clickCounts, err := analytics.CountFile(s.ClicksPath, since, includeBots)
+	if err != nil {
+		return nil, err
+	}
+	origins := make(map[string]string, len(entries))
+	for _, e := range entries {
+		origins[e.ShortURL] = e.OriginalURL
+	}
+
+	top := make([]model.TopLink, 0, len(clickCounts))
+	for short, n := range clickCounts {
+		top = append(top, model.TopLink{Short: short, Origin: origins[short], Clicks: n})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Clicks != top[j].Clicks {
+			return top[i].Clicks > top[j].Clicks
+		}
+		return top[i].Short < top[j].Short
+	})
+	if len(top) > analytics.TopN {
+		top = top[:analytics.TopN]
+	}
+	return top, nil
+}
+
+func (s *ShortenerService) Ping(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Ping")
+	defer span.End()
+
+	if s.Mode != "database" {
+		return nil // Ping актуален только для database
+	}
+	return s.Repo.Ping(ctx)
 }
 func (s *ShortenerService) CreateBatchShortURLs(ctx context.Context, userID string, items []model.BatchItem) ([]model.BatchResult, error) {
+	ctx, span := startSpan(ctx, "CreateBatchShortURLs")
+	defer span.End()
+
+	if err := s.checkBanned(ctx, userID); err != nil {
+		return nil, err
+	}
 	results := make([]model.BatchResult, 0, len(items))
 	urlObjs := make([]*model.URLObject, 0, len(items))
 
 	for _, item := range items {
-		short := util.GenerateShortURL(item.OriginalURL)
-		shortURL := fmt.Sprintf("%s/%s", s.BaseURL, short)
-
-		urlObj := &model.URLObject{
-			Origin:  item.OriginalURL,
-			Shorten: short,
+		originalURL, err := s.checkDestination(ctx, item.OriginalURL)
+		if err != nil {
+			var destErr *DestinationError
+			if errors.As(err, &destErr) {
+				destErr.CorrelationID = item.CorrelationID
+			}
+			return nil, err
+		}
+		urlObjs = append(urlObjs, &model.URLObject{
+			Origin:  originalURL,
+			Shorten: shortCode(originalURL, 0),
 			Created: time.Now(),
 			UserID:  userID,
-		}
-		urlObjs = append(urlObjs, urlObj)
-
-		results = append(results, model.BatchResult{
-			CorrelationID: item.CorrelationID,
-			ShortURL:      shortURL,
 		})
+		results = append(results, model.BatchResult{CorrelationID: item.CorrelationID})
 	}
 
 	if s.Mode == "database" {
-		if err := s.Repo.SaveBatchURLs(ctx, urlObjs); err != nil {
+		if err := s.saveBatch(ctx, urlObjs); err != nil {
 			s.Logger.Error("failed to save batch URLs", zap.Error(err))
 			return nil, err
 		}
+		for i, u := range urlObjs {
+			results[i].ShortURL = fmt.Sprintf("%s/%s", s.BaseURL, u.Shorten)
+			s.fetchMetadata(u.Shorten, u.Origin)
+		}
 	} else {
-		for _, u := range urlObjs {
-			s.Store.Save(u.Shorten, u.Origin, u.UserID)
+		for i, u := range urlObjs {
+			short, created, err := s.saveToStore(u.Origin, u.UserID)
+			if err != nil {
+				return nil, err
+			}
+			results[i].ShortURL = fmt.Sprintf("%s/%s", s.BaseURL, short)
+			if created {
+				s.fetchMetadata(short, u.Origin)
+			}
 		}
 	}
 
 	return results, nil
 }
+
+// saveBatch сохраняет пакет ссылок в базу данных. Если код ссылки занят ссылкой,
+// адрес которой изменили, пакет сохраняется заново со следующим кодом для неё,
+// как в ShortenURL.
+func (s *ShortenerService) saveBatch(ctx context.Context, urlObjs []*model.URLObject) error {
+	attempts := make([]int, len(urlObjs))
+	for {
+		err := s.Repo.SaveBatchURLs(ctx, urlObjs)
+		if !errors.Is(err, repositories.ErrShortenTaken) {
+			return err
+		}
+		// Ссылки сохраняются по порядку: конфликт у первой, которой не выдан ID
+		i := 0
+		for i < len(urlObjs)-1 && urlObjs[i].ID != 0 {
+			i++
+		}
+		if attempts[i]+1 >= maxCodeAttempts {
+			return err
+		}
+		attempts[i]++
+		urlObjs[i].Shorten = shortCode(urlObjs[i].Origin, attempts[i])
+		for _, u := range urlObjs {
+			u.ID = 0
+		}
+	}
+}
+
+// CreateAPIKey создаёт ключ API пользователя с областями доступа scopes.
+// Возвращает сохранённый ключ и сам ключ, который больше нигде не хранится.
+func (s *ShortenerService) CreateAPIKey(ctx context.Context, userID, name string, scopes []string) (model.APIKey, string, error) {
+	ctx, span := startSpan(ctx, "CreateAPIKey")
+	defer span.End()
+
+	if len(scopes) == 0 {
+		return model.APIKey{}, "", fmt.Errorf("%w: at least one scope is required", auth.ErrInvalidScope)
+	}
+	if err := auth.ValidateScopes(scopes); err != nil {
+		return model.APIKey{}, "", err
+	}
+	raw, id, hash, err := apikeys.Generate()
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+	key := model.APIKey{
+		ID:      id,
+		UserID:  userID,
+		Name:    name,
+		Hash:    hash,
+		Scopes:  scopes,
+		Created: time.Now().UTC().Truncate(time.Microsecond),
+	}
+	if s.Mode == "database" {
+		err = s.Repo.CreateAPIKey(ctx, key)
+	} else {
+		err = s.APIKeys.Create(key)
+	}
+	if err != nil {
+		return model.APIKey{}, "", err
+	}
+	return key, raw, nil
+}
+
+// ListAPIKeys возвращает неотозванные ключи API пользователя.
+func (s *ShortenerService) ListAPIKeys(ctx context.Context, userID string) ([]model.APIKey, error) {
+	ctx, span := startSpan(ctx, "ListAPIKeys")
+	defer span.End()
+
+	if s.Mode == "database" {
+		return s.Repo.ListAPIKeys(ctx, userID)
+	}
+	return s.APIKeys.List(userID)
+}
+
+// RevokeAPIKey отзывает ключ API пользователя.
+func (s *ShortenerService) RevokeAPIKey(ctx context.Context, userID, id string) error {
+	ctx, span := startSpan(ctx, "RevokeAPIKey")
+	defer span.End()
+
+	var (
+		ok  bool
+		err error
+	)
+	if s.Mode == "database" {
+		ok, err = s.Repo.RevokeAPIKey(ctx, userID, id)
+	} else {
+		ok, err = s.APIKeys.Revoke(userID, id)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// VerifyAPIKey проверяет ключ API и возвращает его владельца и области доступа.
+// Реализует auth.KeyVerifier.
+func (s *ShortenerService) VerifyAPIKey(ctx context.Context, raw string) (string, []string, error) {
+	ctx, span := startSpan(ctx, "VerifyAPIKey")
+	defer span.End()
+
+	id, secret, ok := apikeys.Split(raw)
+	if !ok {
+		return "", nil, auth.ErrInvalidToken
+	}
+	var key model.APIKey
+	if s.Mode == "database" {
+		stored, err := s.Repo.GetAPIKey(ctx, id)
+		if err != nil {
+			return "", nil, err
+		}
+		if ok = stored != nil; ok {
+			key = *stored
+		}
+	} else {
+		var err error
+		if key, ok, err = s.APIKeys.Get(id); err != nil {
+			return "", nil, err
+		}
+	}
+	if !ok || key.RevokedAt != nil || !apikeys.Match(key, secret) {
+		return "", nil, auth.ErrInvalidToken
+	}
+	return key.UserID, key.Scopes, nil
+}
+
+// Login — результат входа или регистрации.
+type Login struct {
+	Account   model.Account
+	Token     string
+	ExpiresAt time.Time
+	// Claimed — число анонимных ссылок, перенесённых в учётную запись.
+	Claimed int64
+}
+
+// Register создаёт учётную запись и открывает сессию. Ссылки анонимного
+// пользователя anonUserID, если он задан, переносятся в учётную запись.
+func (s *ShortenerService) Register(ctx context.Context, email, password, anonUserID string) (*Login, error) {
+	ctx, span := startSpan(ctx, "Register")
+	defer span.End()
+
+	email = accounts.NormalizeEmail(email)
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return nil, fmt.Errorf("%w: email is not valid", ErrInvalidAccount)
+	}
+	if n := utf8.RuneCountInString(password); n < MinPasswordLength || n > MaxPasswordLength {
+		return nil, fmt.Errorf("%w: password must be %d to %d characters", ErrInvalidAccount, MinPasswordLength, MaxPasswordLength)
+	}
+	hash, err := accounts.HashPassword(password, s.PasswordParams)
+	if err != nil {
+		return nil, err
+	}
+	acc := model.Account{
+		ID:           uuid.NewString(),
+		Email:        email,
+		PasswordHash: hash,
+		Created:      time.Now().UTC().Truncate(time.Microsecond),
+	}
+	var created bool
+	if s.Mode == "database" {
+		created, err = s.Repo.CreateAccount(ctx, acc)
+	} else {
+		created, err = s.Accounts.CreateAccount(acc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, ErrAccountExists
+	}
+	return s.openSession(ctx, acc, anonUserID)
+}
+
+// Login проверяет пароль и открывает сессию. Ссылки анонимного пользователя
+// anonUserID, если он задан, переносятся в учётную запись.
+func (s *ShortenerService) Login(ctx context.Context, email, password, anonUserID string) (*Login, error) {
+	ctx, span := startSpan(ctx, "Login")
+	defer span.End()
+
+	email = accounts.NormalizeEmail(email)
+	var (
+		acc   model.Account
+		found bool
+	)
+	if s.Mode == "database" {
+		stored, err := s.Repo.GetAccountByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if found = stored != nil; found {
+			acc = *stored
+		}
+	} else {
+		var err error
+		if acc, found, err = s.Accounts.GetAccountByEmail(email); err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		// Хешируем впустую, чтобы по времени ответа нельзя было узнать, есть ли адрес
+		_, _ = accounts.HashPassword(password, s.PasswordParams)
+		return nil, ErrInvalidCredentials
+	}
+	ok, err := accounts.VerifyPassword(password, acc.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+	return s.openSession(ctx, acc, anonUserID)
+}
+
+// LoginIdentity открывает сессию пользователя, вошедшего через внешний провайдер.
+// Субъект провайдера при первом входе сопоставляется новому пользователю;
+// ссылки анонимного пользователя anonUserID, если он задан, переносятся ему.
+func (s *ShortenerService) LoginIdentity(ctx context.Context, issuer, subject, email, anonUserID string) (*Login, error) {
+	ctx, span := startSpan(ctx, "LoginIdentity")
+	defer span.End()
+
+	if issuer == "" || subject == "" {
+		return nil, fmt.Errorf("%w: identity has no issuer or subject", ErrInvalidAccount)
+	}
+	var (
+		userID string
+		err    error
+	)
+	if s.Mode == "database" {
+		userID, err = s.Repo.ResolveIdentity(ctx, issuer, subject, uuid.NewString())
+	} else {
+		userID, err = s.Accounts.ResolveIdentity(issuer, subject, uuid.NewString())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.openSession(ctx, model.Account{ID: userID, Email: accounts.NormalizeEmail(email)}, anonUserID)
+}
+
+// openSession переносит анонимные ссылки и создаёт сессию учётной записи.
+func (s *ShortenerService) openSession(ctx context.Context, acc model.Account, anonUserID string) (*Login, error) {
+	login := &Login{Account: acc}
+	if anonUserID != "" && anonUserID != acc.ID {
+		claimed, err := s.claimLinks(ctx, anonUserID, acc.ID)
+		if err != nil {
+			return nil, err
+		}
+		login.Claimed = claimed
+	}
+
+	token, hash, err := accounts.NewSessionToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	session := model.Session{ID: hash, UserID: acc.ID, Created: now, ExpiresAt: now.Add(s.SessionTTL)}
+	if s.Mode == "database" {
+		err = s.Repo.CreateSession(ctx, session)
+	} else {
+		err = s.Accounts.CreateSession(session)
+	}
+	if err != nil {
+		return nil, err
+	}
+	login.Token, login.ExpiresAt = token, session.ExpiresAt
+	return login, nil
+}
+
+// claimLinks передаёт ссылки, ключи API и участие в организациях анонимного
+// пользователя учётной записи.
+func (s *ShortenerService) claimLinks(ctx context.Context, from, to string) (int64, error) {
+	var claimed int64
+	if s.Mode == "database" {
+		var err error
+		if claimed, err = s.Repo.ReassignUser(ctx, from, to); err != nil {
+			return 0, err
+		}
+	} else {
+		claimed = int64(s.Store.Reassign(from, to))
+		if err := s.APIKeys.Reassign(from, to); err != nil {
+			return 0, err
+		}
+		if err := s.Orgs.Reassign(from, to); err != nil {
+			return 0, err
+		}
+	}
+	if claimed > 0 && s.Cache != nil {
+		// Владелец хранится в закешированных ссылках
+		s.Cache.Clear()
+	}
+	s.Logger.Info("Anonymous links claimed", zap.String("account", to), zap.Int64("links", claimed))
+	return claimed, nil
+}
+
+// Logout завершает сессию.
+func (s *ShortenerService) Logout(ctx context.Context, token string) error {
+	ctx, span := startSpan(ctx, "Logout")
+	defer span.End()
+
+	if s.Mode == "database" {
+		return s.Repo.DeleteSession(ctx, accounts.HashToken(token))
+	}
+	return s.Accounts.DeleteSession(accounts.HashToken(token))
+}
+
+// VerifySession возвращает владельца действующей сессии. Реализует auth.SessionVerifier.
+func (s *ShortenerService) VerifySession(ctx context.Context, token string) (string, error) {
+	ctx, span := startSpan(ctx, "VerifySession")
+	defer span.End()
+
+	var (
+		session model.Session
+		found   bool
+	)
+	if s.Mode == "database" {
+		stored, err := s.Repo.GetSession(ctx, accounts.HashToken(token))
+		if err != nil {
+			return "", err
+		}
+		if found = stored != nil; found {
+			session = *stored
+		}
+	} else {
+		var err error
+		if session, found, err = s.Accounts.GetSession(accounts.HashToken(token)); err != nil {
+			return "", err
+		}
+	}
+	if !found || !time.Now().Before(session.ExpiresAt) {
+		return "", auth.ErrInvalidSession
+	}
+	return session.UserID, nil
+}
+
+// CreateOrganization создаёт организацию; создатель становится её владельцем.
+func (s *ShortenerService) CreateOrganization(ctx context.Context, userID, name string) (model.Workspace, error) {
+	ctx, span := startSpan(ctx, "CreateOrganization")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxOrganizationName {
+		return model.Workspace{}, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidOrganization, MaxOrganizationName)
+	}
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	org := model.Organization{ID: uuid.NewString(), Name: name, Created: now}
+	owner := model.Membership{OrgID: org.ID, UserID: userID, Role: orgs.RoleOwner, Created: now}
+	var err error
+	if s.Mode == "database" {
+		err = s.Repo.CreateOrganization(ctx, org, owner)
+	} else {
+		err = s.Orgs.CreateOrganization(org, owner)
+	}
+	if err != nil {
+		return model.Workspace{}, err
+	}
+	return model.Workspace{Organization: org, Role: orgs.RoleOwner}, nil
+}
+
+// ListOrganizations возвращает организации пользователя с его ролями.
+func (s *ShortenerService) ListOrganizations(ctx context.Context, userID string) ([]model.Workspace, error) {
+	ctx, span := startSpan(ctx, "ListOrganizations")
+	defer span.End()
+
+	if s.Mode == "database" {
+		return s.Repo.ListWorkspaces(ctx, userID)
+	}
+	return s.Orgs.ListWorkspaces(userID)
+}
+
+// authorizeOrg проверяет, что роль пользователя в организации не ниже required.
+// Тем, кто в организации не состоит, она не видна: возвращается ErrOrganizationNotFound.
+func (s *ShortenerService) authorizeOrg(ctx context.Context, orgID, userID, required string) (model.Membership, error) {
+	var (
+		m     model.Membership
+		found bool
+	)
+	if s.Mode == "database" {
+		stored, err := s.Repo.GetMembership(ctx, orgID, userID)
+		if err != nil {
+			return model.Membership{}, err
+		}
+		if found = stored != nil; found {
+			m = *stored
+		}
+	} else {
+		var err error
+		if m, found, err = s.Orgs.GetMembership(orgID, userID); err != nil {
+			return model.Membership{}, err
+		}
+	}
+	if !found {
+		return model.Membership{}, ErrOrganizationNotFound
+	}
+	if !orgs.Allows(m.Role, required) {
+		return model.Membership{}, fmt.Errorf("%w: %s role required", ErrForbidden, required)
+	}
+	return m, nil
+}
+
+// ListMembers возвращает участников организации. Доступно любому участнику.
+func (s *ShortenerService) ListMembers(ctx context.Context, userID, orgID string) ([]model.Membership, error) {
+	ctx, span := startSpan(ctx, "ListMembers")
+	defer span.End()
+
+	if _, err := s.authorizeOrg(ctx, orgID, userID, orgs.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.listMembers(ctx, orgID)
+}
+
+func (s *ShortenerService) listMembers(ctx context.Context, orgID string) ([]model.Membership, error) {
+	if s.Mode == "database" {
+		return s.Repo.ListMembers(ctx, orgID)
+	}
+	return s.Orgs.ListMembers(orgID)
+}
+
+// SetMember добавляет участника или меняет его роль. Доступно владельцам;
+// последний владелец не может понизить себя.
+func (s *ShortenerService) SetMember(ctx context.Context, userID, orgID, memberID, role string) (model.Membership, error) {
+	ctx, span := startSpan(ctx, "SetMember")
+	defer span.End()
+
+	if memberID == "" || orgs.IsOwner(memberID) || !orgs.ValidRole(role) {
+		return model.Membership{}, fmt.Errorf("%w: user_id and role (owner, editor or viewer) are required", ErrInvalidOrganization)
+	}
+	if _, err := s.authorizeOrg(ctx, orgID, userID, orgs.RoleOwner); err != nil {
+		return model.Membership{}, err
+	}
+	members, err := s.listMembers(ctx, orgID)
+	if err != nil {
+		return model.Membership{}, err
+	}
+	m := model.Membership{OrgID: orgID, UserID: memberID, Role: role, Created: time.Now().UTC().Truncate(time.Microsecond)}
+	for _, existing := range members {
+		if existing.UserID == memberID {
+			m.Created = existing.Created
+		}
+	}
+	if role != orgs.RoleOwner && lastOwner(members, memberID) {
+		return model.Membership{}, ErrLastOwner
+	}
+	if s.Mode == "database" {
+		err = s.Repo.SetMembership(ctx, m)
+	} else {
+		err = s.Orgs.SetMembership(m)
+	}
+	if err != nil {
+		return model.Membership{}, err
+	}
+	return m, nil
+}
+
+// RemoveMember исключает участника. Владельцы исключают любого, остальные —
+// только себя; последний владелец покинуть организацию не может.
+func (s *ShortenerService) RemoveMember(ctx context.Context, userID, orgID, memberID string) error {
+	ctx, span := startSpan(ctx, "RemoveMember")
+	defer span.End()
+
+	required := orgs.RoleOwner
+	if memberID == userID {
+		required = orgs.RoleViewer
+	}
+	if _, err := s.authorizeOrg(ctx, orgID, userID, required); err != nil {
+		return err
+	}
+	members, err := s.listMembers(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if lastOwner(members, memberID) {
+		return ErrLastOwner
+	}
+	var deleted bool
+	if s.Mode == "database" {
+		deleted, err = s.Repo.DeleteMembership(ctx, orgID, memberID)
+	} else {
+		deleted, err = s.Orgs.DeleteMembership(orgID, memberID)
+	}
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: user is not a member", ErrOrganizationNotFound)
+	}
+	return nil
+}
+
+// lastOwner сообщает, что userID — единственный владелец среди members.
+func lastOwner(members []model.Membership, userID string) bool {
+	owners, isOwner := 0, false
+	for _, m := range members {
+		if m.Role == orgs.RoleOwner {
+			owners++
+			isOwner = isOwner || m.UserID == userID
+		}
+	}
+	return isOwner && owners == 1
+}
+
+// ShortenForOrganization создаёт ссылку организации. Доступно редакторам и владельцам.
+func (s *ShortenerService) ShortenForOrganization(ctx context.Context, userID, orgID, originalURL string) (string, error) {
+	ctx, span := startSpan(ctx, "ShortenForOrganization")
+	defer span.End()
+
+	if _, err := s.authorizeOrg(ctx, orgID, userID, orgs.RoleEditor); err != nil {
+		return "", err
+	}
+	if err := s.checkBanned(ctx, userID); err != nil {
+		return "", err
+	}
+	return s.ShortenURL(ctx, orgs.Owner(orgID), originalURL)
+}
+
+// GetOrganizationURLs возвращает ссылки организации. Доступно любому участнику.
+func (s *ShortenerService) GetOrganizationURLs(ctx context.Context, userID, orgID string) ([]model.BatchResult, error) {
+	ctx, span := startSpan(ctx, "GetOrganizationURLs")
+	defer span.End()
+
+	if _, err := s.authorizeOrg(ctx, orgID, userID, orgs.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.GetUserURLs(ctx, orgs.Owner(orgID))
+}
+
+// DeleteOrganizationURLsAsync проверяет права и удаляет ссылки организации в фоне.
+// Доступно редакторам и владельцам.
+func (s *ShortenerService) DeleteOrganizationURLsAsync(ctx context.Context, userID, orgID string, ids []string) error {
+	ctx, span := startSpan(ctx, "DeleteOrganizationURLs")
+	defer span.End()
+
+	if _, err := s.authorizeOrg(ctx, orgID, userID, orgs.RoleEditor); err != nil {
+		return err
+	}
+	s.DeleteURLsAsync(orgs.Owner(orgID), ids)
+	return nil
+}
+
+// getBan возвращает запрет пользователя; nil, если его нет.
+func (s *ShortenerService) getBan(ctx context.Context, userID string) (*model.Ban, error) {
+	if s.Mode == "database" {
+		return s.Repo.GetBan(ctx, userID)
+	}
+	ban, found, err := s.Bans.GetBan(userID)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &ban, nil
+}
+
+// checkBanned возвращает ErrUserBanned, если пользователю запрещено создавать ссылки.
+func (s *ShortenerService) checkBanned(ctx context.Context, userID string) error {
+	if userID == "" {
+		return nil
+	}
+	ban, err := s.getBan(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if ban != nil {
+		return ErrUserBanned
+	}
+	return nil
+}
+
+// SearchLinks ищет ссылки всех пользователей по домену назначения, владельцу
+// и времени создания, начиная с недавно созданных.
+func (s *ShortenerService) SearchLinks(ctx context.Context, q model.LinkSearch) ([]*model.URLObject, error) {
+	ctx, span := startSpan(ctx, "SearchLinks")
+	defer span.End()
+
+	if q.Limit == 0 {
+		q.Limit = DefaultSearchLimit
+	}
+	if q.Limit < 0 || q.Limit > MaxSearchLimit || q.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit must be 1 to %d, offset must not be negative", ErrInvalidAdminRequest, MaxSearchLimit)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidAdminRequest)
+	}
+	if q.Domain != "" {
+		domain, err := moderation.NormalizeDomain(q.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAdminRequest, err)
+		}
+		q.Domain = domain
+	}
+
+	if s.Mode == "database" {
+		return s.Repo.SearchURLs(ctx, q)
+	}
+	var found []*model.URLObject
+	for _, e := range s.Store.Entries() {
+		switch {
+		case e.IsDeleted && !q.IncludeDeleted,
+			q.UserID != "" && e.UserID != q.UserID,
+			!q.From.IsZero() && e.Created.Before(q.From),
+			!q.To.IsZero() && !e.Created.Before(q.To),
+			q.Domain != "" && !moderation.MatchDomain(e.OriginalURL, q.Domain):
+			continue
+		}
+		found = append(found, entryToURLObject(e))
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if !found[i].Created.Equal(found[j].Created) {
+			return found[i].Created.After(found[j].Created)
+		}
+		return found[i].Shorten < found[j].Shorten
+	})
+	if q.Offset >= len(found) {
+		return nil, nil
+	}
+	found = found[q.Offset:]
+	if len(found) > q.Limit {
+		found = found[:q.Limit]
+	}
+	return found, nil
+}
+
+// DisableLink отключает любую ссылку; переход по ней перестаёт работать.
+// actor — администратор, от имени которого выполняется действие, для журнала.
+func (s *ShortenerService) DisableLink(ctx context.Context, actor, short, reason string) error {
+	ctx, span := startSpan(ctx, "DisableLink")
+	defer span.End()
+
+	if _, err := s.disableLink(ctx, short, "admin: "+reason); err != nil {
+		return err
+	}
+	s.Logger.Info("Link disabled by admin", zap.String("admin", actor), zap.String("short", short), zap.String("reason", reason))
+	return nil
+}
+
+// DeleteLink удаляет любую ссылку. Ссылка попадает в корзину владельца
+// отключённой, поэтому её восстановление не возвращает переходы.
+func (s *ShortenerService) DeleteLink(ctx context.Context, actor, short string) error {
+	ctx, span := startSpan(ctx, "DeleteLink")
+	defer span.End()
+
+	owner, err := s.disableLink(ctx, short, "admin: deleted")
+	if err != nil {
+		return err
+	}
+	s.DeleteURLs(ctx, owner, []string{short})
+	s.Logger.Info("Link deleted by admin", zap.String("admin", actor), zap.String("short", short), zap.String("owner", owner))
+	return nil
+}
+
+// disableLink отключает ссылку и возвращает её владельца.
+func (s *ShortenerService) disableLink(ctx context.Context, short, reason string) (string, error) {
+	var owner string
+	if s.Mode == "database" {
+		obj, err := s.Repo.GetURL(ctx, short)
+		if err != nil {
+			return "", err
+		}
+		if obj == nil {
+			return "", ErrURLNotFound
+		}
+		if err := s.Repo.DisableURLs(ctx, []string{short}, reason); err != nil {
+			return "", err
+		}
+		owner = obj.UserID
+	} else {
+		entry, ok := s.Store.GetEntry(short)
+		if !ok {
+			return "", ErrURLNotFound
+		}
+		s.Store.DisableWhere(func(e model.Entry) bool { return e.ShortURL == short }, reason)
+		owner = entry.UserID
+	}
+	s.invalidate(short)
+	return owner, nil
+}
+
+// BanUser запрещает пользователю создавать ссылки. Если disableLinks, его
+// активные ссылки отключаются. Возвращает запрет и число отключённых ссылок.
+func (s *ShortenerService) BanUser(ctx context.Context, actor, userID, reason string, disableLinks bool) (model.Ban, int, error) {
+	ctx, span := startSpan(ctx, "BanUser")
+	defer span.End()
+
+	if userID == "" {
+		return model.Ban{}, 0, fmt.Errorf("%w: user_id is required", ErrInvalidAdminRequest)
+	}
+	ban := model.Ban{UserID: userID, Reason: reason, By: actor, Created: time.Now().UTC().Truncate(time.Microsecond)}
+	var err error
+	if s.Mode == "database" {
+		err = s.Repo.SetBan(ctx, ban)
+	} else {
+		err = s.Bans.SetBan(ban)
+	}
+	if err != nil {
+		return model.Ban{}, 0, err
+	}
+
+	var disabled []string
+	if disableLinks {
+		why := "banned: " + reason
+		if s.Mode == "database" {
+			urls, err := s.Repo.GetURLsByUserID(ctx, userID)
+			if err != nil {
+				return model.Ban{}, 0, err
+			}
+			for _, u := range urls {
+				if !u.IsDisabled {
+					disabled = append(disabled, u.Shorten)
+				}
+			}
+			if err := s.Repo.DisableURLs(ctx, disabled, why); err != nil {
+				return model.Ban{}, 0, err
+			}
+		} else {
+			disabled = s.Store.DisableWhere(func(e model.Entry) bool { return e.UserID == userID }, why)
+		}
+		s.invalidate(disabled...)
+	}
+	s.Logger.Info("User banned", zap.String("admin", actor), zap.String("user", userID),
+		zap.String("reason", reason), zap.Int("disabled_links", len(disabled)))
+	return ban, len(disabled), nil
+}
+
+// UnbanUser снимает запрет. Отключённые при запрете ссылки остаются отключёнными.
+func (s *ShortenerService) UnbanUser(ctx context.Context, actor, userID string) error {
+	ctx, span := startSpan(ctx, "UnbanUser")
+	defer span.End()
+
+	var (
+		deleted bool
+		err     error
+	)
+	if s.Mode == "database" {
+		deleted, err = s.Repo.DeleteBan(ctx, userID)
+	} else {
+		deleted, err = s.Bans.DeleteBan(userID)
+	}
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBanNotFound
+	}
+	s.Logger.Info("User unbanned", zap.String("admin", actor), zap.String("user", userID))
+	return nil
+}
+
+// UserActivity возвращает сводку по пользователю: запрет, количество ссылок
+// по состояниям, последние ссылки, ключи API и организации.
+func (s *ShortenerService) UserActivity(ctx context.Context, userID string) (*model.UserActivity, error) {
+	ctx, span := startSpan(ctx, "UserActivity")
+	defer span.End()
+
+	if userID == "" {
+		return nil, fmt.Errorf("%w: user_id is required", ErrInvalidAdminRequest)
+	}
+	activity := &model.UserActivity{UserID: userID}
+	var err error
+	if activity.Ban, err = s.getBan(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var counts model.LinkCounts
+	if s.Mode == "database" {
+		if counts, err = s.Repo.CountUserLinks(ctx, userID); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, e := range s.Store.GetEntriesByUser(userID) {
+			counts.Total++
+			switch {
+			case e.IsDeleted:
+				counts.Deleted++
+			case e.IsDisabled:
+				counts.Disabled++
+			default:
+				counts.Active++
+			}
+		}
+	}
+	activity.Links, activity.Active, activity.Deleted, activity.Disabled = counts.Total, counts.Active, counts.Deleted, counts.Disabled
+
+	recent, err := s.SearchLinks(ctx, model.LinkSearch{UserID: userID, IncludeDeleted: true, Limit: ActivityRecentLinks})
+	if err != nil {
+		return nil, err
+	}
+	activity.Recent = recent
+	if len(recent) > 0 {
+		activity.LastCreated = &recent[0].Created
+	}
+	keys, err := s.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	activity.APIKeys = len(keys)
+	if activity.Organizations, err = s.ListOrganizations(ctx, userID); err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
@@ -0,0 +1,193 @@
+// Package sso реализует вход через OpenID Connect: authorization code с PKCE (S256).
+//
+// Адреса провайдера берутся из discovery-документа издателя. Ключи подписи
+// кешируются go-oidc и перечитываются с jwks_uri, только когда ID-токен подписан
+// неизвестным ключом. ID-токен проверяется по подписи, издателю, аудитории,
+// сроку действия и nonce.
+//
+// Незавершённые входы (state, code_verifier, nonce) хранятся в памяти процесса
+// не дольше FlowTTL.
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// DefaultFlowTTL — время, за которое пользователь должен вернуться от провайдера.
+const DefaultFlowTTL = 10 * time.Minute
+
+var (
+	// ErrUnknownState — state не выдавался, уже использован или истёк.
+	ErrUnknownState = errors.New("sso: unknown or expired state")
+	// ErrNonceMismatch — nonce ID-токена не совпадает с выданным.
+	ErrNonceMismatch = errors.New("sso: nonce mismatch")
+	// ErrNoIDToken — ответ провайдера не содержит id_token.
+	ErrNoIDToken = errors.New("sso: token response has no id_token")
+)
+
+// Config — параметры клиента провайдера.
+type Config struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes — дополнительные области помимо openid.
+	Scopes []string
+	// HTTPClient используется для discovery, JWKS и обмена кода; nil — http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Claims — данные пользователя из проверенного ID-токена.
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// flow — незавершённый вход.
+type flow struct {
+	verifier string
+	nonce    string
+	returnTo string
+	expires  time.Time
+}
+
+// Provider — клиент провайдера OpenID Connect.
+type Provider struct {
+	// FlowTTL ограничивает время между Start и Finish.
+	FlowTTL time.Duration
+
+	issuer   string
+	client   *http.Client
+	oauth    oauth2.Config
+	verifier *oidc.IDTokenVerifier
+
+	mu    sync.Mutex
+	flows map[string]flow
+}
+
+// New загружает discovery-документ издателя и создаёт клиента.
+func New(ctx context.Context, cfg Config) (*Provider, error) {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx = oidc.ClientContext(ctx, client)
+	// Ключи загружаются лениво с тем же клиентом, поэтому контекст не должен отменяться
+	keyCtx := oidc.ClientContext(context.Background(), client)
+
+	discovered, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("sso: discovery %s: %w", cfg.IssuerURL, err)
+	}
+	var endpoints struct {
+		JWKSURL string `json:"jwks_uri"`
+	}
+	if err := discovered.Claims(&endpoints); err != nil {
+		return nil, fmt.Errorf("sso: discovery %s: %w", cfg.IssuerURL, err)
+	}
+	keys := oidc.NewRemoteKeySet(keyCtx, endpoints.JWKSURL)
+
+	return &Provider{
+		FlowTTL: DefaultFlowTTL,
+		issuer:  cfg.IssuerURL,
+		client:  client,
+		oauth: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     discovered.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID}, cfg.Scopes...),
+		},
+		verifier: oidc.NewVerifier(cfg.IssuerURL, keys, &oidc.Config{ClientID: cfg.ClientID}),
+		flows:    make(map[string]flow),
+	}, nil
+}
+
+// Issuer возвращает издателя, субъекты которого сопоставляются пользователям.
+func (p *Provider) Issuer() string {
+	return p.issuer
+}
+
+// Start начинает вход: возвращает state и адрес, на который нужно перенаправить
+// пользователя. returnTo сохраняется до Finish.
+func (p *Provider) Start(returnTo string) (state, authURL string, err error) {
+	if state, err = randomString(); err != nil {
+		return "", "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	p.mu.Lock()
+	now := time.Now()
+	for s, f := range p.flows {
+		if now.After(f.expires) {
+			delete(p.flows, s)
+		}
+	}
+	p.flows[state] = flow{verifier: verifier, nonce: nonce, returnTo: returnTo, expires: now.Add(p.FlowTTL)}
+	p.mu.Unlock()
+
+	return state, p.oauth.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce)), nil
+}
+
+// Finish обменивает код на токены и проверяет ID-токен. State используется один раз.
+// Возвращает данные пользователя и returnTo, переданный в Start.
+func (p *Provider) Finish(ctx context.Context, state, code string) (Claims, string, error) {
+	p.mu.Lock()
+	f, ok := p.flows[state]
+	delete(p.flows, state)
+	p.mu.Unlock()
+	if !ok || time.Now().After(f.expires) {
+		return Claims{}, "", ErrUnknownState
+	}
+
+	ctx = oidc.ClientContext(ctx, p.client)
+	token, err := p.oauth.Exchange(ctx, code, oauth2.VerifierOption(f.verifier))
+	if err != nil {
+		return Claims{}, "", fmt.Errorf("sso: code exchange: %w", err)
+	}
+	raw, ok := token.Extra("id_token").(string)
+	if !ok || raw == "" {
+		return Claims{}, "", ErrNoIDToken
+	}
+	idToken, err := p.verifier.Verify(ctx, raw)
+	if err != nil {
+		return Claims{}, "", fmt.Errorf("sso: id token: %w", err)
+	}
+	if idToken.Nonce != f.nonce {
+		return Claims{}, "", ErrNonceMismatch
+	}
+
+	claims := Claims{Issuer: idToken.Issuer, Subject: idToken.Subject}
+	var extra struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idToken.Claims(&extra); err == nil {
+		claims.Email, claims.EmailVerified = extra.Email, extra.EmailVerified
+	}
+	return claims, f.returnTo, nil
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,128 @@
+package sso_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Totarae/URLShortener/internal/sso"
+	"github.com/Totarae/URLShortener/internal/sso/ssotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://shortener.test/api/auth/oidc/callback"
+
+func newProvider(t *testing.T) (*sso.Provider, *ssotest.Provider) {
+	t.Helper()
+	idp := ssotest.NewProvider(t)
+	p, err := sso.New(context.Background(), sso.Config{
+		IssuerURL:    idp.URL,
+		ClientID:     ssotest.ClientID,
+		ClientSecret: ssotest.ClientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"email"},
+	})
+	require.NoError(t, err)
+	return p, idp
+}
+
+// authorize проходит страницу входа провайдера и возвращает code и state из адреса возврата.
+func authorize(t *testing.T, authURL string) (code, state string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	require.Equal(t, redirectURL, location.Scheme+"://"+location.Host+location.Path)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func signIn(t *testing.T, p *sso.Provider, returnTo string) (sso.Claims, string, error) {
+	t.Helper()
+	state, authURL, err := p.Start(returnTo)
+	require.NoError(t, err)
+	code, returnedState := authorize(t, authURL)
+	require.Equal(t, state, returnedState)
+	return p.Finish(context.Background(), state, code)
+}
+
+func TestProvider_SignIn(t *testing.T) {
+	p, idp := newProvider(t)
+	idp.SignIn("subject-42", "ann@example.com")
+
+	state, authURL, err := p.Start("/dashboard")
+	require.NoError(t, err)
+	params, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", params.Query().Get("code_challenge_method"))
+	assert.NotEmpty(t, params.Query().Get("nonce"))
+	assert.Equal(t, "openid email", params.Query().Get("scope"))
+
+	code, _ := authorize(t, authURL)
+	claims, returnTo, err := p.Finish(context.Background(), state, code)
+	require.NoError(t, err)
+	assert.Equal(t, sso.Claims{Issuer: idp.URL, Subject: "subject-42", Email: "ann@example.com", EmailVerified: true}, claims)
+	assert.Equal(t, "/dashboard", returnTo)
+
+	// State одноразовый
+	_, _, err = p.Finish(context.Background(), state, code)
+	assert.ErrorIs(t, err, sso.ErrUnknownState)
+	_, _, err = p.Finish(context.Background(), "forged", code)
+	assert.ErrorIs(t, err, sso.ErrUnknownState)
+}
+
+func TestProvider_KeysCached(t *testing.T) {
+	p, idp := newProvider(t)
+
+	for range 3 {
+		_, _, err := signIn(t, p, "/")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, idp.JWKSRequests(), "ключи загружаются один раз")
+
+	// Токен с неизвестным kid заставляет перечитать ключи
+	idp.RotateKey()
+	_, _, err := signIn(t, p, "/")
+	require.NoError(t, err)
+	assert.Equal(t, 2, idp.JWKSRequests())
+}
+
+func TestProvider_RejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		err    error
+	}{
+		{name: "чужая аудитория", claims: map[string]any{"aud": "other-client"}},
+		{name: "чужой издатель", claims: map[string]any{"iss": "https://evil.example"}},
+		{name: "истёк", claims: map[string]any{"exp": time.Now().Add(-time.Minute).Unix()}},
+		{name: "подменён nonce", claims: map[string]any{"nonce": "replayed"}, err: sso.ErrNonceMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, idp := newProvider(t)
+			idp.SetClaims(tt.claims)
+			_, _, err := signIn(t, p, "/")
+			require.Error(t, err)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestProvider_ExpiredFlow(t *testing.T) {
+	p, _ := newProvider(t)
+	p.FlowTTL = -time.Second
+	_, _, err := signIn(t, p, "/")
+	assert.ErrorIs(t, err, sso.ErrUnknownState)
+}
@@ -1,9 +1,15 @@
 package storage
 
 import (
+	"errors"
+	"time"
+
 	"github.com/Totarae/URLShortener/internal/model"
 )
 
+// ErrOriginExists — для адреса назначения уже существует другая короткая ссылка.
+var ErrOriginExists = errors.New("origin already has a short code")
+
 // Storage определяет интерфейс для работы с хранилищем URL.
 type Storage interface {
 	// Save сохраняет сопоставление короткой и оригинальной ссылки.
@@ -16,4 +22,32 @@ type Storage interface {
 	GetByUser(userID string) map[string]string
 	// MarkDeleted удаляем по user id .
 	MarkDeleted(shortenIDs []string, userID string)
+	// GetEntry возвращает полную запись, включая удалённые.
+	GetEntry(short string) (model.Entry, bool)
+	// FindByOrigin возвращает запись с адресом назначения origin, включая удалённые;
+	// активная запись предпочтительнее удалённой.
+	FindByOrigin(origin string) (model.Entry, bool)
+	// SetRules заменяет правила перенаправления ссылки владельца.
+	SetRules(short, userID string, rules []model.RedirectRule) bool
+	// SetForcePreview включает или выключает обязательный предпросмотр ссылки владельца.
+	SetForcePreview(short, userID string, enabled bool) bool
+	// DisableWhere отключает активные ссылки, для которых match вернул true,
+	// и возвращает их идентификаторы.
+	DisableWhere(match func(model.Entry) bool, reason string) []string
+	// ActiveEntries возвращает неудалённые и неотключённые записи.
+	ActiveEntries() []model.Entry
+	// Entries возвращает все записи, включая удалённые и отключённые.
+	Entries() []model.Entry
+	// GetEntriesByUser возвращает все записи пользователя, включая удалённые.
+	GetEntriesByUser(userID string) []model.Entry
+	// SetHealth сохраняет результат проверки доступности ссылки.
+	SetHealth(short string, health model.LinkHealth) bool
+	// SetMetadata сохраняет метаданные страницы url, если ссылка всё ещё ведёт на неё.
+	SetMetadata(short, url string, meta model.LinkMetadata) bool
+	// UpdateOrigin меняет адрес назначения ссылки владельца и записывает ревизию.
+	UpdateOrigin(short, userID, origin string) (model.Revision, bool, error)
+	// Restore снимает пометку удаления со ссылок владельца, удалённых не раньше since.
+	Restore(shortenIDs []string, userID string, since time.Time) []string
+	// Reassign передаёт все ссылки пользователя from пользователю to.
+	Reassign(from, to string) int
 }
@@ -0,0 +1,134 @@
+// Package tracing настраивает OpenTelemetry: провайдер трассировки с выбранным
+// экспортёром, распространение контекста W3C и инструментирование HTTP,
+// gRPC и запросов к базе данных.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/Totarae/URLShortener/internal/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+// InstrumentationName — имя, под которым сервис создаёт собственные span.
+const InstrumentationName = "github.com/Totarae/URLShortener"
+
+// Экспортёры трассировки.
+const (
+	ExporterNone   = ""
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// Config задаёт экспорт трассировки.
+type Config struct {
+	ServiceName string
+	// Exporter — "stdout", "otlp" или пустая строка, чтобы отключить трассировку.
+	Exporter string
+	// Endpoint — адрес OTLP/gRPC коллектора, например "localhost:4317".
+	Endpoint string
+	// Insecure отключает TLS при подключении к коллектору.
+	Insecure bool
+	// SampleRatio — доля новых трасс, попадающих в выборку; решение родителя соблюдается.
+	SampleRatio float64
+	// Writer — назначение экспортёра stdout, по умолчанию os.Stdout.
+	Writer io.Writer
+}
+
+// Setup устанавливает глобальные провайдер трассировки и распространитель
+// контекста. Возвращаемая функция сбрасывает накопленные span и останавливает
+// экспортёр. При пустом Exporter трассировка остаётся no-op, но входящий
+// контекст по-прежнему распространяется дальше.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		w := cfg.Writer
+		if w == nil {
+			w = os.Stdout
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewInMemory устанавливает глобальный провайдер, синхронно пишущий все span
+// в память, и возвращает экспортёр для проверки в тестах.
+func NewInMemory() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return exporter
+}
+
+// Tracer возвращает трассировщик сервиса из глобального провайдера.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Middleware создаёт span для каждого HTTP-запроса, продолжая трассу из
+// заголовка traceparent. После маршрутизации span получает имя вида
+// "GET /api/user/urls/{id}" по шаблону маршрута chi.
+func Middleware(next http.Handler) http.Handler {
+	named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r)
+		route := middleware.RoutePattern(r)
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(attribute.String("http.route", route))
+	})
+	return otelhttp.NewHandler(named, "http.request")
+}
+
+// ServerOption подключает трассировку к gRPC-серверу; контекст берётся из метаданных traceparent.
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
@@ -0,0 +1,93 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Totarae/URLShortener/internal/service"
+	"github.com/Totarae/URLShortener/internal/tracing"
+	"github.com/Totarae/URLShortener/internal/util"
+	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+var exporter *tracetest.InMemoryExporter
+
+func TestMain(m *testing.M) {
+	// Глобальный провайдер устанавливается один раз на пакет.
+	exporter = tracing.NewInMemory()
+	os.Exit(m.Run())
+}
+
+func spanByName(t *testing.T, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, s := range exporter.GetSpans() {
+		if s.Name == name {
+			return s
+		}
+	}
+	t.Fatalf("span %q not found", name)
+	return tracetest.SpanStub{}
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	exporter.Reset()
+
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "data.json"))
+	svc := service.NewShortenerService(nil, store, zap.NewNop(), "file", "http://localhost:8080")
+	short, err := svc.ShortenURL(context.Background(), "user", "https://example.com/")
+	require.NoError(t, err)
+
+	r := chi.NewRouter()
+	r.Use(tracing.Middleware)
+	r.Get("/{id}", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = svc.ResolveURL(req.Context(), chi.URLParam(req, "id"))
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/"+short, nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	server := spanByName(t, "GET /{id}")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+
+	resolve := spanByName(t, "ShortenerService.ResolveURL")
+	assert.Equal(t, server.SpanContext.SpanID(), resolve.Parent.SpanID())
+	assert.Equal(t, server.SpanContext.TraceID(), resolve.SpanContext.TraceID())
+}
+
+func TestQueryTracer(t *testing.T) {
+	exporter.Reset()
+	var tracer tracing.QueryTracer
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL: "SELECT origin FROM urls WHERE shorten = $1",
+	})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("boom")})
+
+	span := spanByName(t, "select urls")
+	assert.Equal(t, codes.Error, span.Status.Code)
+	attrs := map[string]string{}
+	for _, kv := range span.Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "postgresql", attrs["db.system"])
+	assert.Equal(t, "SELECT origin FROM urls WHERE shorten = $1", attrs["db.statement"])
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(context.Background(), tracing.Config{Exporter: "zipkin"})
+	assert.Error(t, err)
+}
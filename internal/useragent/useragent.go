@@ -0,0 +1,87 @@
+// Package useragent выполняет грубую классификацию клиентов по заголовку User-Agent.
+package useragent
+
+import "strings"
+
+// Классы устройств, используемые в правилах перенаправления.
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceDesktop = "desktop"
+)
+
+// Device определяет класс устройства по строке User-Agent.
+// Всё, что не распознано как iOS или Android, считается десктопом.
+func Device(ua string) string {
+	ua = strings.ToLower(ua)
+	switch {
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return DeviceIOS
+	case strings.Contains(ua, "android"):
+		return DeviceAndroid
+	default:
+		return DeviceDesktop
+	}
+}
+
+// IsDevice сообщает, является ли строка известным классом устройства.
+func IsDevice(name string) bool {
+	switch name {
+	case DeviceIOS, DeviceAndroid, DeviceDesktop:
+		return true
+	}
+	return false
+}
+
+// Browser определяет семейство браузера по строке User-Agent.
+// Порядок проверок важен: Edge и Opera содержат маркер Chrome, а Chrome — Safari.
+func Browser(ua string) string {
+	ua = strings.ToLower(ua)
+	switch {
+	case ua == "":
+		return "Unknown"
+	case strings.Contains(ua, "bot"), strings.Contains(ua, "crawler"), strings.Contains(ua, "spider"):
+		return "Bot"
+	case strings.Contains(ua, "edg/"), strings.Contains(ua, "edge/"):
+		return "Edge"
+	case strings.Contains(ua, "opr/"), strings.Contains(ua, "opera"):
+		return "Opera"
+	case strings.Contains(ua, "yabrowser/"):
+		return "Yandex"
+	case strings.Contains(ua, "samsungbrowser/"):
+		return "Samsung Internet"
+	case strings.Contains(ua, "firefox/"), strings.Contains(ua, "fxios/"):
+		return "Firefox"
+	case strings.Contains(ua, "chrome/"), strings.Contains(ua, "crios/"), strings.Contains(ua, "chromium/"):
+		return "Chrome"
+	case strings.Contains(ua, "safari/"):
+		return "Safari"
+	case strings.Contains(ua, "curl/"), strings.Contains(ua, "wget/"), strings.Contains(ua, "go-http-client"):
+		return "CLI"
+	default:
+		return "Other"
+	}
+}
+
+// OS определяет семейство операционной системы по строке User-Agent.
+func OS(ua string) string {
+	ua = strings.ToLower(ua)
+	switch {
+	case ua == "":
+		return "Unknown"
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return "iOS"
+	case strings.Contains(ua, "android"):
+		return "Android"
+	case strings.Contains(ua, "windows"):
+		return "Windows"
+	case strings.Contains(ua, "mac os x"), strings.Contains(ua, "macintosh"):
+		return "macOS"
+	case strings.Contains(ua, "cros"):
+		return "ChromeOS"
+	case strings.Contains(ua, "linux"):
+		return "Linux"
+	default:
+		return "Other"
+	}
+}
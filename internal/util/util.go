@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Totarae/URLShortener/internal/model"
 	"github.com/Totarae/URLShortener/internal/storage"
@@ -47,6 +48,7 @@ func (s *URLStore) Save(short, original, userID string) {
 		OriginalURL: original,
 		UserID:      userID,
 		IsDeleted:   false,
+		Created:     time.Now(),
 	}
 	s.data[short] = entry
 
@@ -172,11 +174,264 @@ func (s *URLStore) MarkDeleted(shortenIDs []string, userID string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	now := time.Now()
 	for _, id := range shortenIDs {
 		entry, exists := s.data[id]
-		if exists && entry.UserID == userID {
+		if exists && entry.UserID == userID && !entry.IsDeleted {
 			entry.IsDeleted = true
+			entry.DeletedAt = &now
 			s.data[id] = entry
+
+			if err := s.AppendToFile(entry); err != nil {
+				log.Printf("Ошибка сохранения в файл: %v", err)
+			}
+		}
+	}
+}
+
+// GetEntry возвращает полную запись по короткому идентификатору, включая удалённые.
+func (s *URLStore) GetEntry(short string) (model.Entry, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	entry, exists := s.data[short]
+	return entry, exists
+}
+
+// FindByOrigin возвращает запись с адресом назначения origin, включая удалённые;
+// активная запись предпочтительнее удалённой.
+func (s *URLStore) FindByOrigin(origin string) (model.Entry, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var found model.Entry
+	exists := false
+	for _, entry := range s.data {
+		if entry.OriginalURL != origin {
+			continue
+		}
+		if !entry.IsDeleted {
+			return entry, true
+		}
+		found, exists = entry, true
+	}
+	return found, exists
+}
+
+// SetRules заменяет правила перенаправления ссылки, если она принадлежит пользователю.
+func (s *URLStore) SetRules(short, userID string, rules []model.RedirectRule) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.data[short]
+	if !exists || entry.IsDeleted || entry.UserID != userID {
+		return false
+	}
+	entry.Rules = rules
+	s.data[short] = entry
+
+	// Последняя запись в файле побеждает при загрузке
+	if err := s.AppendToFile(entry); err != nil {
+		log.Printf("Ошибка сохранения в файл: %v", err)
+	}
+	return true
+}
+
+// SetForcePreview включает или выключает обязательный предпросмотр ссылки владельца.
+func (s *URLStore) SetForcePreview(short, userID string, enabled bool) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.data[short]
+	if !exists || entry.IsDeleted || entry.UserID != userID {
+		return false
+	}
+	entry.ForcePreview = enabled
+	s.data[short] = entry
+
+	if err := s.AppendToFile(entry); err != nil {
+		log.Printf("Ошибка сохранения в файл: %v", err)
+	}
+	return true
+}
+
+// DisableWhere отключает активные ссылки, для которых match вернул true.
+func (s *URLStore) DisableWhere(match func(model.Entry) bool, reason string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var disabled []string
+	for short, entry := range s.data {
+		if entry.IsDeleted || entry.IsDisabled || !match(entry) {
+			continue
+		}
+		entry.IsDisabled = true
+		entry.DisabledReason = reason
+		s.data[short] = entry
+		disabled = append(disabled, short)
+
+		if err := s.AppendToFile(entry); err != nil {
+			log.Printf("Ошибка сохранения в файл: %v", err)
+		}
+	}
+	return disabled
+}
+
+// ActiveEntries возвращает неудалённые и неотключённые записи.
+func (s *URLStore) ActiveEntries() []model.Entry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]model.Entry, 0, len(s.data))
+	for _, entry := range s.data {
+		if !entry.IsDeleted && !entry.IsDisabled {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// Entries возвращает все записи, включая удалённые и отключённые.
+func (s *URLStore) Entries() []model.Entry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	result := make([]model.Entry, 0, len(s.data))
+	for _, entry := range s.data {
+		result = append(result, entry)
+	}
+	return result
+}
+
+// GetEntriesByUser возвращает все записи пользователя, включая удалённые.
+func (s *URLStore) GetEntriesByUser(userID string) []model.Entry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var result []model.Entry
+	for _, entry := range s.data {
+		if entry.UserID == userID {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// SetHealth сохраняет результат проверки доступности ссылки.
+// В файл запись дописывается только при смене статуса, чтобы периодические
+// проверки не раздували журнал; полный снимок сохраняется при остановке.
+func (s *URLStore) SetHealth(short string, health model.LinkHealth) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.data[short]
+	if !exists {
+		return false
+	}
+	changed := entry.Health == nil || entry.Health.Status != health.Status
+	entry.Health = &health
+	s.data[short] = entry
+
+	if changed {
+		if err := s.AppendToFile(entry); err != nil {
+			log.Printf("Ошибка сохранения в файл: %v", err)
+		}
+	}
+	return true
+}
+
+// SetMetadata сохраняет метаданные страницы url, если ссылка всё ещё ведёт на неё.
+func (s *URLStore) SetMetadata(short, url string, meta model.LinkMetadata) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.data[short]
+	if !exists || entry.OriginalURL != url {
+		return false
+	}
+	entry.Metadata = &meta
+	s.data[short] = entry
+
+	if err := s.AppendToFile(entry); err != nil {
+		log.Printf("Ошибка сохранения в файл: %v", err)
+	}
+	return true
+}
+
+// UpdateOrigin меняет адрес назначения ссылки владельца и записывает ревизию.
+// Сведения о доступности и метаданные прежнего адреса сбрасываются.
+func (s *URLStore) UpdateOrigin(short, userID, origin string) (model.Revision, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, exists := s.data[short]
+	if !exists || entry.IsDeleted || entry.UserID != userID {
+		return model.Revision{}, false, nil
+	}
+	rev := model.Revision{ChangedBy: userID, ChangedAt: time.Now(), OldURL: entry.OriginalURL, NewURL: origin}
+	if rev.OldURL == origin {
+		return rev, true, nil
+	}
+	// Адрес назначения уникален, как в базе данных, включая удалённые ссылки
+	for id, other := range s.data {
+		if id != short && other.OriginalURL == origin {
+			return model.Revision{}, false, storage.ErrOriginExists
+		}
+	}
+	entry.OriginalURL = origin
+	entry.Health = nil
+	entry.Metadata = nil
+	entry.Revisions = append(entry.Revisions, rev)
+	s.data[short] = entry
+
+	if err := s.AppendToFile(entry); err != nil {
+		log.Printf("Ошибка сохранения в файл: %v", err)
+	}
+	return rev, true, nil
+}
+
+// Restore снимает пометку удаления со ссылок владельца, удалённых не раньше since.
+// Возвращает идентификаторы восстановленных ссылок.
+func (s *URLStore) Restore(shortenIDs []string, userID string, since time.Time) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var restored []string
+	for _, id := range shortenIDs {
+		entry, exists := s.data[id]
+		if !exists || entry.UserID != userID || !entry.IsDeleted || entry.DeletedAt == nil || entry.DeletedAt.Before(since) {
+			continue
+		}
+		entry.IsDeleted = false
+		entry.DeletedAt = nil
+		s.data[id] = entry
+		restored = append(restored, id)
+
+		if err := s.AppendToFile(entry); err != nil {
+			log.Printf("Ошибка сохранения в файл: %v", err)
+		}
+	}
+	return restored
+}
+
+// Reassign передаёт все ссылки пользователя from пользователю to.
+// Возвращает число переданных ссылок.
+func (s *URLStore) Reassign(from, to string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	moved := 0
+	for short, entry := range s.data {
+		if entry.UserID != from {
+			continue
+		}
+		entry.UserID = to
+		s.data[short] = entry
+		moved++
+
+		if err := s.AppendToFile(entry); err != nil {
+			log.Printf("Ошибка сохранения в файл: %v", err)
 		}
 	}
+	return moved
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/Totarae/URLShortener/internal/model"
 	"github.com/Totarae/URLShortener/internal/util"
@@ -85,3 +86,63 @@ func TestSaveURL(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, original, got)
 }
+
+// Тест восстановления удалённой ссылки с учётом срока хранения и перезагрузки из файла
+func TestURLStore_Restore(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "trash.json")
+	store := util.NewURLStore(tmpFile)
+
+	store.Save("r1", "https://ya.ru", "owner")
+	store.Save("r2", "https://vk.com", "owner")
+	store.MarkDeleted([]string{"r1", "r2"}, "owner")
+
+	entry, ok := store.GetEntry("r1")
+	assert.True(t, ok)
+	assert.True(t, entry.IsDeleted)
+	assert.NotNil(t, entry.DeletedAt)
+
+	// Удаление сохраняется в файл и переживает перезапуск
+	reloaded := util.NewURLStore(tmpFile)
+	_, ok = reloaded.Get("r1")
+	assert.False(t, ok)
+
+	assert.Empty(t, reloaded.Restore([]string{"r1"}, "stranger", time.Time{}))
+	assert.Empty(t, reloaded.Restore([]string{"r1"}, "owner", time.Now().Add(time.Hour)))
+	assert.Equal(t, []string{"r1"}, reloaded.Restore([]string{"r1", "missing"}, "owner", time.Now().Add(-time.Hour)))
+
+	got, ok := reloaded.Get("r1")
+	assert.True(t, ok)
+	assert.Equal(t, "https://ya.ru", got)
+	_, ok = reloaded.Get("r2")
+	assert.False(t, ok)
+}
+
+func TestURLStore_Reassign(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "claim.json")
+	store := util.NewURLStore(tmpFile)
+	store.Save("a1", "https://ya.ru", "anon")
+	store.Save("a2", "https://vk.com", "anon")
+	store.Save("o1", "https://go.dev", "other")
+
+	assert.Equal(t, 2, store.Reassign("anon", "account"))
+	assert.Empty(t, store.GetByUser("anon"))
+
+	// Смена владельца сохраняется в файл
+	reloaded := util.NewURLStore(tmpFile)
+	assert.Len(t, reloaded.GetByUser("account"), 2)
+	assert.Len(t, reloaded.GetByUser("other"), 1)
+}
+
+func TestURLStore_FindByOrigin(t *testing.T) {
+	store := util.NewURLStore(filepath.Join(t.TempDir(), "origin.json"))
+	store.Save("d1", "https://ya.ru", "u1")
+	store.MarkDeleted([]string{"d1"}, "u1")
+	store.Save("a1", "https://ya.ru", "u2")
+
+	entry, ok := store.FindByOrigin("https://ya.ru")
+	assert.True(t, ok)
+	assert.Equal(t, "a1", entry.ShortURL, "активная запись предпочтительнее удалённой")
+
+	_, ok = store.FindByOrigin("https://vk.com")
+	assert.False(t, ok)
+}